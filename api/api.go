@@ -8,28 +8,113 @@ import (
 	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for DialectQuote.
+const (
+	DialectQuoteDoubleQuote DialectQuote = "\""
+	DialectQuoteNone        DialectQuote = "none"
+	DialectQuoteSingleQuote DialectQuote = "'"
+)
+
+// Defines values for SourceFormat.
+const (
+	Csv  SourceFormat = "csv"
+	Ods  SourceFormat = "ods"
+	Xlsx SourceFormat = "xlsx"
+)
+
+// Dialect How a CSV file's records and fields are written.
+type Dialect struct {
+	// Comment The character that starts a comment line, or none if there aren't any comment lines
+	Comment *string `json:"comment,omitempty"`
+
+	// Delimiter The character between fields
+	Delimiter *string `json:"delimiter,omitempty"`
+
+	// LazyQuotes Whether quotes can appear in unquoted fields
+	LazyQuotes *bool `json:"lazyQuotes,omitempty"`
+
+	// Quote The character fields are quoted with, or none if fields can't be quoted
+	Quote *DialectQuote `json:"quote,omitempty"`
+}
+
+// DialectQuote The character fields are quoted with, or none if fields can't be quoted
+type DialectQuote string
+
+// Job A JSON document representing the progress of an asynchronous validation job.
+type Job struct {
+	Created  string  `json:"created"`
+	Error    *string `json:"error,omitempty"`
+	File     *string `json:"file,omitempty"`
+	Finished *string `json:"finished,omitempty"`
+	Id       string  `json:"id"`
+	Profile  string  `json:"profile"`
+	Started  *string `json:"started,omitempty"`
+
+	// Status One of 'queued', 'running', 'completed', or 'failed'
+	Status string `json:"status"`
+}
+
+// Profile A JSON document representing a validation profile.
+type Profile struct {
+	LastUpdate  *string      `json:"lastUpdate,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Validations []Validation `json:"validations"`
+}
+
 // Report A JSON document encapsulating the results of a validation check.
 type Report struct {
-	Profile  *string `json:"profile,omitempty"`
-	Time     *string `json:"time,omitempty"`
+	Profile *string `json:"profile,omitempty"`
+	Source  *Source `json:"source,omitempty"`
+
+	// Sources How each file was read, when a report combines more than one file
+	Sources  *map[string]Source `json:"sources,omitempty"`
+	Time     *string            `json:"time,omitempty"`
 	Warnings *[]struct {
-		Column  *int    `json:"column,omitempty"`
-		Header  *string `json:"header,omitempty"`
+		// Cell The address of the cell the warning was found in, when the file was read from a workbook
+		Cell   *string `json:"cell,omitempty"`
+		Column *int    `json:"column,omitempty"`
+
+		// File The file the warning was found in, when a report combines more than one file
+		File   *string `json:"file,omitempty"`
+		Header *string `json:"header,omitempty"`
+
+		// Line The line a CSV record that couldn't be parsed was found on, which can differ from its row
+		Line    *int    `json:"line,omitempty"`
 		Message *string `json:"message,omitempty"`
 		Row     *int    `json:"row,omitempty"`
 		Value   *string `json:"value,omitempty"`
 	} `json:"warnings,omitempty"`
 }
 
+// Source How a validated file was read.
+type Source struct {
+	// Dialect How a CSV file's records and fields are written.
+	Dialect *Dialect `json:"dialect,omitempty"`
+
+	// Encoding The character encoding a CSV file was transcoded to UTF-8 from
+	Encoding *string `json:"encoding,omitempty"`
+
+	// Format The format the file was read from
+	Format SourceFormat `json:"format"`
+
+	// Sheet The sheet a workbook's data was read from
+	Sheet *string `json:"sheet,omitempty"`
+}
+
+// SourceFormat The format the file was read from
+type SourceFormat string
+
 // Status A JSON document representing the service's runtime status. It's intentionally brief, for now.
 type Status struct {
 	Fester     string `json:"fester"`
@@ -37,32 +122,112 @@ type Status struct {
 	Service    string `json:"service"`
 }
 
-// StatusCreated A JSON document encapsulating the results of a validation check.
-type StatusCreated = Report
+// Validation A validation check that is run as a part of a profile.
+type Validation struct {
+	Description *string `json:"description,omitempty"`
+
+	// Name The name of a registered validator
+	Name string `json:"name"`
+
+	// Options Options that configure the validator, which vary from validator to validator
+	Options *map[string]interface{} `json:"options,omitempty"`
+}
+
+// JobAccepted A JSON document representing the progress of an asynchronous validation job.
+type JobAccepted = Job
+
+// JobOK A JSON document representing the progress of an asynchronous validation job.
+type JobOK = Job
+
+// ProfileCreated A JSON document representing a validation profile.
+type ProfileCreated = Profile
+
+// ProfileOK A JSON document representing a validation profile.
+type ProfileOK = Profile
+
+// ProfilesOK defines model for ProfilesOK.
+type ProfilesOK = []Profile
+
+// ReportOK A JSON document encapsulating the results of a validation check.
+type ReportOK = Report
 
 // StatusOK A JSON document representing the service's runtime status. It's intentionally brief, for now.
 type StatusOK = Status
 
 // UploadCSVMultipartBody defines parameters for UploadCSV.
 type UploadCSVMultipartBody struct {
-	// CsvFile The CSV file to be uploaded
+	// CsvFile The CSV file, or Excel (.xlsx) or OpenDocument (.ods) workbook, to be uploaded
 	CsvFile openapi_types.File `json:"csvFile"`
 
+	// Dialect The CSV dialect to read, as a delimiter (e.g., tab or ;) or a JSON Dialect object (default, the dialect that's sniffed from the file)
+	Dialect *string `json:"dialect,omitempty"`
+
 	// Profile The name of the profile the validation process should use
 	Profile string `json:"profile"`
+
+	// Sheet The workbook sheet to validate, by name or number (default, the first visible sheet)
+	Sheet *string `json:"sheet,omitempty"`
+
+	// Tolerant Whether to keep reading past CSV records that can't be parsed, so all of them are reported and the records that can be parsed are validated (default, false)
+	Tolerant *bool `json:"tolerant,omitempty"`
 }
 
+// ValidatePathFormdataBody defines parameters for ValidatePath.
+type ValidatePathFormdataBody struct {
+	// Dialect The CSV dialect to read, as a delimiter (e.g., tab or ;) or a JSON Dialect object (default, the dialect that's sniffed from the file)
+	Dialect *string `form:"dialect,omitempty" json:"dialect,omitempty"`
+
+	// Path The path, relative to the HOST_DIR, of a CSV file or a folder of CSV files
+	Path string `form:"path" json:"path"`
+
+	// Profile The name of the profile the validation process should use
+	Profile string `form:"profile" json:"profile"`
+
+	// Sheet The workbook sheet to validate, by name or number (default, the first visible sheet)
+	Sheet *string `form:"sheet,omitempty" json:"sheet,omitempty"`
+
+	// Tolerant Whether to keep reading past CSV records that can't be parsed, so all of them are reported and the records that can be parsed are validated (default, false)
+	Tolerant *bool `form:"tolerant,omitempty" json:"tolerant,omitempty"`
+}
+
+// PutProfileJSONRequestBody defines body for PutProfile for application/json ContentType.
+type PutProfileJSONRequestBody = Profile
+
 // UploadCSVMultipartRequestBody defines body for UploadCSV for multipart/form-data ContentType.
 type UploadCSVMultipartRequestBody UploadCSVMultipartBody
 
+// ValidatePathFormdataRequestBody defines body for ValidatePath for application/x-www-form-urlencoded ContentType.
+type ValidatePathFormdataRequestBody ValidatePathFormdataBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Gets the status of a validation job
+	// (GET /jobs/{jobID})
+	GetJob(ctx echo.Context, jobID string) error
+	// Gets the report of a validation job
+	// (GET /jobs/{jobID}/report)
+	GetJobReport(ctx echo.Context, jobID string) error
+	// Lists the validation profiles
+	// (GET /profiles)
+	ListProfiles(ctx echo.Context) error
+	// Deletes a validation profile
+	// (DELETE /profiles/{profileID})
+	DeleteProfile(ctx echo.Context, profileID string) error
+	// Gets a validation profile
+	// (GET /profiles/{profileID})
+	GetProfile(ctx echo.Context, profileID string) error
+	// Creates or updates a validation profile
+	// (PUT /profiles/{profileID})
+	PutProfile(ctx echo.Context, profileID string) error
 	// Gets the validation service's current status
 	// (GET /status)
 	GetStatus(ctx echo.Context) error
 	// Uploads and validates CSV files
 	// (POST /upload/csv)
 	UploadCSV(ctx echo.Context) error
+	// Validates CSV files that are already on the server
+	// (POST /validate/path)
+	ValidatePath(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -70,6 +235,95 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetJob converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJob(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "jobID" -------------
+	var jobID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobID", ctx.Param("jobID"), &jobID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter jobID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetJob(ctx, jobID)
+	return err
+}
+
+// GetJobReport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetJobReport(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "jobID" -------------
+	var jobID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "jobID", ctx.Param("jobID"), &jobID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter jobID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetJobReport(ctx, jobID)
+	return err
+}
+
+// ListProfiles converts echo context to params.
+func (w *ServerInterfaceWrapper) ListProfiles(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListProfiles(ctx)
+	return err
+}
+
+// DeleteProfile converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteProfile(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "profileID", ctx.Param("profileID"), &profileID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteProfile(ctx, profileID)
+	return err
+}
+
+// GetProfile converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProfile(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "profileID", ctx.Param("profileID"), &profileID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProfile(ctx, profileID)
+	return err
+}
+
+// PutProfile converts echo context to params.
+func (w *ServerInterfaceWrapper) PutProfile(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "profileID" -------------
+	var profileID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "profileID", ctx.Param("profileID"), &profileID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter profileID: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutProfile(ctx, profileID)
+	return err
+}
+
 // GetStatus converts echo context to params.
 func (w *ServerInterfaceWrapper) GetStatus(ctx echo.Context) error {
 	var err error
@@ -88,6 +342,15 @@ func (w *ServerInterfaceWrapper) UploadCSV(ctx echo.Context) error {
 	return err
 }
 
+// ValidatePath converts echo context to params.
+func (w *ServerInterfaceWrapper) ValidatePath(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ValidatePath(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -116,32 +379,74 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/jobs/:jobID", wrapper.GetJob)
+	router.GET(baseURL+"/jobs/:jobID/report", wrapper.GetJobReport)
+	router.GET(baseURL+"/profiles", wrapper.ListProfiles)
+	router.DELETE(baseURL+"/profiles/:profileID", wrapper.DeleteProfile)
+	router.GET(baseURL+"/profiles/:profileID", wrapper.GetProfile)
+	router.PUT(baseURL+"/profiles/:profileID", wrapper.PutProfile)
 	router.GET(baseURL+"/status", wrapper.GetStatus)
 	router.POST(baseURL+"/upload/csv", wrapper.UploadCSV)
+	router.POST(baseURL+"/validate/path", wrapper.ValidatePath)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xW247bNhD9lQFbYF+0tvbWInpLF0mwvSRFnOal7QMtjSwmFKnMDO04gT+m39IfK0jJ",
-	"l7XVOijypgt55szMmUN+VqVvO+/QCavisyLkzjvG9PLgBMlpO0NaIj0j8hQ/l94JOomPgh9l2lltXHzj",
-	"ssFWxyf8qNvOoirUmwaBRUtgIPwQkAVqbSxWMMdSB0aQuMIaadYgfokMlalgsSZUmZJ1F0FYyLiF2mw2",
-	"maqQSzKdGO96eEJYaQbtwAx8gRNhwMR4k6mXXp774Kr/n8LAHSsgZB+oRLj4Zf16eL6A0gdbgfMCc4Q6",
-	"xvpC9mPIMZ0IpecWQfwecpOpWarlPaEWrI5S0V1nTakj+PQd+6OEviWsVaG+me47Pu3/8vQ1dp5kjOJT",
-	"2EoCjKsSuluknh0Sj7uh0QxzRAflwG5H99VPX41pD3iGqTRagFACOQYNP85evQQ/f4elwMpIsxWkcbWn",
-	"NrFQEW+IESkMBSk+nwRJYJUvQ4tOAF2pOw72sCwcrDD4GjQstTVVCgBlg+X7icpUR75DEtPPWEe+NlFm",
-	"h4qrsNbByqmEMiWmPVp8nV/fXeY3l1f5m6urIv+uuMkn+fd3V9dPbq6fXOa3RZ6PAa00OeMWiYQRbLds",
-	"DriV3obWPYp2t0OKw7bANF4N6grpaGiMWByL2yKzXhzlkAazgLLRpEtBgtoTPHv1c697MA5KtHYMjvzq",
-	"PMGltuEo4j0ZFuM0vPDu099/Wfz0hxsZ2d2XXj5q/0ET6fX4ikGjZ8VD2BEyup12om+ZEi8YKLjY6UGp",
-	"E3iQC07+5iKWtnYNczJYZ6lSzq9OlVXH4Tzqin8/VsMoQF6zYHt2daY+Xi78pdNRheq5sTjrN8bx6dmf",
-	"jxjbhh+Coehgv+/2ZVvKjxj9eVLguD+O7liBDwZugO3NIE0fw/3sLSRsCIxJVz4QVGZhRFtYeXpfW7/i",
-	"WExJAi7U2z3ibEB8+uuDytQSifuw+SSfXMUK+A6d7owq1M0kn9zGlmhpUjemvBPFAkeM5U1jGNBVnTfu",
-	"P73rwLRAz32QXjm9o/n6UEcxjaiItPihUoV6gTKIM3t81F/n+b85727ddGfmm0zdfcmGsQtEMtrQtprW",
-	"PSFOlE8bd8FQBqI4KLw1/UxNQ2e9rqYlL5NheT5bTBZNEmvpcHUYpyNfIkcp7OYvxHMJKxhcGbSrkmb6",
-	"oMkhHhf0t/TjfvZW9ZpGlh98tT467tpgxXSaZBp7d1lp0Y9PvCPf5eXz4Uw4vS9sJTxcDHpmGK8bvS5U",
-	"oebGxeqOTPrBaXOKHId6q6BtAY5asy0ZN+m+ExjPTvc2mX3w8Yne7xEKuDnR59WX6vN+f/u4zW/P73p8",
-	"Ofx60u6lwUlDQwnxwIFi1pt/AgAA//8k6Ir/fwsAAA==",
+	"H4sIAAAAAAAC/+xb/3LbNvJ/lf2y3xklM9QvO24b5a/UTlrnco0vTtKZSzIXiFxKiCmAAUDLasYPc89y",
+	"L3azAEiRIhTJrtNrb+4/RwQWi93P/kY+R4lcFFKgMDqafI4KptgCDSr7r2dyenpyRj/Rv1LUieKF4VJE",
+	"k+ixgNMTyKQCM0e4ZDlPGX2Cj3IaxRGnNQUz8yiOBFtgNIk+ErUojhR+KrnCNJoYVWIc6WSOC0YnmFVB",
+	"C7VRXMyi6+s4OlMy4zlu5eLVHIkNmVkuCrcajIRSY5iLoqJ4I06uabEupNBoJfMDS1/ipxK1eaKUVPRT",
+	"IoVBYexuvDLDImd0/OcGVbxiiyInwq/FhZBLUclNKuj9dXU8x+SiF8Wh07vXVu58WDINQhpgU3fzqZVD",
+	"glpjClNMWKkRuFvHhT0wuo6jU2FQCZafo7pEdbtbEBvaMFPqmpuM8bxxLmlF59zMV2DkJWpIeQqzlcI9",
+	"b6nQ8s0EcM8vaMswoOX4OiaQPk4SLAymGxdgRZHzxKJy+FHLjWv8v8IsmkTfDNcWMHRf9fCZnIYYegwV",
+	"CICL1JIWM2Ab6Ic50zBFFMA8XzFMSwNzr6gVGsi44HqOaRRHc2Spt7fn0nEbxnnuvwIzsJzzZG6l+1FO",
+	"e7rSQsIE6T8hIFniIa0NP8qpHn6bjZNxeoj977PRtP8gOTrqP2QPsD9Kvp0epOPsIfsuqCQn8Rd/+R1l",
+	"bebMgEJTKqGBwbPzFz+DnH7ExMCSm3lHAbU8CB4/S/NUliK9PcI9tDEljmSpEiRjfen/7kEiyzy1mp0i",
+	"ZHTWDU24STlgzY7k2hkeK2R3CXZPdn/AmxbrldOtUZ94/tYc3yFa9mP2RogpKpI1v/qGDHODC7035zU4",
+	"mFJsdYub2H0U87qXsJB/iYVU5g6F7gjehFMHEdpFfDIgojkSXDYyhes4ogjEE3wt2CXjOQH/9rZK7vdT",
+	"iSUC15CVef4IjFoBmzEuIGcG1b62qR1XRMdICdNSUwjzLr1pAuSOzZyW8YXV7rn1PT/L4zXzXxaaLhMK",
+	"18TuClhCmUGO6QxJ4dUha+uqJLk+6g41fe795m+yLh+MuMikWlgubC7nzyAWTjjLMQmI5ie5BAbH52+A",
+	"0NzToDCRKqUMIIWMY05/UlKguDEoBlEcFUoWqAx3iVkiF4ug0EmnyZwplhhUXu6GKaMdOmkT5FxgDFKB",
+	"kAKB26RSIR0oegaYWLVW6ihuoO+bLrBIhDlfcINqFz9TNEvSrrtii/CjEOGc/br6WykN6i7lX+ZIfMMn",
+	"+93mBKwokCngAkphf04DJ2Us11ifNZUyRyboMLtj1w0ayvEnEBZa0vRLEkbSnFbriAVRLqLJ2+hdFMUR",
+	"JcC0I3rflIL91K0P/C8OfT4bDBmcxWgqk9JqT2GhUKOoo1mh5Eyh1tZZCWB6JZK5kkKWesNhBRC3Dsdr",
+	"dg9GB0f90WF/PHo1Hk9G304OR4PRd0fjg4eHBw/7oweT0SikVqwc35qSkOsiQbtcwFZd3uFPwJcSIXK0",
+	"oE0tSUx/KdWF7mtOPw0SfRne6fPTHbca73MrvkHmdqlnXJVubWInz8/g3LAsC+2wFr6Pbsa7b+FTyg64",
+	"Xggk2PRs2El7MfRUKQQXM/qz9tY9awk9Vx71Wgbulwfxva5Q35IYay7WwohrAL4PmMPZWmQ3MIlQetQF",
+	"fs60eV2kzGBIvmMr36PJ4WgyGv09JFBXkO+tyzVLeu+c6029J5B2taXbJB+SpM+BdgoSRcIKXebNVFmX",
+	"uXG+pSlYW6V1xRpEeYoZK3MTxKUtHHYGdreqXm+PYmnKiRWWn7VY2I9QN3IjS+Y2cNsyRiFLY1jOqRCu",
+	"ksFELqYUOmEhlU0kBFBw8EjuCN2mVHfhWJdMkY21kbPhxzHPw0GOpWkVHEiftND+4YnayzrHzIW/sJm7",
+	"S9WCgEzJBTAg5zuV8qLlAc7niGb8fyfjByHeE5mXC9ESw1G9jAuDM1RNb9+9gOVkB8d7qqgVSoY2lGyL",
+	"Ia6xsZGjc5MHfTslVGHm6YvPCV0y6JI3W3X7RKJgSmPauJUUsW+RUPaT8ixD5TTAjQYll82bfBcS5gK1",
+	"ZrMN8Nm6ZNJMeqSCJy+e17K02Ahdj47cqcBLlpcbJx4rrg0XDH6U4td//TPHX9+J/RKhjrPrrDivHUco",
+	"B/eOCtM2jrv+Kl3n8l/yG1XKT1mOSGRKjO9IKat1jYrA8mEUEzqRKaZgJLx+9bT/vVVuC56/cJHKpe6P",
+	"D44OghmOrU222Iv9tsWIG/mqA/5Vrq+iOJKpbqesW8xCk7GHz7WfGk6ipyFlhnXP3/AcO1MHf9lQXDvf",
+	"ktbszJl9gUw1WinIUfu6bwCnpqdtv1a44JKvYKo4ZrG1FyGXXRBlqM2mr7BOMpjT6pU2uNi5Oo6u+jPZ",
+	"943/pzzHc7eRtOC4333ihiSrfXHFcoujkIAbOUhAyJv5gHNu3AoVmAZGzs03UbZmYi2iLfchRcbVQq8L",
+	"WRAStKH+Efmt2tZIX8Ac2rb5sCpf6+KWvjgWFc44iWXd6JGqBdgnL57bMUfoAFnU2V04M3FTmo30222q",
+	"goLI+KxU2BxKSVUFg0umVi4M1J/AyDCnnyPBGDHzNjoYjx9+H8XR+HB0OIred33pBkasoLpQoGXUE9kB",
+	"g6rx5C5EstK1+9NQUqDjAmSpIOUzblhu3UWWy6UmZBgbYScN2IFvsMHjs9Moji5RaXfsaDAajJ3gUbCC",
+	"R5PocDAaUBJCMzOrCDcs+Gxnd9f0wyzsvLgGFGkhufhia6jREwI2laXplt+BgpsQYH84TaNJ9COaZ3bG",
+	"2BxWvg1Hn/WSYWOYef1+Y5x3MBpti1/1uqEbe1zH0YPRg92r23OH6zg62ueM0FTO9s7KxYKplbu9a6/6",
+	"LltAZHZHS3FDVVcvN9DfxmA33M8lFcFpVs2iyHVpw/PctWFTkAp8gRvTmneiNbDi2p9mUa0NsvSd2KJx",
+	"X4H97nqv2+nXMZUfewGlnkj+YeDS1F4ILvUA4VY2Xo8kNjBTUW1mDED9Ku3Mv2vdz7k21Rgmuo26GjOc",
+	"uxMjcaW3Xa4twOHn+onBtZMiGcpueS7sfDzUerHN74LcdsVEMmdihl3hndizzurW0M0sZeOxRcBa9gDy",
+	"5vTjP20ATiR668jvt8WzNiJ8FrJgFwhlEZ6TBuPZ19PY/gbzR4lt2xRVlDsV5TqhRELgstprG68Ki5wl",
+	"qDsKcw1/vLJl9gwkDYC2mds78YQaXN7L6ZKmbK2JpoZFqW1HwrSy4ka2GXaDgZh3Vt4tKiwMf5Dp6uuM",
+	"4ttPqa5/Mw4PRuO9dxyvHxw82OegzcdbdwfgYw9AqaAsUo/FEJwpYKwHCl8jp16nhw3MBZ3PeTVSuLnK",
+	"6vnzV0hYuiVRT0NSKoXC1O97SI5lkUuWDqndQk1dqXcKsx78kpvYeLxV6rrBURl4MwxTJeYOHED14ECV",
+	"wtbPNr2dsuRipsg7PrLNxvbLrELmOabADHxoJegfLHFa7/M0hUZxvETfOP4QSuc/BNzGa8vb8fmb6EtG",
+	"vyhzwwumzJCg06eyv233G61xffl0a3O5qk2tn31ylWAO9wbUE7tPP7woUJxUPaR7A5nq+3V7K/YPnJw8",
+	"7SS46spFUy4ID6GZ+ra3AxUzfgERdyMI20apR/FwDwezQQyGTYnBR5ZNb1a+SVmZ1z0/drGVy5runFGD",
+	"SwtqK3v9VK3C+zvmltt7J633q508M0GtQc/tSzP3tPUmfcVK4L7BuG55YAzTlWdBgSgXU1Qbt8640gYu",
+	"ueb0Is0SCF7SyBwVE2b7ewQj4QKxsEohGyuYNo22ftXCYa2mfgxaAstzL6KF7WE5+JMdidRnWW0K6/12",
+	"/bqXvb6bfe1wP+q+d9ho5lTYX6sx3NnZFQD/+HUj7T3cw+dvea7VduPOD7l3O5X4G30s57mrD0P7RPsO",
+	"nHdmTbnlkRhkMk9REYDq42PywNRaNXNQSLPaS/va0nrwdbT56cX5q3+cnL4cwGNPpaebRLhI8jJ18ULa",
+	"N5LO5ZdTt1rHG/AzcuZswQOXq3eiGk3apX74Rs0QI4EBBaO8Ajwd6DuZdtLqdzbQ7+cV/rV3NZUKhAnf",
+	"HcQz9zZ+v/Twqr9cLvs2YpQqt9MZTL8UN/6ErtoDscsufYk3wVIjJHaJfj2m2ga8/4WH/7Lw4P9zye8f",
+	"G25X5/ypY8qbbhxxKiUlspyAswIp6oIHlf0PC/8eAN42z6TkNAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/UCLALibrary/validation-service/validation"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// ValidateCommand is the name of the subcommand that validates CSV files from the command line.
+const ValidateCommand = "validate"
+
+// The exit codes of the validate subcommand
+const (
+	ExitValid    = 0 // No warnings were found
+	ExitWarnings = 1 // At least one warning was found
+	ExitError    = 2 // The files couldn't be validated
+)
+
+// FileReport is the validation report for a single file validated from the command line.
+type FileReport struct {
+	File string `json:"file"`
+	*csv.Report
+}
+
+// runValidate validates the CSV files named in the supplied command-line arguments and writes their reports.
+//
+// Reports are written to stdout as either text or JSON; logging and usage messages are written to stderr. It returns
+// the exit code the command should exit with.
+func runValidate(args []string, stdout io.Writer, stderr io.Writer) int {
+	var profile, format, profilesFile, sheet, dialect string
+	var tolerant bool
+
+	flags := flag.NewFlagSet(ValidateCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&profile, "profile", "", "The name of the profile to validate the CSV files with (required)")
+	flags.StringVar(&format, "format", "text", "The format of the validation report: text or json")
+	flags.StringVar(&profilesFile, "profiles", "", "The profiles file to use (default: the PROFILES_FILE ENV value)")
+	flags.StringVar(&sheet, "sheet", "", "The sheet to read from workbooks, by name or number (default: the first)")
+	flags.StringVar(&dialect, "dialect", "", "The CSV dialect to read, as a delimiter or JSON (default: sniffed)")
+	flags.BoolVar(&tolerant, "tolerant", false, "Keep reading past CSV parse problems and validate what can be parsed")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %s %s --profile NAME [--format text|json] [--profiles FILE] [--sheet SHEET] "+
+			"[--dialect DIALECT] [--tolerant] FILE.csv|FILE.xlsx|FILE.ods...\n",
+			os.Args[0], ValidateCommand)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return ExitError
+	}
+
+	if profile == "" || flags.NArg() == 0 || (format != "text" && format != "json") {
+		flags.Usage()
+		return ExitError
+	}
+
+	if profilesFile != "" {
+		if err := os.Setenv(config.ConfigFile, profilesFile); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	}
+
+	logger, err := buildCLILogger(stderr)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	engine, err := validation.NewEngine(logger)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	if engine.GetProfiles().GetProfile(profile) == nil {
+		_, _ = fmt.Fprintf(stderr, "Error: unknown profile '%s'\n", profile)
+		return ExitError
+	}
+
+	// Short and long rows are left for the profile's StructureCheck to report, if it has one
+	options := csv.ReadOptions{Sheet: sheet, Tolerant: tolerant,
+		StructureChecked: engine.HasValidation(profile, "StructureCheck")}
+	if dialect != "" {
+		if options.Dialect, err = csv.ParseDialect(dialect); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	}
+
+	reports := make([]FileReport, 0, flags.NArg())
+	exitCode := ExitValid
+
+	for _, file := range flags.Args() {
+		report, err := validateFile(engine, profile, file, options, logger)
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+
+		if len(report.Warnings) > 0 {
+			exitCode = ExitWarnings
+		}
+
+		reports = append(reports, FileReport{File: file, Report: report})
+	}
+
+	if err = writeReports(reports, format, stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	return exitCode
+}
+
+// validateFile reads and validates a single CSV file, or workbook sheet, returning its validation report.
+//
+// A file with records that couldn't be parsed is still validated, so its parse problems are reported along with the
+// warnings for the records that could be parsed.
+func validateFile(engine *validation.Engine, profile string, file string, options csv.ReadOptions,
+	logger *zap.Logger) (*csv.Report, error) {
+	var parseWarnings []csv.Warning
+
+	csvData, source, err := csv.ReadFile(file, options, logger)
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		csvData, source, parseWarnings = parseErr.Data, parseErr.Source, parseErr.Warnings
+	} else if err != nil {
+		return nil, err
+	}
+
+	report, err := engine.Report(profile, csvData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate '%s': %w", file, err)
+	}
+
+	if len(parseWarnings) > 0 {
+		report.Warnings = append(parseWarnings, report.Warnings...)
+	}
+
+	report.SetSource(source)
+	return report, nil
+}
+
+// writeReports writes the supplied reports in the requested format.
+//
+// Rows and columns are 1-based in text reports, to match what a user sees in a spreadsheet, but JSON reports use the
+// same 0-based values as the service's API.
+func writeReports(reports []FileReport, format string, writer io.Writer) error {
+	if format == "json" {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reports)
+	}
+
+	var builder strings.Builder
+
+	for _, report := range reports {
+		// Files that had to be transcoded are noted, since the file's encoding may be a surprise
+		if report.Source != nil && report.Source.Encoding != "" && report.Source.Encoding != csv.UTF8 {
+			builder.WriteString(fmt.Sprintf("%s: read as %s\n", report.File, report.Source.Encoding))
+		}
+
+		// So are files that weren't written as standard comma-separated values
+		if report.Source != nil && report.Source.Dialect != nil && !report.Source.Dialect.IsDefault() {
+			builder.WriteString(fmt.Sprintf("%s: read as %s\n", report.File, report.Source.Dialect))
+		}
+
+		if len(report.Warnings) == 0 {
+			builder.WriteString(fmt.Sprintf("%s: no warnings\n", report.File))
+			continue
+		}
+
+		builder.WriteString(fmt.Sprintf("%s: %d warning(s)\n", report.File, len(report.Warnings)))
+		for _, warning := range report.Warnings {
+			// Warnings in workbooks are located by their cell's address, which is what a user sees in the spreadsheet
+			location := fmt.Sprintf("row %d, column %d", warning.RowIndex+1, warning.ColIndex+1)
+			if warning.Cell != "" {
+				location = warning.Cell
+			}
+
+			builder.WriteString(fmt.Sprintf("  %s (%s): %s\n", location, warning.Header,
+				strings.ReplaceAll(warning.Message, "<br/>", " ")))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// buildCLILogger constructs a logger that writes to the supplied writer, so that it doesn't mix with the report.
+//
+// The command-line logger only logs warnings and errors, unless a LOG_LEVEL is set in the environment.
+func buildCLILogger(writer io.Writer) (*zap.Logger, error) {
+	level := zapcore.WarnLevel
+
+	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
+		if err := level.Set(logLevel); err != nil {
+			return nil, fmt.Errorf("invalid LOG_LEVEL '%s': %w", logLevel, err)
+		}
+	}
+
+	encoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(writer), level)), nil
+}
@@ -0,0 +1,177 @@
+//go:build unit
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestRunValidate tests validating CSV files from the command line.
+func TestRunValidate(t *testing.T) {
+	// The validate command sets the PROFILES_FILE, so we make sure it's restored after the test
+	t.Setenv(config.ConfigFile, "")
+
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.csv")
+	invalidFile := filepath.Join(dir, "invalid.csv")
+	latinFile := filepath.Join(dir, "latin.csv")
+	tabFile := filepath.Join(dir, "tab.tsv")
+	raggedFile := filepath.Join(dir, "ragged.csv")
+
+	require.NoError(t, os.WriteFile(validFile, []byte("Title,Object Type\nA title,Work\n"), 0600))
+	require.NoError(t, os.WriteFile(invalidFile, []byte("Title,Object Type\n\"A\ntitle\",Work\n"), 0600))
+	require.NoError(t, os.WriteFile(latinFile, []byte("Title,Object Type\nCaf\xe9,Work\n"), 0600))
+	require.NoError(t, os.WriteFile(tabFile, []byte("Title\tObject Type\nA title\tWork\n"), 0600))
+	require.NoError(t, os.WriteFile(raggedFile, []byte("Title,Object Type\nA title\n\"B\ntitle\",Work\n"), 0600))
+
+	profiles := "--profiles=testdata/test_profiles.json"
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedOutput string
+	}{
+		{
+			name:           "File without warnings",
+			args:           []string{profiles, "--profile", "test", validFile},
+			expectedCode:   ExitValid,
+			expectedOutput: validFile + ": no warnings\n",
+		},
+		{
+			name:         "File with warnings",
+			args:         []string{profiles, "--profile", "test", validFile, invalidFile},
+			expectedCode: ExitWarnings,
+			expectedOutput: validFile + ": no warnings\n" + invalidFile + ": 1 warning(s)\n" +
+				"  row 2, column 1 (Title): Error: character for EOL found in cell\n",
+		},
+		{
+			name:           "File that isn't UTF-8",
+			args:           []string{profiles, "--profile", "test", latinFile},
+			expectedCode:   ExitValid,
+			expectedOutput: latinFile + ": read as Windows-1252\n" + latinFile + ": no warnings\n",
+		},
+		{
+			name:           "File that isn't comma-separated",
+			args:           []string{profiles, "--profile", "test", tabFile},
+			expectedCode:   ExitValid,
+			expectedOutput: tabFile + ": read as tab-separated values\n" + tabFile + ": no warnings\n",
+		},
+		{
+			name:           "Supplied dialect",
+			args:           []string{profiles, "--profile", "test", "--dialect", "tab", tabFile},
+			expectedCode:   ExitValid,
+			expectedOutput: tabFile + ": read as tab-separated values\n" + tabFile + ": no warnings\n",
+		},
+		{
+			name:         "File that can't be parsed",
+			args:         []string{profiles, "--profile", "test", raggedFile},
+			expectedCode: ExitWarnings,
+			expectedOutput: raggedFile + ": 1 warning(s)\n" +
+				"  row 2, column 2 (Object Type): Error: the row has 1 field(s), but the header row has 2 (line 2)\n",
+		},
+		{
+			name:         "File that can't be parsed, read tolerantly without a StructureCheck",
+			args:         []string{profiles, "--profile", "test", "--tolerant", raggedFile},
+			expectedCode: ExitWarnings,
+			expectedOutput: raggedFile + ": 2 warning(s)\n" +
+				"  row 2, column 2 (Object Type): Error: the row has 1 field(s), but the header row has 2 (line 2)\n" +
+				"  row 3, column 1 (Title): Error: character for EOL found in cell\n",
+		},
+		{
+			name:         "File that can't be parsed, read tolerantly",
+			args:         []string{profiles, "--profile", "structure", "--tolerant", raggedFile},
+			expectedCode: ExitWarnings,
+			expectedOutput: raggedFile + ": 1 warning(s)\n" +
+				"  row 2, column 1 (Title): Error: the row has 1 field(s), but the header row has 2\n",
+		},
+		{
+			name:         "Invalid dialect",
+			args:         []string{profiles, "--profile", "test", "--dialect", "::", validFile},
+			expectedCode: ExitError,
+		},
+		{
+			name:         "Missing profile",
+			args:         []string{profiles, validFile},
+			expectedCode: ExitError,
+		},
+		{
+			name:         "Unknown profile",
+			args:         []string{profiles, "--profile", "unknown", validFile},
+			expectedCode: ExitError,
+		},
+		{
+			name:         "Unknown format",
+			args:         []string{profiles, "--profile", "test", "--format", "xml", validFile},
+			expectedCode: ExitError,
+		},
+		{
+			name:         "Missing files",
+			args:         []string{profiles, "--profile", "test"},
+			expectedCode: ExitError,
+		},
+		{
+			name:         "File that doesn't exist",
+			args:         []string{profiles, "--profile", "test", filepath.Join(dir, "missing.csv")},
+			expectedCode: ExitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			assert.Equal(t, tt.expectedCode, runValidate(tt.args, &stdout, &stderr), stderr.String())
+			assert.Equal(t, tt.expectedOutput, stdout.String())
+
+			if tt.expectedCode == ExitError {
+				assert.NotEmpty(t, stderr.String())
+			}
+		})
+	}
+}
+
+// TestRunValidate_JSON tests writing the command line's validation reports as JSON.
+func TestRunValidate_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	var reports []FileReport
+
+	t.Setenv(config.ConfigFile, "")
+
+	file := filepath.Join(t.TempDir(), "invalid.csv")
+	require.NoError(t, os.WriteFile(file, []byte("Title,Object Type\n\"A\ntitle\",Work\n"), 0600))
+
+	args := []string{"--profiles", "testdata/test_profiles.json", "--profile", "test", "--format", "json", file}
+	assert.Equal(t, ExitWarnings, runValidate(args, &stdout, &stderr))
+
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &reports))
+	require.Len(t, reports, 1)
+	assert.Equal(t, file, reports[0].File)
+	assert.Equal(t, "test", reports[0].Profile)
+	require.Len(t, reports[0].Warnings, 1)
+	assert.Equal(t, "Title", reports[0].Warnings[0].Header)
+	assert.Equal(t, 1, reports[0].Warnings[0].RowIndex)
+}
+
+// TestWriteReports_Workbook tests that the warnings in a workbook's text report are located by their cell's address.
+func TestWriteReports_Workbook(t *testing.T) {
+	var stdout bytes.Buffer
+
+	report := &csv.Report{Warnings: []csv.Warning{{Message: "Error: character for EOL found in cell", Header: "Title",
+		RowIndex: 13, ColIndex: 3}}}
+	report.SetSource(&csv.Source{Format: csv.FormatXLSX, Sheet: "Sheet1"})
+
+	require.NoError(t, writeReports([]FileReport{{File: "works.xlsx", Report: report}}, "text", &stdout))
+	assert.Equal(t, "works.xlsx: 1 warning(s)\n  Sheet1!D14 (Title): Error: character for EOL found in cell\n",
+		stdout.String())
+}
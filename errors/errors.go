@@ -4,24 +4,30 @@ package errors
 // Error messages
 var (
 	NilProfileErr        = "supplied profile cannot be nil"
-	NoPrefixErr          = "ARK must start with 'ark:/'"
+	NoPrefixErr          = "ARK must start with 'ark:/' or 'ark:'"
 	NaanTooShortErr      = "NAAN must be at least 5 digits long"
 	NaanProfileErr       = "The supplied NAAN is not allowed for the supplied profile"
 	NoObjIDErr           = "The ARK must contain an object identifier"
 	InvalidObjIDErr      = "The object identifier and qualifier is not valid"
+	NoidCharErr          = "The object identifier has characters that can't be used in a NOID"
+	NoidCheckErr         = "The object identifier's NOID check character doesn't match (it may contain a typo)"
 	ArkValFailed         = "ARK validation failed"
 	EolFoundErr          = "character for EOL found in cell"
 	BadHeaderErr         = "could not retrieve CSV header: %s"
 	FieldNotFoundErr     = "required field `%s` was not found"
 	FieldDataNotFoundErr = "data for required field `%s` was not found"
 	UnknownProfileErr    = "unknown profile `%s`"
-	ProfileConfigErr     = "supplied profile has objTypes and notObjTypes set: %s"
+	ProfileConfigErr     = "required field `%s` has both objTypes and notObjTypes set"
 	NoHostDir            = "a HOST_DIR must be set"
+	HostDirOpenErr       = "the HOST_DIR could not be opened"
 	FileNotExist         = "the file path given does not exist: %s"
-	URLFormatErr         = "license URL is not in a proper format (check for HTTPS)"
+	PathEscapeErr        = "the file path given is outside of the files directory: %s"
+	URLFormatErr         = "license URL is not in a proper format (check for HTTP or HTTPS)"
 	URLConnectErr        = "problem connecting to license URL"
 	URLReadErr           = "problem reading body of license URL"
 	URLDupeBadErr        = "duplicate invalid license URL"
+	URLStatusErr         = "license URL responded with HTTP status %d"
+	URLApprovalErr       = "license `%s` is not one of the approved licenses"
 	TypeWhitespaceError  = "field contains invalid characters (e.g., spaces, line breaks)"
 	TypeValueError       = "object type field doesn't contain valid value"
 	VisibilityValueError = "visibility field doesn't contain valid value"
@@ -40,4 +46,52 @@ var (
 	FormatMissingErr     = "media.format field is missing"
 	FormatEmptyErr       = "media.format field is empty"
 	PageMustBeIntErr     = "if the 'Object Type' is 'Page' the 'Item Seuqence' must be a positive int"
+	RulePatternErr       = "value `%s` for `%s` doesn't match its required pattern"
+	RuleValueErr         = "value `%s` for `%s` isn't one of its allowed values"
+	RuleNotAnIntErr      = "value `%s` for `%s` is not an integer"
+	RuleRangeErr         = "value `%s` for `%s` must be %s"
+	ExpressionFailedErr  = "row failed rule `%s`: %s"
+	ExpressionEvalErr    = "rule `%s` could not be evaluated: %s"
+	OrphanParentErr      = "parent ARK `%s` doesn't match any Item ARK"
+	SelfParentErr        = "the Parent ARK is the same as the Item ARK"
+	ParentTypeErr        = "a %s can't have a %s as its parent"
+	ParentCycleErr       = "parent ARK `%s` is a part of a cycle of parents"
+	CompanionFileErr     = "companion CSV could not be read: %s"
+	DuplicateValueErr    = "duplicate value for %s (first found in row %d)"
+	SeqRepeatedErr       = "Item Sequence %d is repeated for parent `%s` (first found in row %d)"
+	SeqStartErr          = "Item Sequences for parent `%s` start at %d instead of 1"
+	SeqGapErr            = "Item Sequence %d for parent `%s` follows a gap (expected %d)"
+	SeqOrderErr          = "Item Sequence %d for parent `%s` is out of order (follows %d in row %d)"
+	ImageUnknownErr      = "file `%s` is not a recognized image format"
+	ImageFormatErr       = "file `%s` contains %s data, which doesn't match its extension"
+	ImageDamagedErr      = "file `%s` is damaged: %s"
+	ImageDecodeErr       = "file `%s` could not be decoded: %s"
+	MediaWidthErr        = "media.width `%s` doesn't match the width of `%s` (%d)"
+	MediaHeightErr       = "media.height `%s` doesn't match the height of `%s` (%d)"
+	MediaFormatErr       = "media.format `%s` doesn't match the type of `%s` (%s)"
+	ChecksumFormatErr    = "checksum `%s` is not an MD5, SHA-1, SHA-256, or SHA-512 checksum"
+	ChecksumMismatchErr  = "%s checksum of `%s` doesn't match (expected %s, found %s)"
+	ChecksumReadErr      = "file `%s` could not be read to verify its checksum: %s"
+	ManifestEntryErr     = "file `%s` is not listed in a checksum manifest"
+	ManifestFileErr      = "checksum manifest could not be read: %s"
+	IIIFURLErr           = "%s `%s` is not an HTTP or HTTPS URL"
+	IIIFBaseErr          = "%s `%s` doesn't start with an allowed base URL"
+	IIIFShapeErr         = "%s `%s` doesn't have the expected form (e.g., `%s`)"
+	IIIFArkErr           = "%s encodes `%s`, which doesn't match the Item ARK `%s`"
+	IIIFManifestErr      = "a %s shouldn't have an IIIF Manifest URL"
+	DateEDTFErr          = "%s `%s` is not a valid EDTF date (it %s)"
+	DateInvertedErr      = "%s `%s` is a range that ends before it starts"
+	DateLevelErr         = "%s `%s` uses EDTF level 1 features, which aren't allowed"
+	DateCreatedErr       = "%s `%s` doesn't match %s `%s`"
+	DateYearErr          = "%s `%s` doesn't mention a year"
+	VocabTermErr         = "value `%s` for `%s` isn't in its vocabulary"
+	VocabSuggestErr      = "value `%s` for `%s` isn't in its vocabulary (did you mean `%s`?)"
+	VocabFileErr         = "vocabulary for `%s` could not be read: %s"
+	HeaderBlankErr       = "the header is blank"
+	HeaderPaddedErr      = "header `%s` has whitespace around it"
+	HeaderRepeatedErr    = "header `%s` is repeated (first found in column %d)"
+	RowShortErr          = "the row has %d field(s), but the header row has %d"
+	RowLongErr           = "the row has %d field(s), but the header row has only %d"
+	RowEmptyErr          = "the row is empty"
+	ColumnEmptyErr       = "the column is empty, including its header"
 )
@@ -1,27 +1,67 @@
 
 // Add an on-load listener for generating the validation report.
 document.addEventListener('DOMContentLoaded', function() {
+  const jobDiv = document.getElementById('job');
+  const jobID = jobDiv ? (jobDiv.textContent || jobDiv.innerText || '').trim() : '';
+
+  // If we were handed a validation job, wait for it to finish before displaying its report
+  if (jobID) {
+    document.getElementById('report').innerText = 'Validating CSV file...';
+
+    waitForReport(jobID).then(json => displayReport(json)).catch(error => {
+      document.getElementById('report').innerText = 'Validation Error: ' + error.message;
+    });
+
+    return;
+  }
+
   const jsonDiv = document.getElementById('json');
   const jsonString = jsonDiv.textContent || jsonDiv.innerText || '';
-  let json;
 
   if (!jsonString) {
     document.getElementById('report').innerText('No JSON report data found')
   }
 
   // Create a JSON object with the report data
-  json = JSON.parse(jsonString);
+  displayReport(JSON.parse(jsonString));
+});
+
+// Function to poll a validation job until its report is available.
+async function waitForReport(jobID) {
+  const url = '/jobs/' + encodeURIComponent(jobID) + '/report';
+
+  while (true) {
+    const response = await fetch(url, { headers: { 'Accept': 'application/json' } });
+
+    if (response.status === 200) {
+      return response.json();
+    }
+
+    // Anything other than a 202 means the job isn't going to produce a report
+    if (response.status !== 202) {
+      const error = await response.json().catch(() => ({}));
+      throw new Error(error.message || response.statusText);
+    }
+
+    await new Promise(resolve => setTimeout(resolve, 1000));
+  }
+}
+
+// Function to display a validation report on the webpage.
+function displayReport(json) {
+  const reportDiv = document.getElementById('report');
 
   try {
     // Create a validation report and display it on the webpage
-    document.getElementById('report').appendChild(createReport(json));
+    reportDiv.innerText = '';
+    reportDiv.appendChild(createReport(json));
   } catch (error) {
-    document.getElementById('report').innerText = 'JSON Parsing Error: ' + error.message;
+    reportDiv.innerText = 'JSON Parsing Error: ' + error.message;
   }
 
   // Add a listener on our download button just to confirm the work is done
   setUpReportDownload(json.time).then(available => console.log("PDF download available"));
-});
+}
 
 // Function to set up the save report as a PDF functionality.
 async function setUpReportDownload(time) {
@@ -92,7 +132,7 @@ function createReport(data) {
     const row = document.createElement('tr');
     row.innerHTML = `
           <td>${warning.header}</td>
-          <td>${warning.row + 1}<!-- Row index is 1-based --></td>
+          <td>${warning.cell || warning.row + 1}<!-- Row index is 1-based; workbooks use cell addresses --></td>
           <td>${warning.value}</td>
           <td class="warning">${warning.message}</td>
         `;
@@ -11,6 +11,7 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,14 +27,14 @@ func TestUploadCSV(t *testing.T) {
 		{
 			name:           "Valid CSV upload",
 			csvFilePath:    "../testdata/cct-works-simple.csv",
-			expectedStatus: http.StatusCreated,
+			expectedStatus: http.StatusAccepted,
 			// expectedRegex handles JSON with or without line feeds and indentation
 			expectedRegex: `\{\s*"profile"\s*:\s*"DLP Staff"\s*,\s*"time"\s*:\s*".*?"\s*,\s*"warnings"\s*:\s*\[\s*\]\s*\}`,
 		},
 		{
 			name:           "Upload failure CSV",
 			csvFilePath:    "../testdata/upload-failures.csv",
-			expectedStatus: http.StatusCreated,
+			expectedStatus: http.StatusAccepted,
 			// expectedRegex handles JSON with or without line feeds and indentation
 			expectedRegex: `\{\s*"profile"\s*:\s*"DLP Staff"\s*,\s*"time"\s*:\s*".*?"\s*,\s*"warnings"\s*:\s*\[\s*\{\s*[\s\S]*?\s*\}\s*\]\s*\}`,
 		},
@@ -91,13 +92,42 @@ func TestUploadCSV(t *testing.T) {
 			//noinspection GoUnhandledErrorResult
 			defer response.Body.Close()
 
-			// Read the response body
-			body, readErr := io.ReadAll(response.Body)
-			if readErr != nil {
-				t.Fatalf("Error reading response: %v", readErr)
+			// Check the expected status code
+			assert.Equal(t, tt.expectedStatus, response.StatusCode, "Unexpected status code for test case: %s", tt.name)
+
+			// The upload queues a validation job, so we poll for its report until it's finished
+			location := response.Header.Get("Location")
+			if location == "" {
+				t.Fatalf("No job location returned for test case: %s", tt.name)
+			}
+
+			var body []byte
+
+			for deadline := time.Now().Add(30 * time.Second); ; time.Sleep(250 * time.Millisecond) {
+				report, getErr := client.Get(fmt.Sprintf(testServerURL, location+"/report"))
+				if getErr != nil {
+					t.Fatalf("Error requesting report: %v", getErr)
+				}
+
+				// Read the report's response body
+				var readErr error
+				body, readErr = io.ReadAll(report.Body)
+				_ = report.Body.Close()
+				if readErr != nil {
+					t.Fatalf("Error reading response: %v", readErr)
+				}
+
+				if report.StatusCode != http.StatusAccepted {
+					assert.Equal(t, http.StatusOK, report.StatusCode, "Unexpected report status for test case: %s", tt.name)
+					break
+				}
+
+				if time.Now().After(deadline) {
+					t.Fatalf("Timed out waiting for validation job: %s", location)
+				}
 			}
 
-			// Check the response against the expected regex pattern
+			// Check the report against the expected regex pattern
 			matched, _ := regexp.MatchString(tt.expectedRegex, string(body))
 			if !matched {
 				t.Errorf(
@@ -107,9 +137,6 @@ func TestUploadCSV(t *testing.T) {
 					body,
 				)
 			}
-
-			// Check the expected status code
-			assert.Equal(t, tt.expectedStatus, response.StatusCode, "Unexpected status code for test case: %s", tt.name)
 		})
 	}
 }
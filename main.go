@@ -5,7 +5,7 @@
 // renders HTML templates, and provides a REST API conforming to an OpenAPI spec.
 //
 // Key features include:
-// - CSV file upload and validation
+// - CSV file upload and asynchronous validation jobs
 // - Custom HTML rendering for validation reports
 // - OpenAPI-based route validation
 // - Configurable route mappings for static and dynamic content
@@ -23,9 +23,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/UCLALibrary/validation-service/validation"
 
@@ -37,6 +37,7 @@ import (
 	"github.com/UCLALibrary/validation-service/api"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"github.com/UCLALibrary/validation-service/validation/csv"
+	"github.com/UCLALibrary/validation-service/validation/jobs"
 	"github.com/UCLALibrary/validation-service/validation/util"
 )
 
@@ -72,6 +73,10 @@ func (renderer *TemplateRenderer) Render(writer io.Writer, name string, data int
 // Service implements the generated OpenAPI interface (i.e., handles incoming requests)
 type Service struct {
 	Engine *validation.Engine
+	Jobs   *jobs.Queue
+
+	// profilesMutex keeps concurrent profile changes from persisting out of order
+	profilesMutex sync.Mutex
 }
 
 // GetStatus handles the GET /status request
@@ -86,67 +91,392 @@ func (service *Service) GetStatus(context echo.Context) error {
 
 // UploadCSV handles the /upload/csv POST request
 func (service *Service) UploadCSV(context echo.Context) error {
-	engine := service.Engine
-	logger := engine.GetLogger()
+	logger := service.Engine.GetLogger()
 
-	// Get the CSV file upload and profile
+	// Get the CSV file upload, profile, and how the upload should be read
 	profile := context.FormValue("profile")
 	file, fileErr := context.FormFile("csvFile")
 	if fileErr != nil {
 		return context.JSON(http.StatusBadRequest, map[string]string{"error": "A CSV file must be uploaded"})
 	}
 
+	// A job for a profile that doesn't exist would only fail once it was run
+	if service.Engine.GetProfiles().GetProfile(profile) == nil {
+		return context.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf(
+			"The requested profile '%s' could not be found", profile)})
+	}
+
+	options, optionsErr := service.readOptions(context, profile)
+	if reason, found := csv.ReadMessage(optionsErr); found {
+		return context.JSON(http.StatusBadRequest, map[string]string{"error": "The supplied dialect could not be used: " +
+			reason})
+	}
+
 	logger.Debug("Received uploaded CSV file",
 		zap.String("csvFile", file.Filename),
 		zap.String("profile", profile))
 
 	// Parse the CSV data
-	csvData, readErr := csv.ReadUpload(file, logger)
+	csvData, source, readErr := csv.ReadUpload(file, options, logger)
+	jobFile, readErr := jobs.NewFile(file.Filename, csvData, source, readErr)
 
-	if readErr != nil {
+	if reason, found := csv.ReadMessage(readErr); found {
+		return context.JSON(http.StatusBadRequest, map[string]string{"error": "Uploaded CSV file could not be read: " +
+			reason})
+	} else if readErr != nil {
 		return context.JSON(http.StatusBadRequest, map[string]string{"error": "Uploaded CSV file could not be parsed"})
 	}
 
-	if err := engine.Validate(profile, csvData); err != nil {
-		report, reportErr := csv.NewReport(err, csvData, logger)
-		if reportErr != nil {
-			logger.Error("Failed to generate report", zap.Error(reportErr), zap.Stack("stacktrace"))
-			return context.JSON(http.StatusInternalServerError,
-				ServiceError{Code: http.StatusInternalServerError, Message: reportErr.Error()})
-		}
+	// Queue the validation so large CSV files don't hold the request open until they're validated
+	job, jobErr := service.Jobs.SubmitFile(profile, jobFile)
+
+	return acceptJob(job, jobErr, logger, context)
+}
+
+// ValidatePath handles the /validate/path POST request
+func (service *Service) ValidatePath(context echo.Context) error {
+	logger := service.Engine.GetLogger()
+
+	// Get the path, relative to the HOST_DIR, profile, and how the path's files should be read
+	profile := context.FormValue("profile")
+	relPath := context.FormValue("path")
+
+	// A job for a profile that doesn't exist would only fail once it was run
+	if service.Engine.GetProfiles().GetProfile(profile) == nil {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: fmt.Sprintf("The requested profile '%s' could not be found", profile)})
+	}
+
+	options, optionsErr := service.readOptions(context, profile)
+	if reason, found := csv.ReadMessage(optionsErr); found {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: "The supplied dialect could not be used: " + reason})
+	}
+
+	hostDir := os.Getenv("HOST_DIR")
+	if hostDir == "" {
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: "The service's HOST_DIR has not been set"})
+	}
+
+	// An empty path would resolve to the whole HOST_DIR
+	if strings.TrimSpace(relPath) == "" {
+		return context.JSON(http.StatusBadRequest,
+			ServiceError{Code: http.StatusBadRequest, Message: "A path to validate is required"})
+	}
 
-		// Check to see if an HTML version of the report was requested
-		if strings.Contains(context.Request().Header.Get("Accept"), "text/html") {
-			return displayReport(report, logger, context)
+	logger.Debug("Received CSV path", zap.String("path", relPath), zap.String("profile", profile))
+
+	// Resolve the path, making sure it doesn't lead outside of the HOST_DIR
+	path, pathErr := csv.ResolvePath(hostDir, relPath)
+	if errors.Is(pathErr, csv.ErrPathEscape) {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: fmt.Sprintf("The path '%s' is not inside the HOST_DIR", relPath)})
+	} else if pathErr != nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested path '%s' could not be found", relPath)})
+	}
+
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested path '%s' could not be found", relPath)})
+	}
+
+	// A single file is validated just like an uploaded one
+	if !info.IsDir() {
+		csvData, source, readErr := csv.ReadFile(path, options, logger)
+		jobFile, readErr := jobs.NewFile(relPath, csvData, source, readErr)
+		if reason, found := csv.ReadMessage(readErr); found {
+			return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+				Message: fmt.Sprintf("The CSV file '%s' could not be read: %s", relPath, reason)})
+		} else if readErr != nil {
+			return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+				Message: fmt.Sprintf("The CSV file '%s' could not be parsed", relPath)})
 		}
 
-		// If not an HTML request, specifically, we return our JSON formatter version of the report
-		return context.JSON(http.StatusCreated, report)
+		job, jobErr := service.Jobs.SubmitFile(profile, jobFile)
+		return acceptJob(job, jobErr, logger, context)
+	}
+
+	// A folder's CSV files are validated together, so their warnings can be combined into a single report
+	paths, findErr := csv.FindCSVFiles(path)
+	if findErr != nil {
+		logger.Error("Failed to find CSV files", zap.String("path", relPath), zap.Error(findErr))
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: findErr.Error()})
+	}
+
+	if len(paths) == 0 {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("No CSV files could be found in '%s'", relPath)})
 	}
 
-	// There were no validation violations, so we just return an empty report
-	report := &csv.Report{Profile: profile, Time: time.Now(), Warnings: []csv.Warning{}}
+	// The files are read when the job is run, so a large folder doesn't hold up the request
+	files := make([]jobs.File, 0, len(paths))
+	for _, csvPath := range paths {
+		// Each file is named by its path relative to the HOST_DIR, as it was requested
+		name, _ := filepath.Rel(path, csvPath)
+		files = append(files, jobs.File{Name: filepath.ToSlash(filepath.Join(relPath, name)), Path: csvPath})
+	}
+
+	job, jobErr := service.Jobs.SubmitPaths(profile, relPath, files, options)
+
+	return acceptJob(job, jobErr, logger, context)
+}
+
+// GetJob handles the /jobs/{jobID} GET request
+func (service *Service) GetJob(context echo.Context, jobID string) error {
+	job := service.Jobs.GetJob(jobID)
+	if job == nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested job '%s' could not be found", jobID)})
+	}
+
+	return context.JSON(http.StatusOK, job)
+}
+
+// GetJobReport handles the /jobs/{jobID}/report GET request
+func (service *Service) GetJobReport(context echo.Context, jobID string) error {
+	logger := service.Engine.GetLogger()
+
+	job := service.Jobs.GetJob(jobID)
+	if job == nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested job '%s' could not be found", jobID)})
+	}
+
+	// A failed job doesn't have a report, just the reason it failed
+	if job.GetStatus() == jobs.Failed {
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: job.GetError().Error()})
+	}
+
+	// If the job hasn't finished yet, we return its status so the client knows to check back
+	report := job.GetReport()
+	if report == nil {
+		context.Response().Header().Set(echo.HeaderLocation, "/jobs/"+job.GetID())
+		return context.JSON(http.StatusAccepted, job)
+	}
 
 	// Check to see if an HTML version of the report was requested
 	if strings.Contains(context.Request().Header.Get("Accept"), "text/html") {
-		return displayReport(report, logger, context)
+		return displayReport(report, http.StatusOK, logger, context)
 	}
 
 	// If not an HTML request, specifically, we return our JSON formatter version of the report
-	return context.JSON(http.StatusCreated, report)
+	return context.JSON(http.StatusOK, report)
+}
+
+// ListProfiles handles the GET /profiles request
+func (service *Service) ListProfiles(context echo.Context) error {
+	profiles := service.Engine.GetProfiles()
+	profileList := make([]*config.Profile, 0, profiles.Count())
+
+	for _, name := range profiles.GetProfileNames() {
+		// A profile could be deleted after we've gotten its name
+		if profile := profiles.GetProfile(name); profile != nil {
+			profileList = append(profileList, profile)
+		}
+	}
+
+	return context.JSON(http.StatusOK, profileList)
+}
+
+// GetProfile handles the GET /profiles/{profileID} request
+func (service *Service) GetProfile(context echo.Context, profileID string) error {
+	profile := service.Engine.GetProfiles().GetProfile(profileID)
+	if profile == nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested profile '%s' could not be found", profileID)})
+	}
+
+	return context.JSON(http.StatusOK, profile)
+}
+
+// PutProfile handles the PUT /profiles/{profileID} request
+func (service *Service) PutProfile(context echo.Context, profileID string) error {
+	var request api.PutProfileJSONRequestBody
+	var unknown []string
+
+	logger := service.Engine.GetLogger()
+
+	if err := context.Bind(&request); err != nil {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: "The supplied profile could not be parsed"})
+	}
+
+	// If a name is supplied in the request body, it needs to match the requested profile
+	if request.Name != nil && *request.Name != profileID {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: fmt.Sprintf("Profile name '%s' doesn't match requested profile '%s'", *request.Name, profileID)})
+	}
+
+	if len(request.Validations) == 0 {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: "A profile must have at least one validation"})
+	}
+
+	// Confirm the requested validations are ones that the validation engine knows how to run
+	validations := make([]config.Validation, 0, len(request.Validations))
+	for _, check := range request.Validations {
+		if !validation.IsRegistered(check.Name) {
+			unknown = append(unknown, check.Name)
+			continue
+		}
+
+		profileValidation := config.Validation{Name: check.Name}
+		if check.Description != nil {
+			profileValidation.Description = *check.Description
+		}
+
+		if check.Options != nil {
+			options, err := json.Marshal(*check.Options)
+			if err != nil {
+				return context.JSON(http.StatusBadRequest,
+					ServiceError{Code: http.StatusBadRequest, Message: err.Error()})
+			}
+
+			profileValidation.Options = options
+		}
+
+		validations = append(validations, profileValidation)
+	}
+
+	if len(unknown) > 0 {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest,
+			Message: fmt.Sprintf("Unknown validator(s): %s", strings.Join(unknown, ", "))})
+	}
+
+	// Confirm the validators can be configured with the options they've been given
+	for _, profileValidation := range validations {
+		if err := validation.ValidateOptions(profileValidation); err != nil {
+			return context.JSON(http.StatusBadRequest,
+				ServiceError{Code: http.StatusBadRequest, Message: err.Error()})
+		}
+	}
+
+	// A new profile is swapped in, rather than the current one being changed in place, so a validation that's using
+	// the current one, or a reload of the profiles file, never sees a partly updated profile
+	profile, err := config.NewProfile(profileID, nil)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, ServiceError{Code: http.StatusBadRequest, Message: err.Error()})
+	}
+
+	profile.SetValidations(validations)
+
+	service.profilesMutex.Lock()
+	defer service.profilesMutex.Unlock()
+
+	profiles := service.Engine.GetProfiles()
+	previous := profiles.GetProfile(profileID)
+	status := http.StatusOK
+
+	if previous == nil {
+		status = http.StatusCreated
+	}
+
+	if err = profiles.SetProfile(profile); err != nil {
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: err.Error()})
+	}
+
+	// The change is only undone if the profile hasn't been changed again since, such as by a reload
+	rollback := func() {
+		if profiles.GetProfile(profileID) != profile {
+			return
+		}
+
+		if previous == nil {
+			_ = profiles.DeleteProfile(profileID)
+		} else {
+			_ = profiles.SetProfile(previous)
+		}
+	}
+
+	// Persist the change, undoing it in memory if it can't be saved
+	if err = profiles.Save(); err != nil {
+		rollback()
+		logger.Error("Failed to save profiles", zap.String("profile", profileID), zap.Error(err))
+
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: err.Error()})
+	}
+
+	logger.Info("Saved validation profile", zap.String("profile", profileID),
+		zap.Strings("validations", profile.GetValidations()))
+
+	return context.JSON(status, profile)
+}
+
+// DeleteProfile handles the DELETE /profiles/{profileID} request
+func (service *Service) DeleteProfile(context echo.Context, profileID string) error {
+	logger := service.Engine.GetLogger()
+
+	service.profilesMutex.Lock()
+	defer service.profilesMutex.Unlock()
+
+	profiles := service.Engine.GetProfiles()
+	profile := profiles.GetProfile(profileID)
+	if profile == nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound,
+			Message: fmt.Sprintf("The requested profile '%s' could not be found", profileID)})
+	}
+
+	if err := profiles.DeleteProfile(profileID); err != nil {
+		return context.JSON(http.StatusNotFound, ServiceError{Code: http.StatusNotFound, Message: err.Error()})
+	}
+
+	// Persist the change, restoring the profile in memory if it can't be saved
+	if err := profiles.Save(); err != nil {
+		_ = profiles.SetProfile(profile)
+		logger.Error("Failed to save profiles", zap.String("profile", profileID), zap.Error(err))
+
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: err.Error()})
+	}
+
+	logger.Info("Deleted validation profile", zap.String("profile", profileID))
+
+	return context.NoContent(http.StatusNoContent)
 }
 
 // The main function starts our Echo server.
 func main() {
+	// Validate CSV files from the command line, instead of starting the server, if we've been asked to
+	if len(os.Args) > 1 && os.Args[1] == ValidateCommand {
+		os.Exit(runValidate(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	// Create a new validation engine for our service to use
 	engine, err := validation.NewEngine()
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	defer func() {
+		_ = engine.Close()
+	}()
+
 	// Get the validation engine's logger to use to configure Echo
 	logger := engine.GetLogger()
 
+	// Create a queue to run validation jobs in the background
+	queue, queueErr := jobs.NewQueue(engine)
+	if queueErr != nil {
+		log.Fatal(queueErr)
+	}
+	defer queue.Close()
+
+	// Watch the profiles file so changes to it are picked up without restarting the service
+	watcher, watchErr := validation.NewProfilesWatcher(engine)
+	if watchErr != nil {
+		log.Fatal(watchErr)
+	}
+	if watcher != nil {
+		defer watcher.Close()
+	}
+
 	// Create a new validation application and configure its logger
 	echoApp := echo.New()
 	echoApp.Use(util.ZapLoggerMiddleware(logger))
@@ -167,8 +497,8 @@ func main() {
 	echoApp.Pre(trailingSlashMiddleware)
 
 	// Configure the application's route handling
-	routes := append(configStaticRoutes(echoApp), configTemplateRoutes(echoApp, getTemplateRenderer(logger))...)
-	echoApp.Use(routerConfigMiddleware(echoApp, engine, routes))
+	routes := append(configStaticRoutes(echoApp), configTemplateRoutes(echoApp, getTemplateRenderer(logger), engine)...)
+	echoApp.Use(routerConfigMiddleware(echoApp, &Service{Engine: engine, Jobs: queue}, routes))
 
 	// Log the configured routes when we're running in debug mode
 	if debugging := logger.Check(zap.DebugLevel, "Loading routes"); debugging != nil {
@@ -264,8 +594,66 @@ func loadTemplates(logger *zap.Logger) (*template.Template, error) {
 	return templates, nil
 }
 
+// acceptJob responds to a request that's submitted a validation job with the job's status.
+func acceptJob(job *jobs.Job, jobErr error, logger *zap.Logger, context echo.Context) error {
+	if jobErr != nil {
+		logger.Warn("Failed to queue validation job", zap.Error(jobErr))
+		return context.JSON(http.StatusServiceUnavailable,
+			ServiceError{Code: http.StatusServiceUnavailable, Message: jobErr.Error()})
+	}
+
+	context.Response().Header().Set(echo.HeaderLocation, "/jobs/"+job.GetID())
+
+	// Check to see if an HTML version of the report was requested
+	if strings.Contains(context.Request().Header.Get("Accept"), "text/html") {
+		return displayJob(job, logger, context)
+	}
+
+	// If not an HTML request, specifically, we return the JSON version of the job's status
+	return context.JSON(http.StatusAccepted, job)
+}
+
+// readOptions gets the options for reading a request's files from its form data: the sheet to read from workbooks and
+// the dialect, or the parts of it, to read CSV files in.
+//
+// Short and long rows are left for the profile's StructureCheck to report, if it has one.
+func (service *Service) readOptions(context echo.Context, profile string) (csv.ReadOptions, error) {
+	options := csv.ReadOptions{Sheet: context.FormValue("sheet")}
+	options.StructureChecked = service.Engine.HasValidation(profile, "StructureCheck")
+
+	// A tolerant value that isn't a boolean is read as false, the default
+	options.Tolerant, _ = strconv.ParseBool(context.FormValue("tolerant"))
+
+	if dialect := context.FormValue("dialect"); dialect != "" {
+		override, err := csv.ParseDialect(dialect)
+		if err != nil {
+			return options, err
+		}
+
+		options.Dialect = override
+	}
+
+	return options, nil
+}
+
+// displayJob sends a page to the browser that displays a validation job's report once the job has finished.
+func displayJob(job *jobs.Job, logger *zap.Logger, context echo.Context) error {
+	data := map[string]interface{}{
+		"JobID": job.GetID(),
+	}
+
+	if err := context.Render(http.StatusAccepted, "report.html", data); err != nil {
+		logger.Error("Failed to render template", zap.Error(err))
+
+		return context.JSON(http.StatusInternalServerError,
+			ServiceError{Code: http.StatusInternalServerError, Message: err.Error()})
+	}
+
+	return nil
+}
+
 // displayReport sends a CSV validation report to the browser.
-func displayReport(report *csv.Report, logger *zap.Logger, context echo.Context) error {
+func displayReport(report *csv.Report, status int, logger *zap.Logger, context echo.Context) error {
 	json, jsonErr := csv.SerializeReport(report)
 	if jsonErr != nil {
 		return context.JSON(http.StatusInternalServerError,
@@ -276,7 +664,7 @@ func displayReport(report *csv.Report, logger *zap.Logger, context echo.Context)
 		"JSON": template.HTML(json),
 	}
 
-	if err := context.Render(http.StatusCreated, "report.html", data); err != nil {
+	if err := context.Render(status, "report.html", data); err != nil {
 		logger.Error("Failed to render template", zap.Error(err))
 
 		return context.JSON(http.StatusInternalServerError,
@@ -328,7 +716,7 @@ func configStaticRoutes(echoApp *echo.Echo) []RouteMapping {
 }
 
 // configTemplateRoutes configures our template resources with the Echo application.
-func configTemplateRoutes(echoApp *echo.Echo, renderer *TemplateRenderer) []RouteMapping {
+func configTemplateRoutes(echoApp *echo.Echo, renderer *TemplateRenderer, engine *validation.Engine) []RouteMapping {
 	// Set the Echo application's default template renderer
 	echoApp.Renderer = renderer
 
@@ -340,12 +728,8 @@ func configTemplateRoutes(echoApp *echo.Echo, renderer *TemplateRenderer) []Rout
 
 	// Have the templates renderer handle incoming index requests
 	echoApp.GET(templateRoutes[0].RoutePath, func(context echo.Context) error {
-		// Starting with just a one-time, non-refreshing serialization of Profiles
-		profiles := config.NewProfiles()
-		if configErr := profiles.Refresh(); configErr != nil {
-			return fmt.Errorf("failed to deserialize JSON to Profiles: %w", configErr)
-		}
-		serializedProfiles, profilesErr := profiles.String()
+		// Use the engine's Profiles so the page documents the profiles that are actually used for validation
+		serializedProfiles, profilesErr := engine.GetProfiles().String()
 		if profilesErr != nil {
 			return fmt.Errorf("failed to serialize Profiles to JSON: %w", profilesErr)
 		}
@@ -368,16 +752,14 @@ func configTemplateRoutes(echoApp *echo.Echo, renderer *TemplateRenderer) []Rout
 }
 
 // routerConfigMiddleware configures the application's router with a fully configured OpenAPI set of routes.
-func routerConfigMiddleware(echoApp *echo.Echo, engine *validation.Engine, routes []RouteMapping) echo.MiddlewareFunc {
+func routerConfigMiddleware(echoApp *echo.Echo, service *Service, routes []RouteMapping) echo.MiddlewareFunc {
 	swagger, swaggerErr := api.GetSwagger()
 	if swaggerErr != nil {
-		engine.GetLogger().Fatal("Failed to load OpenAPI spec", zap.Error(swaggerErr))
+		service.Engine.GetLogger().Fatal("Failed to load OpenAPI spec", zap.Error(swaggerErr))
 	}
 
 	// Register OpenAPI defined request handlers for our service
-	api.RegisterHandlers(echoApp, &Service{
-		Engine: engine,
-	})
+	api.RegisterHandlers(echoApp, service)
 
 	// We return the oapi-codegen middleware that handles our OpenAPI defined routes
 	return middleware.OapiRequestValidatorWithOptions(swagger, &middleware.Options{
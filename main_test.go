@@ -3,32 +3,30 @@
 package main
 
 import (
-	"flag"
-	"fmt"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"github.com/UCLALibrary/validation-service/api"
-	"github.com/UCLALibrary/validation-service/pkg/utils"
 	"github.com/UCLALibrary/validation-service/validation"
 	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+	"github.com/UCLALibrary/validation-service/validation/jobs"
 	"github.com/UCLALibrary/validation-service/validation/util"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
-// TestMain configures our log level flag for the main package.
-//
-// Usually this would go in a setup_test.go file, but there is only one test file in this package.
-func TestMain(main *testing.M) {
-	flag.Parse()
-	fmt.Printf("*** Package %s's log level: %s ***\n", utils.GetPackageName(), utils.LogLevel)
-	os.Exit(main.Run())
-}
-
 // TestServerHealth checks if the Echo server initializes properly
 func TestServerHealth(t *testing.T) {
 	// Configure the location of the test profiles file
@@ -93,3 +91,571 @@ func TestStatusEndpoint(t *testing.T) {
 	assert.Equal(t, http.StatusOK, recorder.Code)
 	assert.JSONEq(t, `{"fester":"ok", "filesystem":"ok", "service":"ok"}`, recorder.Body.String())
 }
+
+// TestUploadCSVJob checks that an uploaded CSV file is validated as a job whose report can be retrieved
+func TestUploadCSVJob(t *testing.T) {
+	// Configure the location of the test profiles file
+	if err := os.Setenv(config.ConfigFile, "testdata/test_profiles.json"); err != nil {
+		t.Fatalf("error setting env PROFILES_FILE: %v", err)
+	}
+	defer func() {
+		err := os.Unsetenv(config.ConfigFile)
+		require.NoError(t, err)
+	}()
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+	server.Use(util.ZapLoggerMiddleware(engine.GetLogger()))
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	// Build a multipart upload with a CSV file that has a stray EOL character in it
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("profile", "test"))
+	part, err := writer.CreateFormFile("csvFile", "test.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("Title,Description\n\"One\",\"Bad\nvalue\"\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request := httptest.NewRequest(http.MethodPost, "/upload/csv", body)
+	request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+
+	var job map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &job))
+	jobID, ok := job["id"].(string)
+	require.True(t, ok)
+	assert.Equal(t, "/jobs/"+jobID, recorder.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, "test", job["profile"])
+
+	// Wait for the job to complete
+	assert.Eventually(t, func() bool {
+		request := httptest.NewRequest(http.MethodGet, "/jobs/"+jobID, nil)
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+
+		var status map[string]interface{}
+		return recorder.Code == http.StatusOK && json.Unmarshal(recorder.Body.Bytes(), &status) == nil &&
+			status["status"] == string(jobs.Completed)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Retrieve the completed job's report
+	request = httptest.NewRequest(http.MethodGet, "/jobs/"+jobID+"/report", nil)
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, "test", report["profile"])
+	assert.Len(t, report["warnings"], 1)
+	dialect := map[string]interface{}{"delimiter": ",", "quote": csv.DoubleQuote, "lazyQuotes": false}
+	assert.Equal(t, map[string]interface{}{"format": csv.FormatCSV, "encoding": csv.UTF8, "dialect": dialect},
+		report["source"])
+}
+
+// TestUploadCSVDialect checks that an uploaded CSV file is read in its sniffed, or supplied, dialect
+func TestUploadCSVDialect(t *testing.T) {
+	t.Setenv(config.ConfigFile, "testdata/test_profiles.json")
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	tests := []struct {
+		name      string
+		dialect   string
+		profile   string
+		status    int
+		delimiter string
+		message   string
+	}{
+		{name: "sniffed dialect", status: http.StatusAccepted, delimiter: ";"},
+		{name: "supplied delimiter", dialect: "semicolon", status: http.StatusAccepted, delimiter: ";"},
+		{name: "supplied JSON", dialect: `{"delimiter": ",", "comment": "none"}`, status: http.StatusAccepted,
+			delimiter: ","},
+		{name: "invalid dialect", dialect: `{"delimiter": "::"}`, status: http.StatusBadRequest,
+			message: "The supplied dialect could not be used: the delimiter must be a single character"},
+		{name: "unknown profile", profile: "missing", status: http.StatusBadRequest,
+			message: "The requested profile 'missing' could not be found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			if profile == "" {
+				profile = "test"
+			}
+
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("profile", profile))
+			require.NoError(t, writer.WriteField("dialect", tt.dialect))
+			part, err := writer.CreateFormFile("csvFile", "works.csv")
+			require.NoError(t, err)
+			_, err = part.Write([]byte("Title;Description\nOne;Two\n"))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request := httptest.NewRequest(http.MethodPost, "/upload/csv", body)
+			request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+			if tt.status != http.StatusAccepted {
+				assert.Contains(t, recorder.Body.String(), tt.message)
+				return
+			}
+
+			var status map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+			job := queue.GetJob(status["id"].(string))
+			require.NotNil(t, job)
+
+			// Wait for the job to complete
+			assert.Eventually(t, func() bool {
+				return job.GetStatus() == jobs.Completed
+			}, 5*time.Second, 10*time.Millisecond)
+
+			report := job.GetReport()
+			require.NotNil(t, report.Source)
+			require.NotNil(t, report.Source.Dialect)
+			assert.Equal(t, tt.delimiter, report.Source.Dialect.Delimiter)
+		})
+	}
+}
+
+// TestUploadCSVParseError checks that an uploaded CSV file with records that can't be parsed is reported on, rather
+// than rejected
+func TestUploadCSVParseError(t *testing.T) {
+	t.Setenv(config.ConfigFile, "testdata/test_profiles.json")
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	tests := []struct {
+		name     string
+		profile  string
+		tolerant string
+		lines    []int
+	}{
+		{name: "strict", profile: "test", lines: []int{3}},
+		{name: "tolerant", profile: "structure", tolerant: "true", lines: []int{7, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("profile", tt.profile))
+			require.NoError(t, writer.WriteField("tolerant", tt.tolerant))
+			part, err := writer.CreateFormFile("csvFile", "ragged.csv")
+			require.NoError(t, err)
+			_, err = part.Write([]byte("Title,Description\nOne,Two\nThree,Four,Five\nSix\n\"Seven\nEight\",Nine\n" +
+				"\"Ten\" \"Eleven\",Twelve\n"))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request := httptest.NewRequest(http.MethodPost, "/upload/csv", body)
+			request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusAccepted, recorder.Code, recorder.Body.String())
+
+			var status map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+			job := queue.GetJob(status["id"].(string))
+			require.NotNil(t, job)
+
+			// Wait for the job to complete
+			assert.Eventually(t, func() bool {
+				return job.GetStatus() == jobs.Completed
+			}, 5*time.Second, 10*time.Millisecond)
+
+			// The parse problems come first, followed by the warnings for the records that could be parsed
+			lines := []int{}
+			for _, warning := range job.GetReport().Warnings {
+				lines = append(lines, warning.Line)
+			}
+			assert.Equal(t, tt.lines, lines)
+		})
+	}
+}
+
+// TestUploadWorkbook checks that an Excel workbook's sheet can be uploaded, and its warnings located by their cells
+func TestUploadWorkbook(t *testing.T) {
+	t.Setenv(config.ConfigFile, "testdata/test_profiles.json")
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	// Build a workbook whose second sheet has a stray EOL character in it
+	workbook := &bytes.Buffer{}
+	archive := zip.NewWriter(workbook)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook><sheets><sheet name="Notes" r:id="rId1"/><sheet name="Works" r:id="rId2"/>` +
+			`</sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Target="worksheets/sheet2.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData/></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>Title</t></is>` +
+			`</c></row><row r="2"><c r="A2" t="inlineStr"><is><t>A` + "\n" + `title</t></is></c></row></sheetData>` +
+			`</worksheet>`,
+	} {
+		file, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+
+	tests := []struct {
+		name    string
+		sheet   string
+		status  int
+		message string
+	}{
+		{name: "sheet", sheet: "Works", status: http.StatusAccepted},
+		{name: "empty sheet", sheet: "Notes", status: http.StatusBadRequest,
+			message: "Uploaded CSV file could not be read: sheet 'Notes' doesn't have any data"},
+		{name: "unknown sheet", sheet: "Missing", status: http.StatusBadRequest,
+			message: "Uploaded CSV file could not be read: the workbook doesn't have a sheet named 'Missing'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			require.NoError(t, writer.WriteField("profile", "test"))
+			require.NoError(t, writer.WriteField("sheet", tt.sheet))
+			part, err := writer.CreateFormFile("csvFile", "works.xlsx")
+			require.NoError(t, err)
+			_, err = part.Write(workbook.Bytes())
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			request := httptest.NewRequest(http.MethodPost, "/upload/csv", body)
+			request.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+			if tt.status != http.StatusAccepted {
+				assert.Contains(t, recorder.Body.String(), tt.message)
+				return
+			}
+
+			var status map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+			job := queue.GetJob(status["id"].(string))
+			require.NotNil(t, job)
+
+			// Wait for the job to complete
+			assert.Eventually(t, func() bool {
+				return job.GetStatus() == jobs.Completed
+			}, 5*time.Second, 10*time.Millisecond)
+
+			report := job.GetReport()
+			require.Len(t, report.Warnings, 1)
+			assert.Equal(t, "Works!A2", report.Warnings[0].Cell)
+			assert.Equal(t, &csv.Source{Format: csv.FormatXLSX, Sheet: "Works"}, report.Source)
+		})
+	}
+}
+
+// TestValidatePath checks that CSV files under the HOST_DIR can be validated by their path
+func TestValidatePath(t *testing.T) {
+	// Configure the location of the test profiles file
+	if err := os.Setenv(config.ConfigFile, "testdata/test_profiles.json"); err != nil {
+		t.Fatalf("error setting env PROFILES_FILE: %v", err)
+	}
+	defer func() {
+		err := os.Unsetenv(config.ConfigFile)
+		require.NoError(t, err)
+	}()
+
+	// Create a HOST_DIR with a project directory of CSV files, one of which has a stray EOL character in it
+	hostDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(hostDir, "project", "empty"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "project", "good.csv"), []byte("Title\nOne\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "project", "bad.csv"), []byte("Title\n\"Bad\nvalue\"\n"),
+		0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "project", "latin.csv"), []byte("Title\nCaf\xe9\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(hostDir, "unreadable"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "unreadable", "mixed.csv"), []byte("Title\nCaf\x81\n"),
+		0o600))
+	t.Setenv("HOST_DIR", hostDir)
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 2, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	tests := []struct {
+		name      string
+		path      string
+		profile   string
+		status    int
+		warnings  []string
+		encodings map[string]string
+		message   string
+		failure   string
+	}{
+		{name: "file", path: "project/good.csv", status: http.StatusAccepted, warnings: []string{},
+			encodings: map[string]string{"project/good.csv": csv.UTF8}},
+		{name: "Windows-1252 file", path: "project/latin.csv", status: http.StatusAccepted, warnings: []string{},
+			encodings: map[string]string{"project/latin.csv": csv.Windows1252}},
+		{name: "directory", path: "project", status: http.StatusAccepted, warnings: []string{"project/bad.csv"},
+			encodings: map[string]string{"project/bad.csv": csv.UTF8, "project/good.csv": csv.UTF8,
+				"project/latin.csv": csv.Windows1252}},
+		{name: "unreadable encoding", path: "unreadable", status: http.StatusAccepted,
+			failure: "the CSV file 'unreadable/mixed.csv' could not be read: the file isn't UTF-8, and byte 0x81 on line " +
+				"2 isn't a Windows-1252 character either; please save the file as UTF-8"},
+		{name: "unreadable encoding in a file", path: "unreadable/mixed.csv", status: http.StatusBadRequest,
+			message: "The CSV file 'unreadable/mixed.csv' could not be read: the file isn't UTF-8"},
+		{name: "empty path", path: " ", status: http.StatusBadRequest, message: "A path to validate is required"},
+		{name: "outside HOST_DIR", path: "../project", status: http.StatusBadRequest},
+		{name: "missing file", path: "project/missing.csv", status: http.StatusNotFound},
+		{name: "no CSV files", path: "project/empty", status: http.StatusNotFound},
+		{name: "unknown profile", path: "project/good.csv", profile: "missing", status: http.StatusBadRequest,
+			message: "The requested profile 'missing' could not be found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			if profile == "" {
+				profile = "test"
+			}
+
+			form := url.Values{"path": {tt.path}, "profile": {profile}}
+			request := httptest.NewRequest(http.MethodPost, "/validate/path", strings.NewReader(form.Encode()))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			require.Equal(t, tt.status, recorder.Code, recorder.Body.String())
+			if tt.message != "" {
+				assert.Contains(t, recorder.Body.String(), tt.message)
+			}
+
+			if tt.status != http.StatusAccepted {
+				return
+			}
+
+			var status map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+			job := queue.GetJob(status["id"].(string))
+			require.NotNil(t, job)
+
+			// Directories are read when their job is run, so a file that can't be read fails the job
+			if tt.failure != "" {
+				assert.Eventually(t, func() bool {
+					return job.GetStatus() == jobs.Failed
+				}, 5*time.Second, 10*time.Millisecond)
+				assert.EqualError(t, job.GetError(), tt.failure)
+				return
+			}
+
+			// Wait for the job to complete
+			assert.Eventually(t, func() bool {
+				return job.GetStatus() == jobs.Completed
+			}, 5*time.Second, 10*time.Millisecond)
+
+			files := []string{}
+			for _, warning := range job.GetReport().Warnings {
+				files = append(files, warning.File)
+			}
+			assert.Equal(t, tt.warnings, files)
+
+			// A single file's report has its source, and a combined report has the source of each of its files
+			encodings := map[string]string{}
+			if source := job.GetReport().Source; source != nil {
+				encodings[tt.path] = source.Encoding
+			}
+			for name, source := range job.GetReport().Sources {
+				encodings[name] = source.Encoding
+			}
+			assert.Equal(t, tt.encodings, encodings)
+		})
+	}
+}
+
+// TestUnknownJob checks that requests for a job that doesn't exist return a 404
+func TestUnknownJob(t *testing.T) {
+	// Configure the location of the test profiles file
+	if err := os.Setenv(config.ConfigFile, "testdata/test_profiles.json"); err != nil {
+		t.Fatalf("error setting env PROFILES_FILE: %v", err)
+	}
+	defer func() {
+		err := os.Unsetenv(config.ConfigFile)
+		require.NoError(t, err)
+	}()
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	queue, err := jobs.StartQueue(engine, 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	service := &Service{Engine: engine, Jobs: queue}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	for _, path := range []string{"/jobs/unknown", "/jobs/unknown/report"} {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code, path)
+	}
+}
+
+// TestProfileEndpoints checks that profiles can be listed, retrieved, created, updated, and deleted
+func TestProfileEndpoints(t *testing.T) {
+	// Copy the test profiles file so our changes don't affect other tests
+	data, err := os.ReadFile("testdata/test_profiles.json")
+	require.NoError(t, err)
+	profilesFile := filepath.Join(t.TempDir(), "profiles.json")
+	require.NoError(t, os.WriteFile(profilesFile, data, 0644))
+
+	// Configure the location of the test profiles file
+	if err := os.Setenv(config.ConfigFile, profilesFile); err != nil {
+		t.Fatalf("error setting env PROFILES_FILE: %v", err)
+	}
+	defer func() {
+		err := os.Unsetenv(config.ConfigFile)
+		require.NoError(t, err)
+	}()
+
+	engine, err := validation.NewEngine()
+	require.NoError(t, err)
+
+	service := &Service{Engine: engine}
+	server := echo.New()
+
+	// Register handlers
+	api.RegisterHandlers(server, service)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "List profiles", method: http.MethodGet, path: "/profiles", expectedStatus: http.StatusOK,
+			expectedBody: `"name":"example"`},
+		{name: "Get profile", method: http.MethodGet, path: "/profiles/test", expectedStatus: http.StatusOK,
+			expectedBody: `"name":"EOLCheck"`},
+		{name: "Get unknown profile", method: http.MethodGet, path: "/profiles/unknown",
+			expectedStatus: http.StatusNotFound},
+		{name: "Put unknown validator", method: http.MethodPut, path: "/profiles/new",
+			body: `{"validations":[{"name":"EOLCheck"},{"name":"BogusCheck"}]}`, expectedStatus: http.StatusBadRequest,
+			expectedBody: "BogusCheck"},
+		{name: "Put mismatched name", method: http.MethodPut, path: "/profiles/new",
+			body: `{"name":"other","validations":[{"name":"EOLCheck"}]}`, expectedStatus: http.StatusBadRequest},
+		{name: "Put invalid options", method: http.MethodPut, path: "/profiles/new",
+			body:           `{"validations":[{"name":"ARKCheck","options":{"naans":"21198"}}]}`,
+			expectedStatus: http.StatusBadRequest, expectedBody: "ARKCheck"},
+		{name: "Put no validations", method: http.MethodPut, path: "/profiles/new", body: `{"validations":[]}`,
+			expectedStatus: http.StatusBadRequest},
+		{name: "Create profile", method: http.MethodPut, path: "/profiles/new",
+			body:           `{"validations":[{"name":"EOLCheck","description":"Checks for EOLs"}]}`,
+			expectedStatus: http.StatusCreated, expectedBody: `"name":"new"`},
+		{name: "Update profile", method: http.MethodPut, path: "/profiles/new",
+			body:           `{"name":"new","validations":[{"name":"ARKCheck","options":{"naans":["21198"]}},{"name":"EOLCheck"}]}`,
+			expectedStatus: http.StatusOK, expectedBody: `"options":{"naans":["21198"]}`},
+		{name: "Delete profile", method: http.MethodDelete, path: "/profiles/example",
+			expectedStatus: http.StatusNoContent},
+		{name: "Delete unknown profile", method: http.MethodDelete, path: "/profiles/example",
+			expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tt.expectedBody)
+		})
+	}
+
+	// Confirm that the changes were persisted
+	profiles := config.NewProfiles()
+	require.NoError(t, profiles.Refresh())
+	assert.Equal(t, []string{"new", "structure", "test"}, profiles.GetProfileNames())
+	assert.Equal(t, []string{"ARKCheck", "EOLCheck"}, profiles.GetProfile("new").GetValidations())
+
+	// An update swaps in a new profile, rather than changing the one that's in use
+	current := engine.GetProfiles().GetProfile("new")
+	request := httptest.NewRequest(http.MethodPut, "/profiles/new",
+		strings.NewReader(`{"validations":[{"name":"UnicodeCheck"}]}`))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, []string{"ARKCheck", "EOLCheck"}, current.GetValidations())
+	assert.Equal(t, []string{"UnicodeCheck"}, engine.GetProfiles().GetProfile("new").GetValidations())
+}
@@ -0,0 +1,18 @@
+//go:build unit
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/UCLALibrary/validation-service/pkg/utils"
+	"os"
+	"testing"
+)
+
+// TestMain configures our log level flag for the main package.
+func TestMain(main *testing.M) {
+	flag.Parse()
+	fmt.Printf("*** Package %s's log level: %s ***\n", utils.GetPackageName(), utils.LogLevel)
+	os.Exit(main.Run())
+}
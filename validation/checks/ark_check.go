@@ -19,43 +19,48 @@ const ItemARK = "Item ARK"
 // ParentARK is the ARK of the parent item.
 const ParentARK = "Parent ARK"
 
-// The naanProfiles mapping gives us a way to look up valid NAANs for a profile.
-var naanProfiles = map[string]map[string]struct{}{
-	"DLP Staff": {
-		"21198": {},
-		"13030": {},
-	},
-	"Test": {
-		"21198": {},
-		"13030": {},
-	},
-	"Bucketeer": {
-		"21198": {},
-		"13030": {},
-	},
-	"Fester": {
-		"21198": {},
-		"13030": {},
-	},
+// ARKOptions are the profile options that configure an ARKCheck.
+type ARKOptions struct {
+	NAANs []string `json:"naans"`          // The NAANs that are allowed; if none are configured, any NAAN is allowed
+	NOID  bool     `json:"noid,omitempty"` // Whether object identifiers must be NOIDs with a valid check character
 }
 
+// noidDigits are the characters a NOID can be minted from, in the order of the values they have in its check character.
+const noidDigits = "0123456789bcdfghjkmnpqrstvwxz"
+
 // ARKCheck type is a validator that checks for a valid ARK.
 //
 // It implements the Validator interface and returns an error on failure to validate.
 type ARKCheck struct {
 	profiles *config.Profiles
+	naans    map[string]struct{}
+	noid     bool
 }
 
 // NewARKCheck returns a new ARKCheck, which validates that an ARK identifier is properly formatted.
 //
-// It returns an error if the provided profiles argument is nil.
-func NewARKCheck(profiles *config.Profiles) (*ARKCheck, error) {
+// The supplied validation configures the check with its options. It returns an error if the provided profiles
+// argument is nil or if the validation's options can't be decoded.
+func NewARKCheck(profiles *config.Profiles, validation config.Validation) (*ARKCheck, error) {
+	var options ARKOptions
+
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	naans := make(map[string]struct{}, len(options.NAANs))
+	for _, naan := range options.NAANs {
+		naans[naan] = struct{}{}
+	}
+
 	return &ARKCheck{
 		profiles: profiles,
+		naans:    naans,
+		noid:     options.NOID,
 	}, nil
 }
 
@@ -90,17 +95,20 @@ func (check *ARKCheck) Validate(profile string, location csv.Location, csvData [
 }
 
 // verifyARK validates if the given string is a valid ARK.
+//
+// Both the "ark:/NAAN/..." form and the newer "ark:NAAN/..." form are accepted. If the check is configured to, the
+// object identifier must also be a NOID whose check character matches the rest of the ARK.
 func (check *ARKCheck) verifyARK(ark string, location csv.Location, profile string) error {
 	var errs error
 
-	// Ensure the ARK starts with "ark:/"
-	if !strings.HasPrefix(ark, "ark:/") {
+	// Ensure the ARK starts with "ark:/" or "ark:"
+	if !strings.HasPrefix(ark, "ark:") {
 		errs = multierr.Combine(errs, csv.NewError(errors.NoPrefixErr, location, profile))
 		return errs // Early return since the rest of validation depends on this
 	}
 
-	// Remove "ark:/" for further validation
-	arkBody := strings.TrimPrefix(ark, "ark:/")
+	// Remove "ark:/" or "ark:" for further validation
+	arkBody := strings.TrimPrefix(strings.TrimPrefix(ark, "ark:"), "/")
 
 	// Validate the NAAN separately
 	naanRegex := regexp.MustCompile(`^(\d+)`)
@@ -109,13 +117,18 @@ func (check *ARKCheck) verifyARK(ark string, location csv.Location, profile stri
 		errs = multierr.Combine(errs, csv.NewError(errors.NaanTooShortErr, location, profile))
 	}
 
+	// Without a NAAN, there isn't anything else that can be checked
+	if naanMatch == nil {
+		return errs
+	}
+
 	// Extract NAAN and ObjectIdentifier for further validation
 	naan := naanMatch[1]
 	objectID := strings.TrimPrefix(arkBody, naan)
 	objectID = strings.TrimPrefix(objectID, "/")
 
 	// Validate that the NAAN is allowed for the supplied profile
-	if _, exists := naanProfiles[profile][naan]; !exists {
+	if _, exists := check.naans[naan]; !exists && len(check.naans) > 0 {
 		errs = multierr.Combine(errs, csv.NewError(errors.NaanProfileErr, location, profile))
 	}
 
@@ -128,7 +141,49 @@ func (check *ARKCheck) verifyARK(ark string, location csv.Location, profile stri
 	arkRegex := regexp.MustCompile(`^([\w\-./]+)(\?.*)?$`)
 	if !arkRegex.MatchString(objectID) {
 		errs = multierr.Combine(errs, csv.NewError(errors.InvalidObjIDErr, location, profile))
+	} else if check.noid {
+		errs = multierr.Combine(errs, verifyNOID(naan, objectID, location, profile))
 	}
 
 	return errs
 }
+
+// verifyNOID validates that an ARK's object identifier only uses NOID characters and ends with a valid check character.
+//
+// Qualifiers, which follow the base object identifier after a '/', '.', or '?', aren't a part of the NOID, and hyphens
+// are ignored since they don't change an ARK's identity.
+func verifyNOID(naan string, objectID string, location csv.Location, profile string) error {
+	if index := strings.IndexAny(objectID, "/.?"); index >= 0 {
+		objectID = objectID[:index]
+	}
+
+	noid := strings.ReplaceAll(objectID, "-", "")
+	if noid == "" || strings.Trim(noid, noidDigits) != "" {
+		return csv.NewError(errors.NoidCharErr, location, profile)
+	}
+
+	// The check character is computed over the NAAN and the blade that precedes it
+	blade := naan + "/" + noid[:len(noid)-1]
+	if noid[len(noid)-1] != noidCheckChar(blade) {
+		return csv.NewError(errors.NoidCheckErr, location, profile)
+	}
+
+	return nil
+}
+
+// noidCheckChar returns the NOID check character for the supplied string.
+//
+// Each character's value (its position in noidDigits, or zero if it isn't one) is multiplied by its position in the
+// string, and the sum of these products selects the check character. This catches any single-character error and any
+// transposition of two adjacent characters.
+func noidCheckChar(value string) byte {
+	var sum int
+
+	for index := range len(value) {
+		if digit := strings.IndexByte(noidDigits, value[index]); digit > 0 {
+			sum += digit * (index + 1)
+		}
+	}
+
+	return noidDigits[sum%len(noidDigits)]
+}
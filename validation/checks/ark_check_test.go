@@ -3,12 +3,14 @@
 package checks
 
 import (
+	"encoding/json"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"testing"
 
 	"github.com/UCLALibrary/validation-service/errors"
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/multierr"
 )
 
@@ -17,7 +19,10 @@ var testLocation = csv.Location{}
 
 // TestVerifyARK checks if verifyARK throws the correct errors when given incorrect ARKs
 func TestVerifyARK(t *testing.T) {
-	check, err := NewARKCheck(config.NewProfiles())
+	check, err := NewARKCheck(config.NewProfiles(), config.Validation{
+		Name:    "ARKCheck",
+		Options: json.RawMessage(`{"naans": ["21198", "13030"]}`),
+	})
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -56,6 +61,13 @@ func TestVerifyARK(t *testing.T) {
 			profile:     "DLP Staff",
 			expectError: false,
 		},
+		{
+			name:        "Valid ARK without a slash after the colon",
+			ark:         "ark:21198/xyz123",
+			location:    testLocation,
+			profile:     "DLP Staff",
+			expectError: false,
+		},
 		{
 			name:        "Valid ARK with non-default profile",
 			ark:         "ark:/21198/abc456",
@@ -91,6 +103,14 @@ func TestVerifyARK(t *testing.T) {
 				csv.NewError(errors.NoObjIDErr, testLocation, "DLP Staff"),
 			),
 		},
+		{
+			name:        "Invalid NAAN - not digits",
+			ark:         "ark:/abcde/xyz123",
+			location:    testLocation,
+			profile:     "DLP Staff",
+			expectError: true,
+			expectedErr: csv.NewError(errors.NaanTooShortErr, testLocation, "DLP Staff"),
+		},
 		{
 			name:        "Invalid NAAN for default profile",
 			ark:         "ark:/12345/xyz123",
@@ -130,3 +150,92 @@ func TestVerifyARK(t *testing.T) {
 		})
 	}
 }
+
+// TestVerifyARK_NOID checks that NOID check characters are verified when the check is configured to verify them.
+func TestVerifyARK_NOID(t *testing.T) {
+	check, err := NewARKCheck(config.NewProfiles(), config.Validation{
+		Name:    "ARKCheck",
+		Options: json.RawMessage(`{"noid": true}`),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		ark         string
+		expectedErr error
+	}{
+		{name: "Valid NOID", ark: "ark:/21198/z10332bc"},
+		{name: "Valid NOID from the NOID documentation", ark: "ark:/13030/tf5p30086k"},
+		{name: "Valid NOID without a slash after the colon", ark: "ark:21198/z1090g34"},
+		{name: "Valid NOID with qualifiers", ark: "ark:/21198/z10c95jb/page1.tif?size=full"},
+		{name: "Valid NOID with hyphens", ark: "ark:/21198/z10-332-bc"},
+		{name: "Typo in the blade", ark: "ark:/21198/z10322bc",
+			expectedErr: csv.NewError(errors.NoidCheckErr, testLocation, "Test")},
+		{name: "Transposed characters", ark: "ark:/21198/z10323bc",
+			expectedErr: csv.NewError(errors.NoidCheckErr, testLocation, "Test")},
+		{name: "Typo in the check character", ark: "ark:/21198/z10332bd",
+			expectedErr: csv.NewError(errors.NoidCheckErr, testLocation, "Test")},
+		{name: "Typo in the NAAN", ark: "ark:/21189/z10332bc",
+			expectedErr: csv.NewError(errors.NoidCheckErr, testLocation, "Test")},
+		{name: "Vowel in the blade", ark: "ark:/21198/z10a32bc",
+			expectedErr: csv.NewError(errors.NoidCharErr, testLocation, "Test")},
+		{name: "Uppercase blade", ark: "ark:/21198/Z10332BC",
+			expectedErr: csv.NewError(errors.NoidCharErr, testLocation, "Test")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.verifyARK(tt.ark, testLocation, "Test")
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// Without the option, object identifiers don't need to be NOIDs
+	check, err = NewARKCheck(config.NewProfiles(), config.Validation{Name: "ARKCheck"})
+	require.NoError(t, err)
+	assert.NoError(t, check.verifyARK("ark:/21198/z10322bc", testLocation, "Test"))
+}
+
+// TestNewARKCheck checks that an ARKCheck is configured from its validation options.
+func TestNewARKCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     string
+		ark         string
+		expectError bool
+	}{
+		{name: "No options allows any NAAN", ark: "ark:/12345/xyz123"},
+		{name: "Configured NAAN is allowed", options: `{"naans": ["12345"]}`, ark: "ark:/12345/xyz123"},
+		{name: "Other NAAN is not allowed", options: `{"naans": ["12345"]}`, ark: "ark:/21198/xyz123",
+			expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := NewARKCheck(config.NewProfiles(), config.Validation{
+				Name:    "ARKCheck",
+				Options: json.RawMessage(tt.options),
+			})
+			require.NoError(t, err)
+
+			err = check.verifyARK(tt.ark, testLocation, "Test")
+			if tt.expectError {
+				assert.ErrorIs(t, err, csv.NewError(errors.NaanProfileErr, testLocation, "Test"))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// Options the check doesn't know about are rejected
+	_, err := NewARKCheck(config.NewProfiles(), config.Validation{
+		Name:    "ARKCheck",
+		Options: json.RawMessage(`{"naan": ["12345"]}`),
+	})
+	assert.Error(t, err)
+}
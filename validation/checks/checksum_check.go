@@ -0,0 +1,476 @@
+package checks
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// Checksum is the default header of the column with the checksums of the files named in File Name.
+const Checksum = "Checksum"
+
+// digestSizes are the supported checksum algorithms, keyed by the length of their hex-encoded digests.
+var digestSizes = map[int]string{32: "md5", 40: "sha1", 64: "sha256", 128: "sha512"}
+
+// manifestDecoder decodes the characters that are percent-encoded in the paths of BagIt manifests.
+var manifestDecoder = strings.NewReplacer("%0A", "\n", "%0a", "\n", "%0D", "\r", "%0d", "\r", "%25", "%")
+
+// ChecksumOptions are the profile options that configure a ChecksumCheck.
+type ChecksumOptions struct {
+	Column    string   `json:"column,omitempty"`    // The header of the checksum column (default: Checksum)
+	Manifests []string `json:"manifests,omitempty"` // Manifests, relative to HOST_DIR, that list the files' checksums
+	Workers   int      `json:"workers,omitempty"`   // How many files are hashed at the same time (default: CPU count)
+}
+
+// digest is a checksum and the algorithm that created it.
+type digest struct {
+	algorithm string
+	value     string
+}
+
+// expectedDigest is a checksum that a file must have and the location at which a mismatch is reported.
+type expectedDigest struct {
+	digest
+	location csv.Location
+}
+
+// checksumFile is a file whose checksums are verified, along with the checksums that it's expected to have.
+type checksumFile struct {
+	name     string
+	path     string
+	expected []expectedDigest
+}
+
+// digestKey identifies a checksum of a file in the digest cache.
+type digestKey struct {
+	path      string
+	algorithm string
+}
+
+// digestEntry is a cached checksum, along with the state of the file when it was created.
+type digestEntry struct {
+	modTime time.Time
+	size    int64
+	value   string
+}
+
+// digestCache caches checksums, so files are only hashed again if they've changed.
+type digestCache struct {
+	sync.Mutex
+	entries map[digestKey]digestEntry
+}
+
+// hostDigests is the cache of checksums of files in the HOST_DIR that's shared by all ChecksumChecks.
+var hostDigests = &digestCache{entries: make(map[digestKey]digestEntry)}
+
+// ChecksumCheck validates the checksums of the files named in the File Name column.
+//
+// Checksums can come from a checksum column or from BagIt-style manifests. Files are hashed by a pool of workers, and
+// their checksums are cached until the files change. Files that don't exist are left for the FilePathCheck to report.
+type ChecksumCheck struct {
+	profiles    *config.Profiles
+	column      string
+	manifests   []string
+	workers     int
+	fileSystem  fs.FS
+	cache       *digestCache
+	listed      map[string]digest
+	hasManifest bool
+	manifestErr error
+	problems    csvCache[map[csv.Location][]string]
+}
+
+// NewChecksumCheck creates a new ChecksumCheck instance, which validates the checksums of the files in a CSV.
+//
+// A file system can be supplied for the files and manifests to be read from; otherwise, they're read from the
+// HOST_DIR. It returns an error if the profiles argument is nil or if the validation's options can't be decoded.
+func NewChecksumCheck(profiles *config.Profiles, validation config.Validation, suppliedFS ...fs.FS) (*ChecksumCheck,
+	error) {
+	var options ChecksumOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &ChecksumCheck{
+		profiles:  profiles,
+		column:    options.Column,
+		manifests: options.Manifests,
+		workers:   options.Workers,
+		cache:     hostDigests,
+	}
+
+	if check.column == "" {
+		check.column = Checksum
+	}
+
+	if check.workers <= 0 {
+		check.workers = runtime.NumCPU()
+	}
+
+	// Checksums of files in a supplied file system aren't shared, since its paths could mean different files
+	if len(suppliedFS) > 0 && suppliedFS[0] != nil {
+		check.fileSystem = suppliedFS[0]
+		check.cache = &digestCache{entries: make(map[digestKey]digestEntry)}
+	}
+
+	return check, nil
+}
+
+// Validate checks that the file named in a File Name cell matches its checksums.
+//
+// Mismatches with a checksum column are reported at the checksum's cell and mismatches with a manifest are reported
+// at the File Name's cell, as are files that aren't listed in any of the manifests. The files in a CSV are all hashed
+// the first time one of its cells is validated.
+func (check *ChecksumCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	// Skip cells that aren't in the File Name or checksum columns, unless they might be where a manifest error goes
+	if header != FileName && header != check.column && location != (csv.Location{}) {
+		return nil
+	}
+
+	if check.fileSystem == nil {
+		if check.fileSystem, _, err = openHostDir(location, profile); err != nil {
+			return err
+		}
+	}
+
+	problems := check.problems.get(csvData, check.findProblems)
+
+	// Manifests that can't be read are reported once, on the first cell of the header row
+	if location.RowIndex == 0 {
+		if location.ColIndex == 0 && check.manifestErr != nil {
+			return csv.NewError(fmt.Sprintf(errors.ManifestFileErr, check.manifestErr), location, profile)
+		}
+
+		return nil
+	}
+
+	var errs error
+
+	for _, problem := range problems[location] {
+		errs = multierr.Combine(errs, csv.NewError(problem, location, profile))
+	}
+
+	return errs
+}
+
+// findProblems finds the checksum problems of the files in the supplied CSV data, keyed by where they're reported.
+//
+// The files are hashed by a pool of workers.
+func (check *ChecksumCheck) findProblems(csvData [][]string) map[csv.Location][]string {
+	problems := make(map[csv.Location][]string)
+
+	if check.listed == nil {
+		check.loadManifests()
+	}
+
+	files := check.findFiles(csvData, problems)
+
+	queue := make(chan checksumFile)
+	var mutex sync.Mutex
+	var group sync.WaitGroup
+
+	for range check.workers {
+		group.Go(func() {
+			for file := range queue {
+				mismatches := check.verify(file)
+
+				mutex.Lock()
+				for location, messages := range mismatches {
+					problems[location] = append(problems[location], messages...)
+				}
+				mutex.Unlock()
+			}
+		})
+	}
+
+	for _, file := range files {
+		queue <- file
+	}
+
+	close(queue)
+	group.Wait()
+
+	return problems
+}
+
+// findFiles finds the files in the CSV data that have checksums to verify, recording any problems with their entries.
+func (check *ChecksumCheck) findFiles(csvData [][]string, problems map[csv.Location][]string) []checksumFile {
+	fileColumn := slices.Index(csvData[0], FileName)
+	checksumColumn := slices.Index(csvData[0], check.column)
+
+	if fileColumn < 0 || (checksumColumn < 0 && !check.hasManifest) {
+		return nil
+	}
+
+	var files []checksumFile
+
+	for row := 1; row < len(csvData); row++ {
+		if fileColumn >= len(csvData[row]) || strings.TrimSpace(csvData[row][fileColumn]) == "" {
+			continue
+		}
+
+		name := csvData[row][fileColumn]
+		file := checksumFile{name: name}
+		fileLocation := csv.Location{RowIndex: row, ColIndex: fileColumn}
+
+		// Paths that don't exist, or that lead outside the HOST_DIR, are reported by the FilePathCheck
+		resolved, err := csv.ResolveFS(check.fileSystem, stripPrefix(name))
+		if err != nil {
+			continue
+		}
+
+		file.path = resolved
+
+		if checksumColumn >= 0 && checksumColumn < len(csvData[row]) && csvData[row][checksumColumn] != "" {
+			location := csv.Location{RowIndex: row, ColIndex: checksumColumn}
+
+			if value, ok := parseDigest(csvData[row][checksumColumn]); ok {
+				file.expected = append(file.expected, expectedDigest{digest: value, location: location})
+			} else {
+				message := fmt.Sprintf(errors.ChecksumFormatErr, csvData[row][checksumColumn])
+				problems[location] = append(problems[location], message)
+			}
+		}
+
+		if check.hasManifest {
+			if value, found := check.findListed(stripPrefix(name), resolved); found {
+				file.expected = append(file.expected, expectedDigest{digest: value, location: fileLocation})
+			} else {
+				message := fmt.Sprintf(errors.ManifestEntryErr, name)
+				problems[fileLocation] = append(problems[fileLocation], message)
+			}
+		}
+
+		if len(file.expected) > 0 {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// verify hashes a file and compares its checksums with the expected ones, returning any mismatches.
+func (check *ChecksumCheck) verify(file checksumFile) map[csv.Location][]string {
+	problems := make(map[csv.Location][]string)
+
+	var algorithms []string
+	for _, expected := range file.expected {
+		if !slices.Contains(algorithms, expected.algorithm) {
+			algorithms = append(algorithms, expected.algorithm)
+		}
+	}
+
+	digests, err := check.hashFile(file.path, algorithms)
+	if err != nil {
+		location := file.expected[0].location
+		problems[location] = append(problems[location], fmt.Sprintf(errors.ChecksumReadErr, file.name, err))
+
+		return problems
+	}
+
+	for _, expected := range file.expected {
+		if actual := digests[expected.algorithm]; actual != expected.value {
+			message := fmt.Sprintf(errors.ChecksumMismatchErr, expected.algorithm, file.name, expected.value, actual)
+			problems[expected.location] = append(problems[expected.location], message)
+		}
+	}
+
+	return problems
+}
+
+// hashFile returns the supplied algorithms' checksums of a file, hashing it only if a checksum isn't in the cache.
+//
+// Files are streamed through all the algorithms that are needed at the same time, so they're only read once.
+func (check *ChecksumCheck) hashFile(filePath string, algorithms []string) (map[string]string, error) {
+	info, err := fs.Stat(check.fileSystem, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	digests := make(map[string]string, len(algorithms))
+	hashes := make(map[string]hash.Hash)
+
+	check.cache.Lock()
+	for _, algorithm := range algorithms {
+		entry, found := check.cache.entries[digestKey{path: filePath, algorithm: algorithm}]
+		if found && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			digests[algorithm] = entry.value
+		} else {
+			hashes[algorithm] = newHash(algorithm)
+		}
+	}
+	check.cache.Unlock()
+
+	if len(hashes) == 0 {
+		return digests, nil
+	}
+
+	file, err := check.fileSystem.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	writers := make([]io.Writer, 0, len(hashes))
+	for _, hasher := range hashes {
+		writers = append(writers, hasher)
+	}
+
+	if _, err = io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return nil, err
+	}
+
+	check.cache.Lock()
+	for algorithm, hasher := range hashes {
+		digests[algorithm] = hex.EncodeToString(hasher.Sum(nil))
+		check.cache.entries[digestKey{path: filePath, algorithm: algorithm}] = digestEntry{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			value:   digests[algorithm],
+		}
+	}
+	check.cache.Unlock()
+
+	return digests, nil
+}
+
+// loadManifests reads the checksums that are listed in the check's manifests.
+//
+// Manifests are only read if they're configured, and a configured manifest that can't be read is reported.
+func (check *ChecksumCheck) loadManifests() {
+	check.listed = make(map[string]digest)
+
+	for _, manifest := range check.manifests {
+		if err := check.loadManifest(manifest); err != nil {
+			check.manifestErr = multierr.Append(check.manifestErr, fmt.Errorf("%s: %w", manifest, err))
+			continue
+		}
+
+		check.hasManifest = true
+	}
+}
+
+// loadManifest reads the checksums that are listed in a BagIt-style manifest.
+//
+// Each line of the manifest has a checksum and the path of a file, relative to the manifest.
+func (check *ChecksumCheck) loadManifest(manifest string) error {
+	manifestPath, err := csv.ResolveFS(check.fileSystem, manifest)
+	if err != nil {
+		return err
+	}
+
+	file, err := check.fileSystem.Open(manifestPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// The manifest's name, like manifest-sha256.txt, tells us which algorithm it uses
+	algorithm := strings.TrimSuffix(strings.TrimPrefix(path.Base(manifestPath), "manifest-"), ".txt")
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		checksum, filePath, found := strings.Cut(strings.ReplaceAll(text, "\t", " "), " ")
+		value, ok := parseDigest(checksum)
+
+		if !found || !ok || (newHash(algorithm) != nil && value.algorithm != algorithm) {
+			return fmt.Errorf("line %d is not a valid manifest entry", line)
+		}
+
+		// Paths in the manifest are relative to the manifest, and may be marked as binary (as in md5sum's output)
+		filePath = manifestDecoder.Replace(strings.TrimLeft(filePath, " *"))
+		check.listed[path.Join(path.Dir(manifestPath), filePath)] = value
+	}
+
+	return scanner.Err()
+}
+
+// findListed finds the checksum of a file in the manifests, by the name it's given in the CSV or its resolved path.
+func (check *ChecksumCheck) findListed(name string, resolved string) (digest, bool) {
+	if value, found := check.listed[path.Clean(name)]; found {
+		return value, true
+	}
+
+	value, found := check.listed[resolved]
+	return value, found
+}
+
+// parseDigest parses a hex-encoded checksum, which can be prefixed with its algorithm (e.g., sha256:...).
+//
+// The algorithm of a checksum without a prefix is determined by its length.
+func parseDigest(value string) (digest, bool) {
+	algorithm, checksum, found := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+	if !found {
+		checksum = algorithm
+	}
+
+	if _, err := hex.DecodeString(checksum); err != nil {
+		return digest{}, false
+	}
+
+	sized, known := digestSizes[len(checksum)]
+	if !known || (found && strings.ReplaceAll(algorithm, "-", "") != sized) {
+		return digest{}, false
+	}
+
+	return digest{algorithm: sized, value: checksum}, true
+}
+
+// newHash returns a new hash for the supplied algorithm, or nil if the algorithm isn't supported.
+func newHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "md5":
+		return md5.New()
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "sha512":
+		return sha512.New()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+//go:build unit
+
+package checks
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// sha256Hex returns the hex-encoded SHA-256 checksum of the supplied data.
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// md5Hex returns the hex-encoded MD5 checksum of the supplied data.
+func md5Hex(data string) string {
+	sum := md5.Sum([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// TestChecksumCheck_Validate tests the Validate method on ChecksumCheck.
+func TestChecksumCheck_Validate(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"bag/data/one.tif":   {Data: []byte("one")},
+		"bag/data/two.tif":   {Data: []byte("two")},
+		"bag/data/three.tif": {Data: []byte("three")},
+		"bag/manifest-sha256.txt": {Data: []byte(sha256Hex("one") + "  data/one.tif\n" +
+			sha256Hex("changed") + " *data/two.tif\n")},
+	}
+
+	check, err := NewChecksumCheck(config.NewProfiles(), config.Validation{
+		Name:    "ChecksumCheck",
+		Options: json.RawMessage(`{"column": "MD5", "manifests": ["bag/manifest-sha256.txt"], "workers": 2}`),
+	}, fileSystem)
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Title", "File Name", "MD5"},
+		{"One", "Masters/bag/data/one.tif", md5Hex("one")},
+		{"Two", "bag/data/two.tif", "sha256:" + sha256Hex("two")},
+		{"Three", "bag/data/three.tif", md5Hex("tree")},
+		{"Four", "bag/data/four.tif", md5Hex("four")},
+		{"Five", "bag/data/one.tif", "not a checksum"},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{name: "header row", location: csv.Location{RowIndex: 0, ColIndex: 0}},
+		{name: "not a checked column", location: csv.Location{RowIndex: 1, ColIndex: 0}},
+		{name: "matching manifest", location: csv.Location{RowIndex: 1, ColIndex: 1}},
+		{name: "matching column", location: csv.Location{RowIndex: 1, ColIndex: 2}},
+		{name: "mismatched manifest", location: csv.Location{RowIndex: 2, ColIndex: 1}, expectedErr: []string{
+			fmt.Sprintf(errors.ChecksumMismatchErr, "sha256", "bag/data/two.tif", sha256Hex("changed"), sha256Hex("two")),
+		}},
+		{name: "prefixed column", location: csv.Location{RowIndex: 2, ColIndex: 2}},
+		{name: "not in manifest", location: csv.Location{RowIndex: 3, ColIndex: 1}, expectedErr: []string{
+			fmt.Sprintf(errors.ManifestEntryErr, "bag/data/three.tif"),
+		}},
+		{name: "mismatched column", location: csv.Location{RowIndex: 3, ColIndex: 2}, expectedErr: []string{
+			fmt.Sprintf(errors.ChecksumMismatchErr, "md5", "bag/data/three.tif", md5Hex("tree"), md5Hex("three")),
+		}},
+		{name: "missing file", location: csv.Location{RowIndex: 4, ColIndex: 1}},
+		{name: "invalid checksum", location: csv.Location{RowIndex: 5, ColIndex: 2}, expectedErr: []string{
+			fmt.Sprintf(errors.ChecksumFormatErr, "not a checksum"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("DLP Staff", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range tt.expectedErr {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+// TestChecksumCheck_Manifests tests that only configured manifests are read, and that ones that can't be read are
+// reported.
+func TestChecksumCheck_Manifests(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"one.tif":          {Data: []byte("one")},
+		"manifest-md5.txt": {Data: []byte(md5Hex("one") + " one.tif\n")},
+		"manifest-bad.txt": {Data: []byte("not a manifest\n")},
+	}
+	data := [][]string{{"File Name"}, {"one.tif"}}
+
+	// A manifest in the HOST_DIR isn't used unless it's configured
+	check, err := NewChecksumCheck(config.NewProfiles(), config.Validation{Name: "ChecksumCheck"}, fileSystem)
+	require.NoError(t, err)
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 0, ColIndex: 0}, data))
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+	assert.False(t, check.hasManifest)
+
+	check, err = NewChecksumCheck(config.NewProfiles(), config.Validation{
+		Name:    "ChecksumCheck",
+		Options: json.RawMessage(`{"manifests": ["manifest-md5.txt"]}`),
+	}, fileSystem)
+	require.NoError(t, err)
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 0, ColIndex: 0}, data))
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+	assert.True(t, check.hasManifest)
+
+	check, err = NewChecksumCheck(config.NewProfiles(), config.Validation{
+		Name:    "ChecksumCheck",
+		Options: json.RawMessage(`{"manifests": ["manifest-sha256.txt", "manifest-bad.txt"]}`),
+	}, fileSystem)
+	require.NoError(t, err)
+
+	err = check.Validate("DLP Staff", csv.Location{RowIndex: 0, ColIndex: 0}, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "manifest-sha256.txt")
+	assert.Contains(t, err.Error(), "manifest-bad.txt: line 1")
+}
+
+// TestChecksumCheck_Cache tests that checksums are cached until a file changes.
+func TestChecksumCheck_Cache(t *testing.T) {
+	modTime := time.Now()
+	fileSystem := fstest.MapFS{"one.tif": {Data: []byte("one"), ModTime: modTime}}
+
+	check, err := NewChecksumCheck(config.NewProfiles(), config.Validation{Name: "ChecksumCheck"}, fileSystem)
+	require.NoError(t, err)
+
+	digests, err := check.hashFile("one.tif", []string{"md5"})
+	require.NoError(t, err)
+	assert.Equal(t, md5Hex("one"), digests["md5"])
+
+	// A file with the same modification time and size isn't hashed again
+	fileSystem["one.tif"].Data = []byte("two")
+	digests, err = check.hashFile("one.tif", []string{"md5", "sha256"})
+	require.NoError(t, err)
+	assert.Equal(t, md5Hex("one"), digests["md5"])
+	assert.Equal(t, sha256Hex("two"), digests["sha256"])
+
+	fileSystem["one.tif"].ModTime = modTime.Add(time.Second)
+	digests, err = check.hashFile("one.tif", []string{"md5"})
+	require.NoError(t, err)
+	assert.Equal(t, md5Hex("two"), digests["md5"])
+}
+
+// TestParseDigest tests that checksums are parsed, and their algorithms determined.
+func TestParseDigest(t *testing.T) {
+	value, ok := parseDigest(" " + md5Hex("one") + " ")
+	assert.True(t, ok)
+	assert.Equal(t, digest{algorithm: "md5", value: md5Hex("one")}, value)
+
+	value, ok = parseDigest("SHA-256:" + sha256Hex("one"))
+	assert.True(t, ok)
+	assert.Equal(t, digest{algorithm: "sha256", value: sha256Hex("one")}, value)
+
+	_, ok = parseDigest("md5:" + sha256Hex("one"))
+	assert.False(t, ok)
+
+	_, ok = parseDigest("abc")
+	assert.False(t, ok)
+
+	_, ok = parseDigest("zz" + md5Hex("one")[2:])
+	assert.False(t, ok)
+}
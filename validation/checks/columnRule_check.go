@@ -0,0 +1,286 @@
+package checks
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// RuleCondition is a condition on another column in the same row (e.g., 'Object Type' is 'Page').
+type RuleCondition struct {
+	Header string   `json:"header"` // The header of the column to check
+	Values []string `json:"values"` // The values that satisfy the condition
+}
+
+// ColumnRule is a declarative rule for the values in a single column.
+//
+// Empty cells are only checked by Required and RequiredIf; the other constraints are checked against each value in a
+// non-empty cell. When a Delimiter is set, a cell's value is split into multiple values before it's checked.
+type ColumnRule struct {
+	Header     string         `json:"header"`               // The header of the column the rule applies to
+	Required   bool           `json:"required,omitempty"`   // Whether the column, and its values, must be present
+	RequiredIf *RuleCondition `json:"requiredIf,omitempty"` // A value is required when the condition is met
+	Pattern    string         `json:"pattern,omitempty"`    // A regular expression that must match a whole value
+	Values     []string       `json:"values,omitempty"`     // An enumeration of the values that are allowed
+	Min        *int           `json:"min,omitempty"`        // The minimum allowed integer value
+	Max        *int           `json:"max,omitempty"`        // The maximum allowed integer value
+	Delimiter  string         `json:"delimiter,omitempty"`  // The delimiter that separates multiple values
+}
+
+// ColumnRuleOptions are the profile options that configure a ColumnRuleCheck.
+type ColumnRuleOptions struct {
+	Rules []ColumnRule `json:"rules"`
+}
+
+// columnRule is a ColumnRule that's been prepared for use.
+type columnRule struct {
+	ColumnRule
+	pattern *regexp.Regexp
+	values  map[string]struct{}
+}
+
+// ColumnRuleCheck is a validator that checks columns against the rules configured in a profile.
+//
+// It implements the Validator interface and returns an error on failure to validate.
+type ColumnRuleCheck struct {
+	profiles *config.Profiles
+	rules    map[string][]columnRule
+	headers  []string
+}
+
+// NewColumnRuleCheck returns a new ColumnRuleCheck, which validates columns against declarative rules.
+//
+// The supplied validation configures the check with its rules. It returns an error if the provided profiles argument
+// is nil or if any of the rules are invalid.
+func NewColumnRuleCheck(profiles *config.Profiles, validation config.Validation) (*ColumnRuleCheck, error) {
+	var options ColumnRuleOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &ColumnRuleCheck{
+		profiles: profiles,
+		rules:    make(map[string][]columnRule),
+	}
+
+	for index, rule := range options.Rules {
+		prepared, err := prepareRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid column rule %d: %w", index, err)
+		}
+
+		// Keep track of the order of the headers so required columns are reported consistently
+		if _, exists := check.rules[rule.Header]; !exists {
+			check.headers = append(check.headers, rule.Header)
+		}
+
+		check.rules[rule.Header] = append(check.rules[rule.Header], prepared)
+	}
+
+	return check, nil
+}
+
+// Validate checks a data cell against the rules configured for its column.
+//
+// The cells that a short row is missing are treated as empty, and they're checked at the row's last cell.
+func (check *ColumnRuleCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Required columns are checked once, while we're on the first cell of the header row
+	if location.RowIndex == 0 {
+		if location.ColIndex == 0 {
+			return check.checkHeaders(profile, location, csvData)
+		}
+
+		return nil
+	}
+
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+
+	value := csvData[location.RowIndex][location.ColIndex]
+	for _, rule := range check.rules[header] {
+		errs = multierr.Combine(errs, rule.check(value, profile, location, csvData))
+	}
+
+	// A short row's missing cells are checked as empty cells at the row's last cell
+	if location.ColIndex == len(csvData[location.RowIndex])-1 && location.ColIndex+1 < len(csvData[0]) {
+		for _, missing := range csvData[0][location.ColIndex+1:] {
+			for _, rule := range check.rules[missing] {
+				errs = multierr.Combine(errs, rule.check("", profile, location, csvData))
+			}
+		}
+	}
+
+	return errs
+}
+
+// checkHeaders checks that the columns that are required are present in the header row.
+func (check *ColumnRuleCheck) checkHeaders(profile string, location csv.Location, csvData [][]string) error {
+	var errs error
+
+	present := make(map[string]struct{}, len(csvData[0]))
+	for _, header := range csvData[0] {
+		present[header] = struct{}{}
+	}
+
+	for _, header := range check.headers {
+		if _, exists := present[header]; exists {
+			continue
+		}
+
+		for _, rule := range check.rules[header] {
+			if rule.Required {
+				message := fmt.Sprintf(errors.FieldNotFoundErr, header)
+				errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+// check checks a data cell's value against the rule.
+func (rule *columnRule) check(value string, profile string, location csv.Location, csvData [][]string) error {
+	var errs error
+
+	if strings.TrimSpace(value) == "" {
+		if rule.isRequired(profile, location, csvData) {
+			return csv.NewError(fmt.Sprintf(errors.FieldDataNotFoundErr, rule.Header), location, profile)
+		}
+
+		return nil
+	}
+
+	values := []string{value}
+	if rule.Delimiter != "" {
+		values = strings.Split(value, rule.Delimiter)
+	}
+
+	for _, part := range values {
+		if rule.pattern != nil && !rule.pattern.MatchString(part) {
+			message := fmt.Sprintf(errors.RulePatternErr, part, rule.Header)
+			errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+		}
+
+		if len(rule.values) > 0 {
+			if _, allowed := rule.values[part]; !allowed {
+				message := fmt.Sprintf(errors.RuleValueErr, part, rule.Header)
+				errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+			}
+		}
+
+		if rule.Min != nil || rule.Max != nil {
+			errs = multierr.Combine(errs, rule.checkRange(part, profile, location))
+		}
+	}
+
+	return errs
+}
+
+// checkRange checks that a value is an integer within the rule's range.
+func (rule *columnRule) checkRange(value string, profile string, location csv.Location) error {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return csv.NewError(fmt.Sprintf(errors.RuleNotAnIntErr, value, rule.Header), location, profile)
+	}
+
+	if (rule.Min != nil && number < *rule.Min) || (rule.Max != nil && number > *rule.Max) {
+		return csv.NewError(fmt.Sprintf(errors.RuleRangeErr, value, rule.Header, rule.describeRange()), location,
+			profile)
+	}
+
+	return nil
+}
+
+// describeRange describes the rule's integer range for use in an error message.
+func (rule *columnRule) describeRange() string {
+	switch {
+	case rule.Min != nil && rule.Max != nil:
+		return fmt.Sprintf("between %d and %d", *rule.Min, *rule.Max)
+	case rule.Min != nil:
+		return fmt.Sprintf("at least %d", *rule.Min)
+	default:
+		return fmt.Sprintf("at most %d", *rule.Max)
+	}
+}
+
+// isRequired checks whether the rule requires a value in the row of the supplied location.
+func (rule *columnRule) isRequired(profile string, location csv.Location, csvData [][]string) bool {
+	if rule.Required {
+		return true
+	}
+
+	if rule.RequiredIf == nil {
+		return false
+	}
+
+	// If the conditional column isn't there, its condition can't be met
+	rowValue, err := csv.GetRowValue(rule.RequiredIf.Header, location, csvData, profile)
+	if err != nil {
+		return false
+	}
+
+	for _, value := range rule.RequiredIf.Values {
+		if rowValue == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// prepareRule checks that a rule is valid and prepares it for use.
+func prepareRule(rule ColumnRule) (columnRule, error) {
+	prepared := columnRule{ColumnRule: rule}
+
+	if rule.Header == "" {
+		return prepared, fmt.Errorf("a header is required")
+	}
+
+	if rule.RequiredIf != nil && rule.RequiredIf.Header == "" {
+		return prepared, fmt.Errorf("requiredIf for '%s' must have a header", rule.Header)
+	}
+
+	if rule.Min != nil && rule.Max != nil && *rule.Min > *rule.Max {
+		return prepared, fmt.Errorf("min for '%s' is greater than its max", rule.Header)
+	}
+
+	// Patterns are anchored so that they must match the whole value
+	if rule.Pattern != "" {
+		pattern, err := regexp.Compile(`^(?:` + rule.Pattern + `)$`)
+		if err != nil {
+			return prepared, fmt.Errorf("pattern for '%s' is not valid: %w", rule.Header, err)
+		}
+
+		prepared.pattern = pattern
+	}
+
+	if len(rule.Values) > 0 {
+		prepared.values = make(map[string]struct{}, len(rule.Values))
+		for _, value := range rule.Values {
+			prepared.values[value] = struct{}{}
+		}
+	}
+
+	return prepared, nil
+}
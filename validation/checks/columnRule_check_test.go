@@ -0,0 +1,194 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// testColumnRules are the column rules used to test the ColumnRuleCheck.
+const testColumnRules = `{
+	"rules": [
+		{"header": "Visibility", "required": true, "values": ["open", "ucla", "private"]},
+		{"header": "Item Sequence", "requiredIf": {"header": "Object Type", "values": ["Page"]}, "min": 1},
+		{"header": "Rating", "min": 1, "max": 5},
+		{"header": "Subject", "pattern": "[A-Z][a-z]+", "delimiter": "|~|"}
+	]
+}`
+
+// TestColumnRuleCheck_Validate tests the Validate method on ColumnRuleCheck.
+func TestColumnRuleCheck_Validate(t *testing.T) {
+	check, err := NewColumnRuleCheck(config.NewProfiles(), config.Validation{
+		Name:    "ColumnRuleCheck",
+		Options: json.RawMessage(testColumnRules),
+	})
+	require.NoError(t, err)
+
+	headers := []string{"Visibility", "Item Sequence", "Object Type", "Rating", "Subject"}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		data        [][]string
+		expectedErr bool
+	}{
+		{
+			name:     "Allowed value",
+			location: csv.Location{RowIndex: 1, ColIndex: 0},
+			data:     [][]string{headers, {"open", "", "Work", "", ""}},
+		},
+		{
+			name:        "Value that isn't allowed",
+			location:    csv.Location{RowIndex: 1, ColIndex: 0},
+			data:        [][]string{headers, {"public", "", "Work", "", ""}},
+			expectedErr: true,
+		},
+		{
+			name:        "Missing required value",
+			location:    csv.Location{RowIndex: 1, ColIndex: 0},
+			data:        [][]string{headers, {" ", "", "Work", "", ""}},
+			expectedErr: true,
+		},
+		{
+			name:     "Missing conditionally required value that isn't required",
+			location: csv.Location{RowIndex: 1, ColIndex: 1},
+			data:     [][]string{headers, {"open", "", "Work", "", ""}},
+		},
+		{
+			name:        "Missing conditionally required value that is required",
+			location:    csv.Location{RowIndex: 1, ColIndex: 1},
+			data:        [][]string{headers, {"open", "", "Page", "", ""}},
+			expectedErr: true,
+		},
+		{
+			name:        "Value below the minimum",
+			location:    csv.Location{RowIndex: 1, ColIndex: 1},
+			data:        [][]string{headers, {"open", "0", "Page", "", ""}},
+			expectedErr: true,
+		},
+		{
+			name:     "Value within the range",
+			location: csv.Location{RowIndex: 1, ColIndex: 3},
+			data:     [][]string{headers, {"open", "", "Work", "5", ""}},
+		},
+		{
+			name:        "Value above the maximum",
+			location:    csv.Location{RowIndex: 1, ColIndex: 3},
+			data:        [][]string{headers, {"open", "", "Work", "6", ""}},
+			expectedErr: true,
+		},
+		{
+			name:        "Value that isn't an integer",
+			location:    csv.Location{RowIndex: 1, ColIndex: 3},
+			data:        [][]string{headers, {"open", "", "Work", "five", ""}},
+			expectedErr: true,
+		},
+		{
+			name:     "Multiple values that match the pattern",
+			location: csv.Location{RowIndex: 1, ColIndex: 4},
+			data:     [][]string{headers, {"open", "", "Work", "", "Cats|~|Dogs"}},
+		},
+		{
+			name:        "Multiple values where one doesn't match the anchored pattern",
+			location:    csv.Location{RowIndex: 1, ColIndex: 4},
+			data:        [][]string{headers, {"open", "", "Work", "", "Cats|~|Dogs and cats"}},
+			expectedErr: true,
+		},
+		{
+			name:     "Column without any rules",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+			data:     [][]string{headers, {"open", "", "Anything", "", ""}},
+		},
+		{
+			name:     "Header row with required columns",
+			location: csv.Location{RowIndex: 0, ColIndex: 0},
+			data:     [][]string{headers, {"open", "", "Work", "", ""}},
+		},
+		{
+			name:        "Header row missing a required column",
+			location:    csv.Location{RowIndex: 0, ColIndex: 0},
+			data:        [][]string{{"Item Sequence", "Object Type"}, {"1", "Page"}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, tt.data)
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestColumnRuleCheck_RaggedRows tests that the cells a short row is missing are checked as empty cells.
+func TestColumnRuleCheck_RaggedRows(t *testing.T) {
+	check, err := NewColumnRuleCheck(config.NewProfiles(), config.Validation{
+		Name:    "ColumnRuleCheck",
+		Options: json.RawMessage(testColumnRules),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Object Type", "Rating", "Item Sequence", "Visibility"},
+		{"Page"},
+		{"Work", "3"},
+		{"Page", "3", "1", "open", "extra"},
+	}
+
+	// The missing cells are checked at the short row's last cell
+	err = check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, data)
+	assert.Len(t, multierr.Errors(err), 2)
+	assert.ErrorContains(t, err, "`Item Sequence`")
+	assert.ErrorContains(t, err, "`Visibility`")
+
+	err = check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 1}, data)
+	assert.Len(t, multierr.Errors(err), 1)
+	assert.ErrorContains(t, err, "`Visibility`")
+
+	// Cells that aren't a short row's last cell don't check its missing cells
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, data))
+
+	// A long row's extra cells don't have any rules
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 3, ColIndex: 4}, data))
+}
+
+// TestNewColumnRuleCheck tests that invalid column rules are rejected.
+func TestNewColumnRuleCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+	}{
+		{name: "Missing header", options: `{"rules": [{"required": true}]}`},
+		{name: "Invalid pattern", options: `{"rules": [{"header": "Title", "pattern": "("}]}`},
+		{name: "Inverted range", options: `{"rules": [{"header": "Rating", "min": 5, "max": 1}]}`},
+		{name: "Condition without a header", options: `{"rules": [{"header": "Title", "requiredIf": {"values": ["A"]}}]}`},
+		{name: "Unknown option", options: `{"rules": [{"header": "Title", "regex": ".*"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewColumnRuleCheck(config.NewProfiles(), config.Validation{
+				Name:    "ColumnRuleCheck",
+				Options: json.RawMessage(tt.options),
+			})
+			assert.Error(t, err)
+		})
+	}
+
+	// A check without any rules doesn't check anything
+	check, err := NewColumnRuleCheck(config.NewProfiles(), config.Validation{Name: "ColumnRuleCheck"})
+	require.NoError(t, err)
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, [][]string{{"Title"}, {""}}))
+}
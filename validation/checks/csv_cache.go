@@ -0,0 +1,27 @@
+package checks
+
+// csvCache holds a value that a check builds from a whole CSV, like an index of its rows, so that it's built once per
+// CSV rather than once for each of the CSV's cells.
+type csvCache[T any] struct {
+	data  [][]string
+	value T
+}
+
+// get returns the value built from the supplied CSV data, building it with the supplied function if the cache holds
+// a value that was built from different CSV data.
+func (cache *csvCache[T]) get(csvData [][]string, build func([][]string) T) T {
+	if !cache.holds(csvData) {
+		cache.value = build(csvData)
+		cache.data = csvData
+	}
+
+	return cache.value
+}
+
+// holds returns whether the cache's value was built from the supplied CSV data.
+//
+// The engine passes the same CSV data to a check for each of the CSV's cells, so CSV data that shares its first row
+// with the data the value was built from is the same CSV.
+func (cache *csvCache[T]) holds(csvData [][]string) bool {
+	return len(cache.data) > 0 && len(cache.data) == len(csvData) && &cache.data[0] == &csvData[0]
+}
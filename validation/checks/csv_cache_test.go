@@ -0,0 +1,36 @@
+//go:build unit
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCSVCache_Get tests that a csvCache only builds its value again when it's given a different CSV.
+func TestCSVCache_Get(t *testing.T) {
+	var cache csvCache[int]
+	var builds int
+
+	build := func(csvData [][]string) int {
+		builds++
+		return len(csvData)
+	}
+
+	first := [][]string{{"Title"}, {"One"}}
+	second := [][]string{{"Title"}, {"One"}, {"Two"}}
+
+	assert.Equal(t, 2, cache.get(first, build))
+	assert.Equal(t, 2, cache.get(first, build))
+	assert.Equal(t, 1, builds)
+
+	// A CSV with the same values is still a different CSV
+	assert.Equal(t, 3, cache.get(second, build))
+	assert.Equal(t, 2, cache.get([][]string{{"Title"}, {"One"}}, build))
+	assert.Equal(t, 3, builds)
+
+	// A CSV that shares its first row with the cached one, but has a different length, is also different
+	assert.Equal(t, 1, cache.get(first[:1], build))
+	assert.Equal(t, 4, builds)
+}
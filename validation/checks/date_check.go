@@ -0,0 +1,228 @@
+package checks
+
+import (
+	"cmp"
+	goerrors "errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/edtf"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// The date columns that are checked, unless the check is configured otherwise
+const (
+	DateCreated    = "Date.created"
+	DateNormalized = "Date.normalized"
+)
+
+// The policies for how free-text created dates are checked
+const (
+	CreatedAny  = "any"  // Any text is allowed
+	CreatedYear = "year" // The text must mention at least one year
+	CreatedEDTF = "edtf" // The text must be an EDTF date
+)
+
+// DateOptions are the profile options that configure a DateCheck.
+type DateOptions struct {
+	Normalized string `json:"normalized,omitempty"` // The header of the EDTF dates (default: Date.normalized)
+	Created    string `json:"created,omitempty"`    // The header of the free-text dates (default: Date.created)
+	Policy     string `json:"policy,omitempty"`     // How free-text dates are checked: any, year, or edtf (default: any)
+	Strict     bool   `json:"strict,omitempty"`     // Whether only EDTF level 0 features are allowed
+}
+
+// yearRegex finds the years, and decades like "1950s", that are mentioned in free-text dates.
+var yearRegex = regexp.MustCompile(`\b(\d{4})(s?)\b`)
+
+// yearRangeRegex matches a span of years in free text, like "1970 - 1979" or "1920s to 1930s".
+var yearRangeRegex = regexp.MustCompile(`\b(\d{4})(s?)\s*(?:-|–|—|to)\s*(\d{4})(s?)\b`)
+
+// yearSpan is a span of years, from the first to the last, that a date covers.
+type yearSpan struct {
+	first int
+	last  int
+}
+
+// DateCheck validates that normalized dates are EDTF and that they're consistent with their row's created date.
+//
+// It implements the Validator interface and returns an error on failure to validate.
+type DateCheck struct {
+	profiles   *config.Profiles
+	normalized string
+	created    string
+	policy     string
+	strict     bool
+}
+
+// NewDateCheck creates a new DateCheck instance, which validates a CSV's dates.
+//
+// The supplied validation configures the date columns, the policy for free-text dates, and whether EDTF level 1
+// features can be used. It returns an error if the profiles argument is nil or if the validation's options can't be
+// decoded or have an unknown policy.
+func NewDateCheck(profiles *config.Profiles, validation config.Validation) (*DateCheck, error) {
+	var options DateOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &DateCheck{
+		profiles:   profiles,
+		normalized: cmp.Or(options.Normalized, DateNormalized),
+		created:    cmp.Or(options.Created, DateCreated),
+		policy:     cmp.Or(options.Policy, CreatedAny),
+		strict:     options.Strict,
+	}
+
+	if check.policy != CreatedAny && check.policy != CreatedYear && check.policy != CreatedEDTF {
+		return nil, fmt.Errorf("unknown created date policy: %s", check.policy)
+	}
+
+	return check, nil
+}
+
+// Validate checks that a normalized date is EDTF and consistent with the created date, and that a created date follows
+// the configured policy.
+//
+// This check doesn't care what profile is being used. Empty dates are skipped.
+func (check *DateCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Find the header and determine if it matches a date header
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	// Skip if we don't have a date cell, or we're on the first (i.e., header) row
+	if header != check.normalized && header != check.created || location.RowIndex == 0 {
+		return nil
+	}
+
+	value := strings.TrimSpace(csvData[location.RowIndex][location.ColIndex])
+	if value == "" {
+		return nil
+	}
+
+	if header == check.created {
+		return check.verifyCreated(value, location, profile)
+	}
+
+	date, err := edtf.Parse(value)
+	if goerrors.Is(err, edtf.ErrInverted) {
+		return csv.NewError(fmt.Sprintf(errors.DateInvertedErr, header, value), location, profile)
+	} else if err != nil {
+		return csv.NewError(fmt.Sprintf(errors.DateEDTFErr, header, value, err), location, profile)
+	}
+
+	var errs error
+
+	if check.strict && date.Level > 0 {
+		errs = multierr.Combine(errs, csv.NewError(fmt.Sprintf(errors.DateLevelErr, header, value), location, profile))
+	}
+
+	// The created date is compared with the normalized date when the created date mentions any years
+	created, _ := csv.GetRowValue(check.created, location, csvData, profile)
+	created = strings.TrimSpace(created)
+
+	for _, span := range createdYears(created) {
+		if !overlaps(date, span) {
+			message := fmt.Sprintf(errors.DateCreatedErr, header, value, check.created, created)
+			errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+			break
+		}
+	}
+
+	return errs
+}
+
+// verifyCreated checks that a created date follows the check's policy for free-text dates.
+func (check *DateCheck) verifyCreated(value string, location csv.Location, profile string) error {
+	switch check.policy {
+	case CreatedYear:
+		if !yearRegex.MatchString(value) {
+			return csv.NewError(fmt.Sprintf(errors.DateYearErr, check.created, value), location, profile)
+		}
+	case CreatedEDTF:
+		if _, err := edtf.Parse(value); err != nil {
+			return csv.NewError(fmt.Sprintf(errors.DateEDTFErr, check.created, value, err), location, profile)
+		}
+	default:
+	}
+
+	return nil
+}
+
+// createdYears returns the spans of years that a created date mentions.
+//
+// EDTF created dates cover the years from their start to their end; other created dates cover each year they mention,
+// with decades (e.g., "1950s") covering each of their years, and each span of years (e.g., "1970 - 1979") covering the
+// years from its start to its end.
+func createdYears(created string) []yearSpan {
+	if date, err := edtf.Parse(created); err == nil {
+		earliest, hasStart := date.Earliest()
+		latest, hasEnd := date.Latest()
+
+		if hasStart && hasEnd {
+			return []yearSpan{{first: earliest.Year, last: latest.Year}}
+		}
+
+		return nil
+	}
+
+	var spans []yearSpan
+
+	for _, match := range yearRangeRegex.FindAllStringSubmatch(created, -1) {
+		start, end := mentionedYears(match[1], match[2]), mentionedYears(match[3], match[4])
+		spans = append(spans, yearSpan{first: min(start.first, end.first), last: max(start.last, end.last)})
+	}
+
+	// The years in a span aren't also mentioned on their own
+	for _, match := range yearRegex.FindAllStringSubmatch(yearRangeRegex.ReplaceAllString(created, " "), -1) {
+		spans = append(spans, mentionedYears(match[1], match[2]))
+	}
+
+	return spans
+}
+
+// mentionedYears returns the span of years that a year mentioned in free text covers; the suffix is "s" if the year
+// is a century or decade.
+func mentionedYears(digits string, suffix string) yearSpan {
+	year, _ := strconv.Atoi(digits)
+	span := yearSpan{first: year, last: year}
+
+	// Centuries (e.g., "1900s") and decades (e.g., "1950s") cover the years that follow them
+	if suffix != "" && year%100 == 0 {
+		span.last += 99
+	} else if suffix != "" {
+		span.last += 9
+	}
+
+	return span
+}
+
+// overlaps returns whether the supplied EDTF date covers any of the years in the span.
+func overlaps(date edtf.Value, span yearSpan) bool {
+	if earliest, found := date.Earliest(); found && earliest.Year > span.last {
+		return false
+	}
+
+	if latest, found := date.Latest(); found && latest.Year < span.first {
+		return false
+	}
+
+	return true
+}
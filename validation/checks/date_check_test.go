@@ -0,0 +1,186 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestDateCheck_Validate tests the Validate method on DateCheck.
+func TestDateCheck_Validate(t *testing.T) {
+	check, err := NewDateCheck(config.NewProfiles(), config.Validation{Name: "DateCheck"})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Date.created", "Date.normalized"},
+		{"1970 - 1979", "1970/1979"},
+		{"08-03-1986", "1986-08-03"},
+		{"circa 1950s", "1955~"},
+		{"undated", "../1900"},
+		{"1986", "1968"},
+		{"1990", "1996/1990"},
+		{"", "1985-02-30"},
+		{"", "March 1985"},
+		{"1977/1994", "1977/1980"},
+		{"", ""},
+		{"1970 - 1979", "1975"},
+		{"1920s to 1930s", "1935"},
+		{"1970 - 1979", "1985"},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "Range that matches its created date",
+			location: csv.Location{RowIndex: 1, ColIndex: 1},
+		},
+		{
+			name:     "Day that matches its created date",
+			location: csv.Location{RowIndex: 2, ColIndex: 1},
+		},
+		{
+			name:     "Approximate year within a created decade",
+			location: csv.Location{RowIndex: 3, ColIndex: 1},
+		},
+		{
+			name:     "Open range without a created year",
+			location: csv.Location{RowIndex: 4, ColIndex: 1},
+		},
+		{
+			name:        "Year that doesn't match its created date",
+			location:    csv.Location{RowIndex: 5, ColIndex: 1},
+			expectedErr: []string{"Date.normalized `1968` doesn't match Date.created `1986`"},
+		},
+		{
+			name:        "Inverted range",
+			location:    csv.Location{RowIndex: 6, ColIndex: 1},
+			expectedErr: []string{"Date.normalized `1996/1990` is a range that ends before it starts"},
+		},
+		{
+			name:        "Day that doesn't exist",
+			location:    csv.Location{RowIndex: 7, ColIndex: 1},
+			expectedErr: []string{"Date.normalized `1985-02-30` is not a valid EDTF date (it isn't a real calendar date)"},
+		},
+		{
+			name:        "Free text",
+			location:    csv.Location{RowIndex: 8, ColIndex: 1},
+			expectedErr: []string{"is not a valid EDTF date (it doesn't follow the EDTF syntax)"},
+		},
+		{
+			name:     "Range that's narrower than an EDTF created date",
+			location: csv.Location{RowIndex: 9, ColIndex: 1},
+		},
+		{
+			name:     "Any created date is allowed by default",
+			location: csv.Location{RowIndex: 4, ColIndex: 0},
+		},
+		{
+			name:     "Empty dates are skipped",
+			location: csv.Location{RowIndex: 10, ColIndex: 1},
+		},
+		{
+			name:     "Year within a created span of years",
+			location: csv.Location{RowIndex: 11, ColIndex: 1},
+		},
+		{
+			name:     "Year within a created span of decades",
+			location: csv.Location{RowIndex: 12, ColIndex: 1},
+		},
+		{
+			name:        "Year outside a created span of years",
+			location:    csv.Location{RowIndex: 13, ColIndex: 1},
+			expectedErr: []string{"Date.normalized `1985` doesn't match Date.created `1970 - 1979`"},
+		},
+		{
+			name:     "Header row isn't checked",
+			location: csv.Location{RowIndex: 0, ColIndex: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+// TestDateCheck_Options tests the created date policies and the other options of a DateCheck.
+func TestDateCheck_Options(t *testing.T) {
+	data := [][]string{
+		{"Created", "Normalized"},
+		{"undated", "1984?"},
+		{"1984", "1984"},
+		{"1984?", "198X"},
+	}
+
+	tests := []struct {
+		name        string
+		options     string
+		location    csv.Location
+		expectedErr string
+	}{
+		{name: "Year policy with a year", options: `{"policy": "year"}`, location: csv.Location{RowIndex: 2}},
+		{name: "Year policy without a year", options: `{"policy": "year"}`, location: csv.Location{RowIndex: 1},
+			expectedErr: "Created `undated` doesn't mention a year"},
+		{name: "EDTF policy with an EDTF date", options: `{"policy": "edtf"}`, location: csv.Location{RowIndex: 3}},
+		{name: "EDTF policy with free text", options: `{"policy": "edtf"}`, location: csv.Location{RowIndex: 1},
+			expectedErr: "Created `undated` is not a valid EDTF date"},
+		{name: "Level 1 date", location: csv.Location{RowIndex: 1, ColIndex: 1}},
+		{name: "Strict with a level 0 date", options: `{"strict": true}`,
+			location: csv.Location{RowIndex: 2, ColIndex: 1}},
+		{name: "Strict with a level 1 date", options: `{"strict": true}`,
+			location:    csv.Location{RowIndex: 3, ColIndex: 1},
+			expectedErr: "Normalized `198X` uses EDTF level 1 features"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := map[string]any{"created": "Created", "normalized": "Normalized"}
+			if tt.options != "" {
+				require.NoError(t, json.Unmarshal([]byte(tt.options), &options))
+			}
+
+			raw, err := json.Marshal(options)
+			require.NoError(t, err)
+
+			check, err := NewDateCheck(config.NewProfiles(), config.Validation{Name: "DateCheck", Options: raw})
+			require.NoError(t, err)
+
+			err = check.Validate("Test", tt.location, data)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+// TestNewDateCheck tests creating a new DateCheck.
+func TestNewDateCheck(t *testing.T) {
+	_, err := NewDateCheck(nil, config.Validation{Name: "DateCheck"})
+	assert.Error(t, err)
+
+	_, err = NewDateCheck(config.NewProfiles(), config.Validation{
+		Name:    "DateCheck",
+		Options: json.RawMessage(`{"policy": "strict"}`),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// keySeparator joins the values of a composite key; it's a character that isn't expected in CSV data.
+const keySeparator = "\x00"
+
+// DuplicateOptions are the profile options that configure a DuplicateCheck.
+//
+// Each key is a list of headers whose combined values must be unique across the CSV's rows (e.g., ["Item ARK"] or
+// ["Parent ARK", "Item Sequence"]).
+type DuplicateOptions struct {
+	Keys [][]string `json:"keys"`
+}
+
+// duplicateIndex maps the values of a uniqueness key to the first row in which they're found.
+type duplicateIndex struct {
+	columns []int
+	rows    map[string]int
+}
+
+// DuplicateCheck is a validator that checks that the values of configured uniqueness keys aren't repeated.
+//
+// Each duplicate is reported at the cell of the key's first column, along with the row in which the value was first
+// found. Rows with an empty value in any of a key's columns aren't checked for that key. It implements the Validator
+// interface and returns an error on failure to validate.
+type DuplicateCheck struct {
+	profiles *config.Profiles
+	keys     [][]string
+	indexes  csvCache[[]*duplicateIndex]
+}
+
+// NewDuplicateCheck returns a new DuplicateCheck, which validates that uniqueness keys aren't repeated.
+//
+// The supplied validation configures the check's keys; if there aren't any, Item ARKs are checked. It returns an error
+// if the provided profiles argument is nil or if any of the keys are empty.
+func NewDuplicateCheck(profiles *config.Profiles, validation config.Validation) (*DuplicateCheck, error) {
+	var options DuplicateOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	if len(options.Keys) == 0 {
+		options.Keys = [][]string{{ItemARK}}
+	}
+
+	for index, key := range options.Keys {
+		if len(key) == 0 {
+			return nil, fmt.Errorf("invalid uniqueness key %d: at least one header is required", index)
+		}
+	}
+
+	return &DuplicateCheck{
+		profiles: profiles,
+		keys:     options.Keys,
+	}, nil
+}
+
+// Validate checks whether the cell at the supplied location starts a uniqueness key that's already been seen.
+func (check *DuplicateCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Skip the header row
+	if location.RowIndex == 0 {
+		return nil
+	}
+
+	var errs error
+
+	for keyIndex, index := range check.indexes.get(csvData, check.buildIndexes) {
+		// Keys are only checked at their first column, and only if all their columns are in the CSV
+		if index == nil || index.columns[0] != location.ColIndex {
+			continue
+		}
+
+		value, ok := index.value(csvData[location.RowIndex])
+		if !ok {
+			continue
+		}
+
+		if first := index.rows[value]; first != location.RowIndex {
+			headers := "`" + strings.Join(check.keys[keyIndex], "`, `") + "`"
+			message := fmt.Sprintf(errors.DuplicateValueErr, headers, first+1)
+			errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+		}
+	}
+
+	return errs
+}
+
+// buildIndexes builds the indexes of the supplied CSV data's uniqueness keys.
+//
+// A key whose columns aren't all in the CSV has a nil index.
+func (check *DuplicateCheck) buildIndexes(csvData [][]string) []*duplicateIndex {
+	indexes := make([]*duplicateIndex, len(check.keys))
+
+	for keyIndex, key := range check.keys {
+		columns, found := findColumns(key, csvData[0])
+		if !found {
+			continue
+		}
+
+		index := &duplicateIndex{columns: columns, rows: make(map[string]int)}
+		for row := 1; row < len(csvData); row++ {
+			if value, ok := index.value(csvData[row]); ok {
+				if _, exists := index.rows[value]; !exists {
+					index.rows[value] = row
+				}
+			}
+		}
+
+		indexes[keyIndex] = index
+	}
+
+	return indexes
+}
+
+// value returns the key's value in the supplied row, and whether the row has a value for each of the key's columns.
+func (index *duplicateIndex) value(row []string) (string, bool) {
+	values := make([]string, 0, len(index.columns))
+
+	for _, column := range index.columns {
+		if column >= len(row) || strings.TrimSpace(row[column]) == "" {
+			return "", false
+		}
+
+		values = append(values, strings.TrimSpace(row[column]))
+	}
+
+	return strings.Join(values, keySeparator), true
+}
+
+// findColumns returns the indices of the supplied headers, and whether they were all found.
+func findColumns(headers []string, headerRow []string) ([]int, bool) {
+	columns := make([]int, 0, len(headers))
+
+	for _, header := range headers {
+		column := -1
+
+		for index, name := range headerRow {
+			if name == header {
+				column = index
+				break
+			}
+		}
+
+		if column == -1 {
+			return nil, false
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, true
+}
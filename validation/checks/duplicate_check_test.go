@@ -0,0 +1,111 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestDuplicateCheck_Validate tests the Validate method on DuplicateCheck.
+func TestDuplicateCheck_Validate(t *testing.T) {
+	check, err := NewDuplicateCheck(config.NewProfiles(), config.Validation{
+		Name: "DuplicateCheck",
+		Options: json.RawMessage(`{"keys": [["Item ARK"], ["Parent ARK", "File Name"],
+			["Parent ARK", "Item Sequence"]]}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Item ARK", "Parent ARK", "File Name", "Item Sequence"},
+		{"ark:/21198/w1", "ark:/21198/c1", "", ""},
+		{"ark:/21198/p1", "ark:/21198/w1", "page1.tif", "1"},
+		{"ark:/21198/p2", "ark:/21198/w1", "page2.tif", "2"},
+		{"ark:/21198/p1", "ark:/21198/w1", "page1.tif", "2"},
+		{"ark:/21198/w2", "ark:/21198/c1", "", ""},
+		{"ark:/21198/p3", "ark:/21198/w2", "page1.tif", "1"},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "First occurrence of an Item ARK",
+			location: csv.Location{RowIndex: 2, ColIndex: 0},
+		},
+		{
+			name:        "Duplicate Item ARK",
+			location:    csv.Location{RowIndex: 4, ColIndex: 0},
+			expectedErr: []string{"duplicate value for `Item ARK` (first found in row 3)"},
+		},
+		{
+			name:     "Same File Name under a different parent",
+			location: csv.Location{RowIndex: 6, ColIndex: 1},
+		},
+		{
+			name:     "Empty File Names aren't duplicates",
+			location: csv.Location{RowIndex: 5, ColIndex: 1},
+		},
+		{
+			name:     "Duplicate composite keys",
+			location: csv.Location{RowIndex: 4, ColIndex: 1},
+			expectedErr: []string{
+				"duplicate value for `Parent ARK`, `File Name` (first found in row 3)",
+				"duplicate value for `Parent ARK`, `Item Sequence` (first found in row 4)",
+			},
+		},
+		{
+			name:     "Composite keys are only checked at their first column",
+			location: csv.Location{RowIndex: 4, ColIndex: 2},
+		},
+		{
+			name:     "Header row isn't checked",
+			location: csv.Location{RowIndex: 0, ColIndex: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+// TestNewDuplicateCheck tests creating a new DuplicateCheck.
+func TestNewDuplicateCheck(t *testing.T) {
+	_, err := NewDuplicateCheck(nil, config.Validation{Name: "DuplicateCheck"})
+	assert.Error(t, err)
+
+	_, err = NewDuplicateCheck(config.NewProfiles(), config.Validation{
+		Name:    "DuplicateCheck",
+		Options: json.RawMessage(`{"keys": [[]]}`),
+	})
+	assert.Error(t, err)
+
+	// Without any keys, Item ARKs are checked
+	check, err := NewDuplicateCheck(config.NewProfiles(), config.Validation{Name: "DuplicateCheck"})
+	require.NoError(t, err)
+
+	data := [][]string{{"Title", "Item ARK"}, {"One", "ark:/21198/w1"}, {"Two", "ark:/21198/w1"}}
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 1}, data))
+	assert.Error(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 1}, data))
+
+	// Keys with columns that aren't in the CSV aren't checked
+	data = [][]string{{"Title"}, {"One"}, {"One"}}
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, data))
+}
@@ -0,0 +1,247 @@
+package checks
+
+import (
+	"fmt"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+	"github.com/UCLALibrary/validation-service/validation/expr"
+)
+
+// ExpressionRule is a rule, written in the expr package's expression language, that a CSV row must satisfy.
+//
+// The Assert expression is only checked for rows that satisfy the When expression; if there is no When expression,
+// it's checked for every row. Failures are reported at the rule's Header column, if the CSV has it, or at the row's
+// first column otherwise.
+type ExpressionRule struct {
+	Name    string `json:"name"`              // A short name for the rule
+	When    string `json:"when,omitempty"`    // The condition a row must satisfy for the rule to apply
+	Assert  string `json:"assert"`            // The condition a row that the rule applies to must satisfy
+	Message string `json:"message,omitempty"` // The message to report when a row fails the rule
+	Header  string `json:"header,omitempty"`  // The header of the column at which failures are reported
+}
+
+// ExpressionOptions are the profile options that configure an ExpressionCheck.
+type ExpressionOptions struct {
+	Rules []ExpressionRule `json:"rules"`
+}
+
+// expressionRule is an ExpressionRule that's been compiled for use.
+type expressionRule struct {
+	ExpressionRule
+	when   *expr.Expression
+	assert *expr.Expression
+}
+
+// ExpressionCheck is a validator that checks rows against cross-column rules configured in a profile.
+//
+// It implements the Validator interface and returns an error on failure to validate.
+type ExpressionCheck struct {
+	profiles *config.Profiles
+	rules    []expressionRule
+	indexes  csvCache[map[string]map[string]int]
+}
+
+// rowEnv is the environment in which an ExpressionCheck's rules are evaluated for a single row.
+type rowEnv struct {
+	check   *ExpressionCheck
+	row     int
+	csvData [][]string
+}
+
+// NewExpressionCheck returns a new ExpressionCheck, which validates rows against expression-based rules.
+//
+// The supplied validation configures the check with its rules. It returns an error if the provided profiles argument
+// is nil or if any of the rules' expressions can't be compiled.
+func NewExpressionCheck(profiles *config.Profiles, validation config.Validation) (*ExpressionCheck, error) {
+	var options ExpressionOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &ExpressionCheck{profiles: profiles}
+
+	for index, rule := range options.Rules {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression rule %d: %w", index, err)
+		}
+
+		check.rules = append(check.rules, compiled)
+	}
+
+	return check, nil
+}
+
+// Validate checks the row of the supplied location against the rules that are reported at the location's column.
+//
+// Each rule is evaluated once per row, when the location is at the column at which its failures are reported. A rule
+// whose column is past the end of a short row is evaluated at the row's last cell, with the missing cells treated as
+// empty.
+func (check *ExpressionCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Expressions are about data rows, so we skip the header row
+	if location.RowIndex == 0 {
+		return nil
+	}
+
+	var errs error
+
+	lastCell := len(csvData[location.RowIndex]) - 1
+
+	env := &rowEnv{check: check, row: location.RowIndex, csvData: csvData}
+	for _, rule := range check.rules {
+		column := rule.column(csvData)
+
+		// A rule whose column is past the end of a short row is evaluated at the row's last cell
+		if column != location.ColIndex && (column < lastCell || location.ColIndex != lastCell) {
+			continue
+		}
+
+		errs = multierr.Combine(errs, rule.check(env, profile, location))
+	}
+
+	return errs
+}
+
+// check evaluates the rule against a row, returning an error if the row fails the rule or can't be evaluated.
+func (rule *expressionRule) check(env *rowEnv, profile string, location csv.Location) error {
+	if rule.when != nil {
+		applies, err := rule.when.EvalBool(env)
+		if err != nil {
+			return csv.NewError(fmt.Sprintf(errors.ExpressionEvalErr, rule.Name, err), location, profile, err)
+		}
+
+		if !applies {
+			return nil
+		}
+	}
+
+	passed, err := rule.assert.EvalBool(env)
+	if err != nil {
+		return csv.NewError(fmt.Sprintf(errors.ExpressionEvalErr, rule.Name, err), location, profile, err)
+	}
+
+	if passed {
+		return nil
+	}
+
+	if rule.Message != "" {
+		return csv.NewError(rule.Message, location, profile)
+	}
+
+	return csv.NewError(fmt.Sprintf(errors.ExpressionFailedErr, rule.Name, rule.Assert), location, profile)
+}
+
+// column returns the index of the column at which the rule's failures are reported.
+func (rule *expressionRule) column(csvData [][]string) int {
+	if rule.Header != "" {
+		for index, header := range csvData[0] {
+			if header == rule.Header {
+				return index
+			}
+		}
+	}
+
+	return 0
+}
+
+// Column returns the row's value for the named column, or an empty string if the CSV doesn't have the column.
+func (env *rowEnv) Column(name string) string {
+	for index, header := range env.csvData[0] {
+		if header == name && index < len(env.csvData[env.row]) {
+			return env.csvData[env.row][index]
+		}
+	}
+
+	return ""
+}
+
+// Lookup returns the target column's value in the first row whose key column has the supplied key value.
+func (env *rowEnv) Lookup(keyColumn string, keyValue string, targetColumn string) string {
+	row, found := env.check.index(keyColumn, env.csvData)[keyValue]
+	if !found {
+		return ""
+	}
+
+	for index, header := range env.csvData[0] {
+		if header == targetColumn && index < len(env.csvData[row]) {
+			return env.csvData[row][index]
+		}
+	}
+
+	return ""
+}
+
+// index returns a map of the supplied column's values to the first row they're found in.
+//
+// A column's index is built the first time it's needed for a CSV.
+func (check *ExpressionCheck) index(keyColumn string, csvData [][]string) map[string]int {
+	indexes := check.indexes.get(csvData, func([][]string) map[string]map[string]int {
+		return make(map[string]map[string]int)
+	})
+
+	if index, exists := indexes[keyColumn]; exists {
+		return index
+	}
+
+	index := make(map[string]int)
+	for colIndex, header := range csvData[0] {
+		if header != keyColumn {
+			continue
+		}
+
+		for rowIndex := 1; rowIndex < len(csvData); rowIndex++ {
+			if colIndex >= len(csvData[rowIndex]) {
+				continue
+			}
+
+			if _, exists := index[csvData[rowIndex][colIndex]]; !exists {
+				index[csvData[rowIndex][colIndex]] = rowIndex
+			}
+		}
+
+		break
+	}
+
+	indexes[keyColumn] = index
+	return index
+}
+
+// compileRule checks that a rule is valid and compiles its expressions.
+func compileRule(rule ExpressionRule) (expressionRule, error) {
+	var err error
+
+	compiled := expressionRule{ExpressionRule: rule}
+
+	if rule.Name == "" {
+		return compiled, fmt.Errorf("a name is required")
+	}
+
+	if rule.Assert == "" {
+		return compiled, fmt.Errorf("an assert expression is required for '%s'", rule.Name)
+	}
+
+	if rule.When != "" {
+		if compiled.when, err = expr.Compile(rule.When); err != nil {
+			return compiled, fmt.Errorf("when expression for '%s' is not valid: %w", rule.Name, err)
+		}
+	}
+
+	if compiled.assert, err = expr.Compile(rule.Assert); err != nil {
+		return compiled, fmt.Errorf("assert expression for '%s' is not valid: %w", rule.Name, err)
+	}
+
+	return compiled, nil
+}
@@ -0,0 +1,181 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// testExpressionRules are the expression rules used to test the ExpressionCheck.
+const testExpressionRules = `{
+	"rules": [
+		{
+			"name": "page-parent",
+			"header": "Parent ARK",
+			"when": "` + "`Object Type`" + ` == 'Page'",
+			"assert": "lookup('Item ARK', ` + "`Parent ARK`" + `, 'Object Type') == 'Work'",
+			"message": "A page's Parent ARK must reference a Work"
+		},
+		{
+			"name": "private-iiif",
+			"header": "IIIF Access URL",
+			"when": "` + "`Visibility`" + ` == 'private'",
+			"assert": "empty(` + "`IIIF Access URL`" + `)"
+		},
+		{
+			"name": "sequence",
+			"assert": "empty(` + "`Item Sequence`" + `) || ` + "`Item Sequence`" + ` > 0"
+		}
+	]
+}`
+
+// TestExpressionCheck_Validate tests the Validate method on ExpressionCheck.
+func TestExpressionCheck_Validate(t *testing.T) {
+	check, err := NewExpressionCheck(config.NewProfiles(), config.Validation{
+		Name:    "ExpressionCheck",
+		Options: json.RawMessage(testExpressionRules),
+	})
+	require.NoError(t, err)
+
+	headers := []string{"Item ARK", "Parent ARK", "Object Type", "Visibility", "IIIF Access URL", "Item Sequence"}
+	work := []string{"ark:/21198/w1", "", "Work", "open", "https://iiif.example.edu/w1", ""}
+	page := []string{"ark:/21198/p1", "ark:/21198/w1", "Page", "open", "", "1"}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		data        [][]string
+		expectedErr string
+	}{
+		{
+			name:     "Page whose parent is a Work",
+			location: csv.Location{RowIndex: 2, ColIndex: 1},
+			data:     [][]string{headers, work, page},
+		},
+		{
+			name:     "Page whose parent is a Page",
+			location: csv.Location{RowIndex: 3, ColIndex: 1},
+			data: [][]string{headers, work, page,
+				{"ark:/21198/p2", "ark:/21198/p1", "Page", "open", "", "2"}},
+			expectedErr: "A page's Parent ARK must reference a Work",
+		},
+		{
+			name:        "Page whose parent isn't in the CSV",
+			location:    csv.Location{RowIndex: 1, ColIndex: 1},
+			data:        [][]string{headers, page},
+			expectedErr: "A page's Parent ARK must reference a Work",
+		},
+		{
+			name:     "Rule only checked at its header's column",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+			data:     [][]string{headers, page},
+		},
+		{
+			name:     "Private row without an IIIF Access URL",
+			location: csv.Location{RowIndex: 1, ColIndex: 4},
+			data:     [][]string{headers, {"ark:/21198/w1", "", "Work", "private", "", ""}},
+		},
+		{
+			name:        "Private row with an IIIF Access URL",
+			location:    csv.Location{RowIndex: 1, ColIndex: 4},
+			data:        [][]string{headers, {"ark:/21198/w1", "", "Work", "private", "https://iiif.example.edu", ""}},
+			expectedErr: "row failed rule `private-iiif`",
+		},
+		{
+			name:        "Rule without a header is checked at the first column",
+			location:    csv.Location{RowIndex: 1, ColIndex: 0},
+			data:        [][]string{headers, {"ark:/21198/p1", "", "Work", "open", "", "-1"}},
+			expectedErr: "row failed rule `sequence`",
+		},
+		{
+			name:        "Rule that can't be evaluated",
+			location:    csv.Location{RowIndex: 1, ColIndex: 0},
+			data:        [][]string{headers, {"ark:/21198/p1", "", "Work", "open", "", "one"}},
+			expectedErr: "rule `sequence` could not be evaluated",
+		},
+		{
+			name:     "Header row isn't checked",
+			location: csv.Location{RowIndex: 0, ColIndex: 0},
+			data:     [][]string{headers, work},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, tt.data)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestExpressionCheck_RaggedRows tests that rules whose columns are past the end of a short row are still evaluated.
+func TestExpressionCheck_RaggedRows(t *testing.T) {
+	check, err := NewExpressionCheck(config.NewProfiles(), config.Validation{
+		Name: "ExpressionCheck",
+		Options: json.RawMessage(`{"rules": [
+			{"name": "title", "header": "Title", "assert": "not empty(` + "`Title`" + `)"},
+			{"name": "sequence", "header": "Item Sequence", "assert": "empty(` + "`Item Sequence`" + `)"}
+		]}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Item ARK", "Item Sequence", "Title"},
+		{"ark:/21198/w1"},
+		{"ark:/21198/p1", "1"},
+		{"ark:/21198/p2", "", "Two"},
+	}
+
+	// The rules are evaluated at the short row's last cell, with its missing cells treated as empty
+	err = check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, data)
+	assert.Len(t, multierr.Errors(err), 1)
+	assert.ErrorContains(t, err, "row failed rule `title`")
+
+	err = check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 1}, data)
+	assert.Len(t, multierr.Errors(err), 2)
+	assert.ErrorContains(t, err, "row failed rule `sequence`")
+	assert.ErrorContains(t, err, "row failed rule `title`")
+
+	// A rule isn't evaluated again at cells before the short row's last one
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, data))
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 3, ColIndex: 2}, data))
+}
+
+// TestNewExpressionCheck tests that invalid expression rules are rejected.
+func TestNewExpressionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		options string
+	}{
+		{name: "Missing name", options: `{"rules": [{"assert": "true"}]}`},
+		{name: "Missing assert", options: `{"rules": [{"name": "rule"}]}`},
+		{name: "Invalid assert", options: `{"rules": [{"name": "rule", "assert": "size('a') > 1"}]}`},
+		{name: "Invalid when", options: `{"rules": [{"name": "rule", "when": "'a' ==", "assert": "true"}]}`},
+		{name: "Unknown option", options: `{"rules": [{"name": "rule", "expression": "true"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewExpressionCheck(config.NewProfiles(), config.Validation{
+				Name:    "ExpressionCheck",
+				Options: json.RawMessage(tt.options),
+			})
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewExpressionCheck(nil, config.Validation{Name: "ExpressionCheck"})
+	assert.Error(t, err)
+}
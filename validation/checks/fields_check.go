@@ -2,6 +2,7 @@ package checks
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,40 +17,16 @@ import (
 	"github.com/UCLALibrary/validation-service/validation/csv"
 )
 
-var profileFields = map[string]map[string]struct {
-	dataReq     bool     // Whether data is required in the cells (could just be a header only check)
-	objTypes    []string // Data cell must be present for these 'Object Types'
-	notObjTypes []string // Data cell must be present for all but these 'Object Types'
-}{
-	"DLP Staff": {
-		"Item ARK":      {true, []string{}, []string{}},
-		"Parent ARK":    {true, []string{}, []string{"Collection"}},
-		"File Name":     {false, []string{"Page"}, []string{}},
-		"Object Type":   {true, []string{}, []string{}},
-		"Item Sequence": {true, []string{"Page"}, []string{}},
-		"Visibility":    {true, []string{}, []string{}},
-		"Title":         {true, []string{}, []string{}},
-		"Summary":       {true, []string{"Collection"}, []string{}},
-	},
-	"Test": {
-		"Item ARK":   {true, []string{}, []string{}},
-		"Visibility": {true, []string{}, []string{}},
-	},
-	"Bucketeer": {
-		"Item ARK":   {true, []string{}, []string{}},
-		"File Name":  {true, []string{}, []string{}},
-		"Visibility": {true, []string{}, []string{}},
-	},
-	"Fester": {
-		"Item ARK":      {true, []string{}, []string{}},
-		"Parent ARK":    {true, []string{}, []string{"Collection"}},
-		"File Name":     {false, []string{"Page"}, []string{}},
-		"Object Type":   {true, []string{}, []string{}},
-		"Item Sequence": {true, []string{"Page"}, []string{}},
-		"Visibility":    {true, []string{}, []string{}},
-		"Title":         {true, []string{}, []string{}},
-		"Summary":       {true, []string{"Collection"}, []string{}},
-	},
+// FieldRequirement describes when a field, and the data in its cells, is required.
+type FieldRequirement struct {
+	DataReq     bool     `json:"dataReq"`               // Whether data is required in the cells (or just the header)
+	ObjTypes    []string `json:"objTypes,omitempty"`    // Data cell must be present for these 'Object Types'
+	NotObjTypes []string `json:"notObjTypes,omitempty"` // Data cell must be present for all but these 'Object Types'
+}
+
+// ReqFieldOptions are the profile options that configure a ReqFieldCheck.
+type ReqFieldOptions struct {
+	Fields map[string]FieldRequirement `json:"fields"` // The required fields, keyed by their header
 }
 
 // condition encapsulates conditional information about an 'Object Type' (ot) check.
@@ -64,19 +41,36 @@ type condition struct {
 type ReqFieldCheck struct {
 	profiles *config.Profiles
 	logger   *zap.Logger
+	fields   map[string]FieldRequirement
 }
 
 // NewReqFieldCheck returns a new ReqFieldCheck, which validates that all required fields are present for a given profile.
 //
-// It returns an error if the provided profiles argument is nil. The logger is used to record validation details.
-func NewReqFieldCheck(profiles *config.Profiles, logger *zap.Logger) (*ReqFieldCheck, error) {
+// The supplied validation configures the check with its options. It returns an error if the provided profiles argument
+// is nil or if the validation's options can't be decoded. The logger is used to record validation details.
+func NewReqFieldCheck(profiles *config.Profiles, logger *zap.Logger, validation config.Validation) (*ReqFieldCheck,
+	error) {
+	var options ReqFieldOptions
+
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	// A field's data can be required for some Object Types or for all but some, but not both
+	for header, field := range options.Fields {
+		if len(field.ObjTypes) > 0 && len(field.NotObjTypes) > 0 {
+			return nil, fmt.Errorf(errors.ProfileConfigErr, header)
+		}
+	}
+
 	return &ReqFieldCheck{
 		profiles: profiles,
 		logger:   logger,
+		fields:   options.Fields,
 	}, nil
 }
 
@@ -109,35 +103,27 @@ func (check *ReqFieldCheck) Validate(profile string, location csv.Location, csvD
 	}
 
 	// Check headers where we care about the presence of the header and its cell data
-	if profileCfg, exists := profileFields[profile]; exists {
-		if field, exists := profileCfg[header]; exists {
-			if field.dataReq && len(field.objTypes) == 0 && len(field.notObjTypes) == 0 {
-				err = check.confirmExistence(context, header)
-				check.logger.Debug("confirmExistence", zap.String("Header", header),
-					zap.Bool("Data required", field.dataReq), zap.Error(err))
-				multiErr = multierr.Combine(multiErr, err)
-			} else if len(field.notObjTypes) == 0 && len(field.objTypes) > 0 {
-				requirements := condition{field.objTypes, true}
-				err = check.confirmWithOT(context, header, requirements)
-				check.logger.Debug("confirmWithOT", zap.String("Header", header),
-					zap.Bool("Data required with `Object Type` checks", field.dataReq),
-					zap.Strings("`Object Type` requirements", field.objTypes), zap.Error(err))
-				multiErr = multierr.Combine(multiErr, err)
-			} else if len(field.objTypes) == 0 && len(field.notObjTypes) > 0 {
-				requirements := condition{field.notObjTypes, false}
-				err = check.confirmWithOT(context, header, requirements)
-				check.logger.Debug("confirmWithOT", zap.String("Header", header),
-					zap.Bool("Data required with `Object Type` exclusions", field.dataReq),
-					zap.Strings("`Object Type` exclusions", field.notObjTypes), zap.Error(err))
-				multiErr = multierr.Combine(multiErr, err)
-			} else if len(field.objTypes) > 0 && len(field.notObjTypes) > 0 {
-				err = csv.NewError(fmt.Sprintf(errors.ProfileConfigErr, profile), location, profile)
-				check.logger.Error(fmt.Sprintf("Bad profile configuration: %s", profile), zap.Error(err))
-				multiErr = multierr.Combine(multiErr, err)
-			}
+	if field, exists := check.fields[header]; exists {
+		if field.DataReq && len(field.ObjTypes) == 0 && len(field.NotObjTypes) == 0 {
+			err = check.confirmExistence(context, header)
+			check.logger.Debug("confirmExistence", zap.String("Header", header),
+				zap.Bool("Data required", field.DataReq), zap.Error(err))
+			multiErr = multierr.Combine(multiErr, err)
+		} else if len(field.NotObjTypes) == 0 && len(field.ObjTypes) > 0 {
+			requirements := condition{field.ObjTypes, true}
+			err = check.confirmWithOT(context, header, requirements)
+			check.logger.Debug("confirmWithOT", zap.String("Header", header),
+				zap.Bool("Data required with `Object Type` checks", field.DataReq),
+				zap.Strings("`Object Type` requirements", field.ObjTypes), zap.Error(err))
+			multiErr = multierr.Combine(multiErr, err)
+		} else if len(field.ObjTypes) == 0 && len(field.NotObjTypes) > 0 {
+			requirements := condition{field.NotObjTypes, false}
+			err = check.confirmWithOT(context, header, requirements)
+			check.logger.Debug("confirmWithOT", zap.String("Header", header),
+				zap.Bool("Data required with `Object Type` exclusions", field.DataReq),
+				zap.Strings("`Object Type` exclusions", field.NotObjTypes), zap.Error(err))
+			multiErr = multierr.Combine(multiErr, err)
 		}
-	} else {
-		return csv.NewError(fmt.Sprintf(errors.UnknownProfileErr, context.Profile), context.Location, context.Profile)
 	}
 
 	// If we found any errors, report them
@@ -157,33 +143,29 @@ func (check *ReqFieldCheck) checkHeaders(profile string, location csv.Location,
 		// We only check for required fields with no data requirements once, at the RowIndex==0, ColIndex==0 position
 		if location.ColIndex == 0 {
 			// Check for required fields that don't have data requirements
-			if profileCfg, exists := profileFields[profile]; exists {
-				for fieldName, value := range profileCfg {
-					// If the fieldName we check is required but doesn't have a data requirement look in the csvData
-					if !value.dataReq {
-						row := csvData[0]
-						found := false
-
-						// Check the csvData for the fieldName we're checking
-						for colIndex := 0; colIndex < len(row); colIndex++ {
-							// If we find it in our CSV data, it's okay (i.e., was required and was found)
-							if fieldName == row[colIndex] {
-								found = true
-							}
-						}
-
-						// If we looked through all the CSV data's headers, and it's not there, that's a problem
-						if !found {
-							newErr := csv.NewError(fmt.Sprintf(errors.FieldNotFoundErr, fieldName), location, profile)
-							multiErr = multierr.Combine(multiErr, newErr)
+			for _, fieldName := range check.sortedFields() {
+				// If the fieldName we check is required but doesn't have a data requirement look in the csvData
+				if !check.fields[fieldName].DataReq {
+					row := csvData[0]
+					found := false
+
+					// Check the csvData for the fieldName we're checking
+					for colIndex := 0; colIndex < len(row); colIndex++ {
+						// If we find it in our CSV data, it's okay (i.e., was required and was found)
+						if fieldName == row[colIndex] {
+							found = true
 						}
+					}
 
-						check.logger.Debug("Required field check",
-							zap.Bool(fmt.Sprintf("`%s` found", fieldName), found))
+					// If we looked through all the CSV data's headers, and it's not there, that's a problem
+					if !found {
+						newErr := csv.NewError(fmt.Sprintf(errors.FieldNotFoundErr, fieldName), location, profile)
+						multiErr = multierr.Combine(multiErr, newErr)
 					}
+
+					check.logger.Debug("Required field check",
+						zap.Bool(fmt.Sprintf("`%s` found", fieldName), found))
 				}
-			} else {
-				return csv.NewError(fmt.Sprintf(errors.UnknownProfileErr, profile), location, profile)
 			}
 		} // Else: once we've checked the headers once, we don't need to keep checking them; we just drop through
 	}
@@ -231,6 +213,17 @@ func (check *ReqFieldCheck) confirmExistence(context util.Context, header string
 	return nil
 }
 
+// sortedFields returns the names of the check's required fields in a consistent order.
+func (check *ReqFieldCheck) sortedFields() []string {
+	names := make([]string, 0, len(check.fields))
+	for name := range check.fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 // finds checks whether a supplied string exists in a supplied slice.
 func (check *ReqFieldCheck) finds(slice []string, value string) bool {
 	for _, sliceValue := range slice {
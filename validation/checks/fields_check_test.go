@@ -3,6 +3,9 @@
 package checks
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/UCLALibrary/validation-service/errors"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
@@ -10,9 +13,24 @@ import (
 	"testing"
 )
 
+// testFieldOptions are the required fields options used to test the ReqFieldCheck.
+const testFieldOptions = `{
+	"fields": {
+		"Item ARK": {"dataReq": true},
+		"Parent ARK": {"dataReq": true, "notObjTypes": ["Collection"]},
+		"File Name": {"dataReq": false, "objTypes": ["Page"]},
+		"Object Type": {"dataReq": true},
+		"Item Sequence": {"dataReq": true, "objTypes": ["Page"]},
+		"Visibility": {"dataReq": true},
+		"Title": {"dataReq": true},
+		"Summary": {"dataReq": true, "objTypes": ["Collection"]}
+	}
+}`
+
 // TestReqFieldCheck_Validate tests the Validate method on EOLCheck.
 func TestReqFieldCheck_Validate(t *testing.T) {
-	check, err := NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t))
+	check, err := NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t),
+		config.Validation{Name: "ReqFieldCheck", Options: json.RawMessage(testFieldOptions)})
 	assert.NoError(t, err)
 
 	// Data variations to check the EOLCheck.Validate method against
@@ -101,3 +119,36 @@ func TestReqFieldCheck_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestNewReqFieldCheck tests that the ReqFieldCheck is configured from its validation options.
+func TestNewReqFieldCheck(t *testing.T) {
+	data := [][]string{{"Title", "Summary"}, {"", ""}}
+
+	// A check without any configured fields doesn't require anything
+	check, err := NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t), config.Validation{Name: "ReqFieldCheck"})
+	assert.NoError(t, err)
+	assert.NoError(t, check.Validate("New Profile", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+
+	// A check with configured fields only requires those fields
+	check, err = NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t), config.Validation{
+		Name: "ReqFieldCheck", Options: json.RawMessage(`{"fields": {"Title": {"dataReq": true}}}`)})
+	assert.NoError(t, err)
+	assert.Error(t, check.Validate("New Profile", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+	assert.NoError(t, check.Validate("New Profile", csv.Location{RowIndex: 1, ColIndex: 1}, data))
+
+	// A header-only requirement is checked against the header row
+	check, err = NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t), config.Validation{
+		Name: "ReqFieldCheck", Options: json.RawMessage(`{"fields": {"File Name": {"dataReq": false}}}`)})
+	assert.NoError(t, err)
+	assert.Error(t, check.Validate("New Profile", csv.Location{RowIndex: 0, ColIndex: 0}, data))
+
+	// Malformed options are rejected
+	_, err = NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t), config.Validation{
+		Name: "ReqFieldCheck", Options: json.RawMessage(`{"fields": ["Title"]}`)})
+	assert.Error(t, err)
+
+	// A field can't be required for some Object Types and for all but some at the same time
+	_, err = NewReqFieldCheck(config.NewProfiles(), zaptest.NewLogger(t), config.Validation{Name: "ReqFieldCheck",
+		Options: json.RawMessage(`{"fields": {"Title": {"objTypes": ["Work"], "notObjTypes": ["Collection"]}}}`)})
+	assert.EqualError(t, err, fmt.Sprintf(errors.ProfileConfigErr, "Title"))
+}
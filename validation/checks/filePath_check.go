@@ -1,9 +1,9 @@
 package checks
 
 import (
+	goerrors "errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"strings"
 
 	"github.com/UCLALibrary/validation-service/validation/config"
@@ -17,22 +17,31 @@ const FileName = "File Name"
 
 // FilePathCheck type is a validator that checks if a File exists at the specified location.
 //
-// It implements the Validator interface and returns an error on failure to validate.
+// Files are looked up in a file system that's rooted at the HOST_DIR, and paths that lead outside of it are reported
+// rather than looked up. It implements the Validator interface and returns an error on failure to validate.
 type FilePathCheck struct {
-	profiles *config.Profiles
+	profiles   *config.Profiles
+	fileSystem fs.FS
 }
 
 // NewFilePathCheck returns a new FilePathCheck, which validates that the file path specified in a CSV data cell points to an existing file.
 //
-// It returns an error if the provided profiles argument is nil.
-func NewFilePathCheck(profiles *config.Profiles) (*FilePathCheck, error) {
+// A file system can be supplied for the files to be looked up in; otherwise, the HOST_DIR is opened when the first
+// file is checked. It returns an error if the provided profiles argument is nil.
+func NewFilePathCheck(profiles *config.Profiles, suppliedFS ...fs.FS) (*FilePathCheck, error) {
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
-	return &FilePathCheck{
+	check := &FilePathCheck{
 		profiles: profiles,
-	}, nil
+	}
+
+	if len(suppliedFS) > 0 && suppliedFS[0] != nil {
+		check.fileSystem = suppliedFS[0]
+	}
+
+	return check, nil
 }
 
 // Validate verifies the file given at that location exists.
@@ -43,14 +52,6 @@ func (check *FilePathCheck) Validate(profile string, location csv.Location, csvD
 		return err
 	}
 
-	value := check.stripPrefix(csvData[location.RowIndex][location.ColIndex])
-
-	// Get dir name from HOST_DIR
-	hostDir := os.Getenv("HOST_DIR")
-	if hostDir == "" {
-		return csv.NewError(errors.NoHostDir, location, profile)
-	}
-
 	// Find the header and determine if it matches a File Name header
 	header, err := csv.GetHeader(location, csvData, profile)
 	if err != nil {
@@ -62,18 +63,42 @@ func (check *FilePathCheck) Validate(profile string, location csv.Location, csvD
 		return nil
 	}
 
-	fullPath := filepath.Join(hostDir, value)
+	value := stripPrefix(csvData[location.RowIndex][location.ColIndex])
 
-	// If the file doesn't exist, return an error
-	if _, err = os.Stat(fullPath); os.IsNotExist(err) {
-		return csv.NewError(fmt.Sprintf(errors.FileNotExist, fullPath), location, profile)
+	fileSystem, err := check.getFileSystem(location, profile)
+	if err != nil {
+		return err
+	}
+
+	// Report paths that lead outside the HOST_DIR without revealing whether anything exists where they lead
+	if _, err = csv.ResolveFS(fileSystem, value); goerrors.Is(err, csv.ErrPathEscape) {
+		return csv.NewError(fmt.Sprintf(errors.PathEscapeErr, value), location, profile)
+	} else if goerrors.Is(err, fs.ErrNotExist) {
+		return csv.NewError(fmt.Sprintf(errors.FileNotExist, value), location, profile)
 	}
 
 	return nil
 }
 
+// getFileSystem returns the file system that files are looked up in.
+//
+// Unless a file system was supplied, it's the HOST_DIR's.
+func (check *FilePathCheck) getFileSystem(location csv.Location, profile string) (fs.FS, error) {
+	if check.fileSystem != nil {
+		return check.fileSystem, nil
+	}
+
+	fileSystem, _, err := openHostDir(location, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	check.fileSystem = fileSystem
+	return check.fileSystem, nil
+}
+
 // stripPrefix strips the prefix found in the CSV file paths so that various sub-dirs will match when compared.
-func (check *FilePathCheck) stripPrefix(filePath string) string {
+func stripPrefix(filePath string) string {
 	prefix := "Masters/" // The masters directory mount point, which is found in HOST_DIR and the CSV file paths
 
 	// Strip the prefix if found in the CSV resource file's file path
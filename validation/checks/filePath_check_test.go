@@ -3,11 +3,17 @@
 package checks
 
 import (
-	"github.com/UCLALibrary/validation-service/validation/config"
+	"fmt"
+	"io/fs"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestFilePathCheck_Validate tests the Validate method on filePath.
@@ -59,3 +65,67 @@ func TestFilePathCheck_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestFilePathCheck_FileSystem tests that files are looked up in a supplied file system and can't be escaped from.
+func TestFilePathCheck_FileSystem(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"images/test.jpx":    {Data: []byte("image")},
+		"images/link.jpx":    {Data: []byte("test.jpx"), Mode: fs.ModeSymlink},
+		"images/outside.jpx": {Data: []byte("../../etc/passwd"), Mode: fs.ModeSymlink},
+		"images/absolute":    {Data: []byte("/etc/passwd"), Mode: fs.ModeSymlink},
+		"masters":            {Data: []byte("images"), Mode: fs.ModeSymlink},
+	}
+
+	check, err := NewFilePathCheck(config.NewProfiles(), fileSystem)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		value       string
+		expectedErr string
+	}{
+		{name: "file exists", value: "images/test.jpx"},
+		{name: "link inside root", value: "images/link.jpx"},
+		{name: "linked directory", value: "masters/test.jpx"},
+		{name: "file does not exist", value: "images/missing.jpx",
+			expectedErr: fmt.Sprintf(errors.FileNotExist, "images/missing.jpx")},
+		{name: "parent directory", value: "../../etc/passwd",
+			expectedErr: fmt.Sprintf(errors.PathEscapeErr, "../../etc/passwd")},
+		{name: "hidden parent directory", value: "images/../../etc/passwd",
+			expectedErr: fmt.Sprintf(errors.PathEscapeErr, "images/../../etc/passwd")},
+		{name: "absolute path", value: "/etc/passwd", expectedErr: fmt.Sprintf(errors.PathEscapeErr, "/etc/passwd")},
+		{name: "link outside root", value: "images/outside.jpx",
+			expectedErr: fmt.Sprintf(errors.PathEscapeErr, "images/outside.jpx")},
+		{name: "absolute link", value: "images/absolute",
+			expectedErr: fmt.Sprintf(errors.PathEscapeErr, "images/absolute")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := [][]string{{"File Name"}, {tt.value}}
+
+			err := check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 0}, data)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestFilePathCheck_NoHostDir tests that only File Name cells are affected by a HOST_DIR that can't be opened.
+func TestFilePathCheck_NoHostDir(t *testing.T) {
+	t.Setenv("HOST_DIR", filepath.Join(t.TempDir(), "missing"))
+
+	check, err := NewFilePathCheck(config.NewProfiles())
+	require.NoError(t, err)
+
+	data := [][]string{{"Title", "File Name"}, {"A title", "images/test.jpx"}}
+
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 0, ColIndex: 1}, data))
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+	assert.ErrorContains(t, check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 1}, data),
+		errors.HostDirOpenErr)
+}
@@ -0,0 +1,278 @@
+package checks
+
+import (
+	goerrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// ObjectType is the Object Type of the current item.
+const ObjectType = "Object Type"
+
+// defaultParentTypes are the Object Types that each Object Type may have as its parent.
+var defaultParentTypes = map[string][]string{
+	"Collection": {},
+	"Work":       {"Collection"},
+	"Page":       {"Work"},
+}
+
+// HierarchyOptions are the profile options that configure a HierarchyCheck.
+type HierarchyOptions struct {
+	ParentTypes    map[string][]string `json:"parentTypes,omitempty"`    // Object Types and their allowed parents' types
+	ParentARKs     []string            `json:"parentArks,omitempty"`     // ARKs outside the CSV that may be parents
+	CompanionFiles []string            `json:"companionFiles,omitempty"` // CSVs, relative to HOST_DIR, with parents
+}
+
+// hierarchyNode is an ARK in the hierarchy, and the row in which it was found.
+type hierarchyNode struct {
+	row        int
+	objectType string
+	parent     string
+}
+
+// hierarchy is the graph of ARKs found in a CSV, along with those that are known from outside of it.
+type hierarchy struct {
+	nodes     map[string]hierarchyNode
+	external  map[string]string
+	companion error
+}
+
+// HierarchyCheck is a validator that checks the Parent ARKs of a CSV form a valid hierarchy.
+//
+// It reports Parent ARKs that don't match an Item ARK, items whose parent has an Object Type they can't have as a
+// parent, items that are their own parent, and items that are a part of a cycle of parents. Problems are reported at
+// the offending Parent ARK cell. It implements the Validator interface and returns an error on failure to validate.
+type HierarchyCheck struct {
+	profiles  *config.Profiles
+	options   HierarchyOptions
+	hierarchy csvCache[*hierarchy]
+}
+
+// NewHierarchyCheck returns a new HierarchyCheck, which validates the ARK hierarchy of a CSV.
+//
+// The supplied validation can configure the check's allowed parent types and the ARKs that are known from outside the
+// CSV. It returns an error if the provided profiles argument is nil or if the options can't be decoded.
+func NewHierarchyCheck(profiles *config.Profiles, validation config.Validation) (*HierarchyCheck, error) {
+	var options HierarchyOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	if options.ParentTypes == nil {
+		options.ParentTypes = defaultParentTypes
+	}
+
+	if err := checkLocalPaths("companionFiles", options.CompanionFiles); err != nil {
+		return nil, err
+	}
+
+	return &HierarchyCheck{
+		profiles: profiles,
+		options:  options,
+	}, nil
+}
+
+// Validate checks the Parent ARK at the supplied location against the CSV's hierarchy.
+func (check *HierarchyCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	graph := check.hierarchy.get(csvData, check.buildHierarchy)
+
+	// Companion files that can't be read are reported once, on the first cell of the header row
+	if location.RowIndex == 0 {
+		if location.ColIndex == 0 && graph.companion != nil {
+			return csv.NewError(fmt.Sprintf(errors.CompanionFileErr, graph.companion), location, profile)
+		}
+
+		return nil
+	}
+
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	// Skip cells that aren't Parent ARKs, or that are empty (whether they're required is checked elsewhere)
+	parent := strings.TrimSpace(csvData[location.RowIndex][location.ColIndex])
+	if header != ParentARK || parent == "" {
+		return nil
+	}
+
+	item := graph.rowValue(csvData, location.RowIndex, ItemARK)
+	if item != "" && item == parent {
+		return csv.NewError(errors.SelfParentErr, location, profile)
+	}
+
+	parentType, found := graph.objectType(parent)
+	if !found {
+		return csv.NewError(fmt.Sprintf(errors.OrphanParentErr, parent), location, profile)
+	}
+
+	var errs error
+
+	objectType := graph.rowValue(csvData, location.RowIndex, ObjectType)
+	if allowed, known := check.options.ParentTypes[objectType]; known && parentType != "" &&
+		!slices.Contains(allowed, parentType) {
+		message := fmt.Sprintf(errors.ParentTypeErr, objectType, parentType)
+		errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+	}
+
+	if item != "" && graph.inCycle(item) {
+		message := fmt.Sprintf(errors.ParentCycleErr, parent)
+		errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+	}
+
+	return errs
+}
+
+// buildHierarchy builds the hierarchy of the supplied CSV data.
+func (check *HierarchyCheck) buildHierarchy(csvData [][]string) *hierarchy {
+	graph := &hierarchy{
+		nodes:    make(map[string]hierarchyNode),
+		external: make(map[string]string),
+	}
+
+	for _, ark := range check.options.ParentARKs {
+		graph.external[ark] = ""
+	}
+
+	for _, file := range check.options.CompanionFiles {
+		if err := graph.addCompanion(file); err != nil {
+			graph.companion = err
+			break
+		}
+	}
+
+	for row := 1; row < len(csvData); row++ {
+		item := graph.rowValue(csvData, row, ItemARK)
+		if item == "" {
+			continue
+		}
+
+		// If an Item ARK is duplicated, the first one is used
+		if _, exists := graph.nodes[item]; !exists {
+			graph.nodes[item] = hierarchyNode{
+				row:        row,
+				objectType: graph.rowValue(csvData, row, ObjectType),
+				parent:     graph.rowValue(csvData, row, ParentARK),
+			}
+		}
+	}
+
+	return graph
+}
+
+// addCompanion adds the Item ARKs, and their Object Types, from a companion CSV to the known external ARKs.
+//
+// The companion CSV must be inside the HOST_DIR. Its errors only name the file as it was configured, since they're
+// reported to whoever uploaded the CSV being checked.
+func (graph *hierarchy) addCompanion(file string) error {
+	hostDir := os.Getenv("HOST_DIR")
+	if hostDir == "" {
+		return fmt.Errorf("`%s` (%s)", file, errors.NoHostDir)
+	}
+
+	path, err := csv.ResolvePath(hostDir, file)
+	if err != nil {
+		return configuredFileError(file, "the HOST_DIR", err)
+	}
+
+	csvData, _, err := csv.ReadFile(path, csv.ReadOptions{}, zap.NewNop())
+	if err != nil {
+		return configuredFileError(file, "the HOST_DIR", err)
+	}
+
+	for row := 1; row < len(csvData); row++ {
+		if item := graph.rowValue(csvData, row, ItemARK); item != "" {
+			graph.external[item] = graph.rowValue(csvData, row, ObjectType)
+		}
+	}
+
+	return nil
+}
+
+// configuredFileError describes why a file that's named in a profile couldn't be read, without revealing where the
+// file, or the directory it must be in, is on the server.
+func configuredFileError(file string, dir string, err error) error {
+	switch {
+	case goerrors.Is(err, csv.ErrPathEscape):
+		return fmt.Errorf("`%s` (it's outside of %s)", file, dir)
+	case goerrors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("`%s` (it doesn't exist)", file)
+	default:
+		return fmt.Errorf("`%s` (it can't be read)", file)
+	}
+}
+
+// checkLocalPaths returns an error if any of an option's paths is absolute or climbs out of the directory that it's
+// relative to.
+func checkLocalPaths(option string, paths []string) error {
+	for _, file := range paths {
+		if !filepath.IsLocal(filepath.Clean(filepath.FromSlash(strings.TrimSpace(file)))) {
+			return fmt.Errorf("invalid %s path '%s': it must be relative and stay inside its directory", option, file)
+		}
+	}
+
+	return nil
+}
+
+// objectType returns the Object Type of the supplied ARK and whether the ARK is known.
+//
+// ARKs that are known from outside the CSV, but that don't have an Object Type, have an empty Object Type.
+func (graph *hierarchy) objectType(ark string) (string, bool) {
+	if node, found := graph.nodes[ark]; found {
+		return node.objectType, true
+	}
+
+	objectType, found := graph.external[ark]
+	return objectType, found
+}
+
+// inCycle checks whether following the parents of the supplied ARK leads back to it.
+func (graph *hierarchy) inCycle(ark string) bool {
+	visited := make(map[string]struct{})
+
+	for current := graph.nodes[ark].parent; current != ""; current = graph.nodes[current].parent {
+		if current == ark {
+			return true
+		}
+
+		// A cycle that doesn't include the ARK we started with is reported on the ARKs that are in it
+		if _, seen := visited[current]; seen {
+			return false
+		}
+
+		visited[current] = struct{}{}
+	}
+
+	return false
+}
+
+// rowValue returns the trimmed value of the supplied header's column in a row, or an empty string if there isn't one.
+func (graph *hierarchy) rowValue(csvData [][]string, row int, header string) string {
+	for index, name := range csvData[0] {
+		if name == header && index < len(csvData[row]) {
+			return strings.TrimSpace(csvData[row][index])
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,151 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestHierarchyCheck_Validate tests the Validate method on HierarchyCheck.
+func TestHierarchyCheck_Validate(t *testing.T) {
+	check, err := NewHierarchyCheck(config.NewProfiles(), config.Validation{
+		Name:    "HierarchyCheck",
+		Options: json.RawMessage(`{"parentArks": ["ark:/21198/external"]}`),
+	})
+	require.NoError(t, err)
+
+	headers := []string{"Object Type", "Item ARK", "Parent ARK"}
+	collection := []string{"Collection", "ark:/21198/c1", ""}
+	work := []string{"Work", "ark:/21198/w1", "ark:/21198/c1"}
+	page := []string{"Page", "ark:/21198/p1", "ark:/21198/w1"}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		data        [][]string
+		expectedErr string
+	}{
+		{
+			name:     "Work whose parent is a Collection",
+			location: csv.Location{RowIndex: 2, ColIndex: 2},
+			data:     [][]string{headers, collection, work, page},
+		},
+		{
+			name:     "Page whose parent is a Work",
+			location: csv.Location{RowIndex: 3, ColIndex: 2},
+			data:     [][]string{headers, collection, work, page},
+		},
+		{
+			name:     "Collection without a parent",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+			data:     [][]string{headers, collection, work, page},
+		},
+		{
+			name:     "Parent that's a known external ARK",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+			data:     [][]string{headers, {"Work", "ark:/21198/w1", "ark:/21198/external"}},
+		},
+		{
+			name:        "Parent that doesn't match any Item ARK",
+			location:    csv.Location{RowIndex: 2, ColIndex: 2},
+			data:        [][]string{headers, collection, {"Work", "ark:/21198/w1", "ark:/21198/c2"}},
+			expectedErr: "parent ARK `ark:/21198/c2` doesn't match any Item ARK",
+		},
+		{
+			name:        "Work whose parent is a Page",
+			location:    csv.Location{RowIndex: 3, ColIndex: 2},
+			data:        [][]string{headers, work, page, {"Work", "ark:/21198/w2", "ark:/21198/p1"}},
+			expectedErr: "a Work can't have a Page as its parent",
+		},
+		{
+			name:        "Page whose parent is a Collection",
+			location:    csv.Location{RowIndex: 2, ColIndex: 2},
+			data:        [][]string{headers, collection, {"Page", "ark:/21198/p1", "ark:/21198/c1"}},
+			expectedErr: "a Page can't have a Collection as its parent",
+		},
+		{
+			name:        "Item that's its own parent",
+			location:    csv.Location{RowIndex: 1, ColIndex: 2},
+			data:        [][]string{headers, {"Work", "ark:/21198/w1", "ark:/21198/w1"}},
+			expectedErr: "the Parent ARK is the same as the Item ARK",
+		},
+		{
+			name:     "Item that's a part of a cycle",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+			data: [][]string{headers, {"Work", "ark:/21198/w1", "ark:/21198/w2"},
+				{"Work", "ark:/21198/w2", "ark:/21198/w3"}, {"Work", "ark:/21198/w3", "ark:/21198/w1"}},
+			expectedErr: "is a part of a cycle of parents",
+		},
+		{
+			name:     "Item whose ancestors are in a cycle",
+			location: csv.Location{RowIndex: 3, ColIndex: 2},
+			data: [][]string{headers, {"Work", "ark:/21198/w1", "ark:/21198/w2"},
+				{"Work", "ark:/21198/w2", "ark:/21198/w1"}, {"Page", "ark:/21198/p1", "ark:/21198/w1"}},
+		},
+		{
+			name:     "Cell that isn't a Parent ARK",
+			location: csv.Location{RowIndex: 1, ColIndex: 1},
+			data:     [][]string{headers, {"Work", "ark:/21198/w1", "ark:/21198/w1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, tt.data)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestHierarchyCheck_CompanionFiles tests that parents can be found in a companion CSV.
+func TestHierarchyCheck_CompanionFiles(t *testing.T) {
+	check, err := NewHierarchyCheck(config.NewProfiles(), config.Validation{
+		Name:    "HierarchyCheck",
+		Options: json.RawMessage(`{"companionFiles": ["cct-collection.csv"]}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{{"Object Type", "Item ARK", "Parent ARK"}, {"Work", "ark:/21198/w1", "ark:/21198/z1cz7hzc"}}
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 2}, data))
+
+	// A companion file that can't be read is reported on the header row
+	check, err = NewHierarchyCheck(config.NewProfiles(), config.Validation{
+		Name:    "HierarchyCheck",
+		Options: json.RawMessage(`{"companionFiles": ["missing.csv"]}`),
+	})
+	require.NoError(t, err)
+	assert.ErrorContains(t, check.Validate("Test", csv.Location{RowIndex: 0, ColIndex: 0}, data),
+		"companion CSV could not be read: `missing.csv` (it doesn't exist)")
+
+	// Companion files must be inside the HOST_DIR
+	for _, file := range []string{"/etc/passwd", "../../etc/passwd", "images/../../cct-collection.csv"} {
+		_, err = NewHierarchyCheck(config.NewProfiles(), config.Validation{
+			Name:    "HierarchyCheck",
+			Options: json.RawMessage(`{"companionFiles": ["` + file + `"]}`),
+		})
+		assert.ErrorContains(t, err, "it must be relative and stay inside its directory", file)
+	}
+}
+
+// TestNewHierarchyCheck tests creating a new HierarchyCheck.
+func TestNewHierarchyCheck(t *testing.T) {
+	_, err := NewHierarchyCheck(nil, config.Validation{Name: "HierarchyCheck"})
+	assert.Error(t, err)
+
+	_, err = NewHierarchyCheck(config.NewProfiles(), config.Validation{
+		Name:    "HierarchyCheck",
+		Options: json.RawMessage(`{"parents": ["ark:/21198/c1"]}`),
+	})
+	assert.Error(t, err)
+}
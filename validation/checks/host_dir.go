@@ -0,0 +1,65 @@
+package checks
+
+import (
+	"io/fs"
+	"os"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// hostDirs holds the HOST_DIR roots that are shared by the checks that read the HOST_DIR's files, keyed by their path.
+//
+// Validators are created for each validation, so a root is opened the first time one of them needs it and is then
+// kept open, for the ones that follow, until CloseHostDirs is called.
+var hostDirs = struct {
+	sync.Mutex
+	roots map[string]*os.Root
+}{roots: make(map[string]*os.Root)}
+
+// openHostDir returns a file system that's rooted at the HOST_DIR, and the HOST_DIR.
+//
+// The root prevents symbolic links from leading outside of the HOST_DIR when its files are accessed.
+func openHostDir(location csv.Location, profile string) (fs.FS, string, error) {
+	// Get dir name from HOST_DIR
+	hostDir := os.Getenv("HOST_DIR")
+	if hostDir == "" {
+		return nil, "", csv.NewError(errors.NoHostDir, location, profile)
+	}
+
+	hostDirs.Lock()
+	defer hostDirs.Unlock()
+
+	if root, found := hostDirs.roots[hostDir]; found {
+		return root.FS(), hostDir, nil
+	}
+
+	// The HOST_DIR's path is the server's business, so it's not included in the error
+	root, err := os.OpenRoot(hostDir)
+	if err != nil {
+		return nil, "", csv.NewError(errors.HostDirOpenErr, location, profile)
+	}
+
+	hostDirs.roots[hostDir] = root
+	return root.FS(), hostDir, nil
+}
+
+// CloseHostDirs closes the HOST_DIR roots that have been opened by the checks, which should be done on shutdown.
+//
+// A check that's validated after this opens the HOST_DIR again.
+func CloseHostDirs() error {
+	var errs error
+
+	hostDirs.Lock()
+	defer hostDirs.Unlock()
+
+	for hostDir, root := range hostDirs.roots {
+		errs = multierr.Combine(errs, root.Close())
+		delete(hostDirs.roots, hostDir)
+	}
+
+	return errs
+}
@@ -0,0 +1,75 @@
+//go:build unit
+
+package checks
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestOpenHostDir tests that the HOST_DIR's root is opened once, shared, and closed by CloseHostDirs.
+func TestOpenHostDir(t *testing.T) {
+	hostDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "image.tif"), []byte("tif"), 0644))
+	t.Setenv("HOST_DIR", hostDir)
+
+	first, path, err := openHostDir(csv.Location{}, "Test")
+	require.NoError(t, err)
+	assert.Equal(t, hostDir, path)
+
+	hostDirs.Lock()
+	root := hostDirs.roots[hostDir]
+	hostDirs.Unlock()
+	require.NotNil(t, root)
+
+	// The same root is used by the checks that follow
+	second, _, err := openHostDir(csv.Location{}, "Test")
+	require.NoError(t, err)
+
+	hostDirs.Lock()
+	assert.Same(t, root, hostDirs.roots[hostDir])
+	hostDirs.Unlock()
+
+	_, err = fs.Stat(second, "image.tif")
+	assert.NoError(t, err)
+
+	// Closing the roots closes the file systems that were opened from them
+	require.NoError(t, CloseHostDirs())
+
+	hostDirs.Lock()
+	assert.Empty(t, hostDirs.roots)
+	hostDirs.Unlock()
+
+	_, err = fs.Stat(first, "image.tif")
+	assert.Error(t, err)
+
+	// The HOST_DIR is opened again when it's next needed
+	third, _, err := openHostDir(csv.Location{}, "Test")
+	require.NoError(t, err)
+
+	_, err = fs.Stat(third, "image.tif")
+	assert.NoError(t, err)
+	require.NoError(t, CloseHostDirs())
+}
+
+// TestOpenHostDir_Errors tests that a HOST_DIR that isn't set, or that can't be opened, is reported.
+func TestOpenHostDir_Errors(t *testing.T) {
+	t.Setenv("HOST_DIR", "")
+	_, _, err := openHostDir(csv.Location{}, "Test")
+	assert.Error(t, err)
+
+	// The HOST_DIR's path isn't given away in the error
+	hostDir := filepath.Join(t.TempDir(), "missing")
+	t.Setenv("HOST_DIR", hostDir)
+	_, _, err = openHostDir(csv.Location{}, "Test")
+	assert.ErrorContains(t, err, errors.HostDirOpenErr)
+	assert.NotContains(t, err.Error(), hostDir)
+}
@@ -0,0 +1,224 @@
+package checks
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// The IIIF columns that reference an item's image service and manifest
+const (
+	IIIFAccessURL   = "IIIF Access URL"
+	IIIFManifestURL = "IIIF Manifest URL"
+)
+
+// Defaults for the base URLs of IIIF Access and Manifest URLs, and the Object Types that don't have manifests
+var (
+	DefaultAccessBases   = []string{"https://iiif.library.ucla.edu/iiif/2/"}
+	DefaultManifestBases = []string{"https://iiif.library.ucla.edu/"}
+	DefaultNoManifest    = []string{"Page"}
+)
+
+// iiifCollections is the path, after a manifest base URL, of the manifests of Collections.
+const iiifCollections = "collections/"
+
+// IIIFURLOptions are the profile options that configure an IIIFURLCheck.
+type IIIFURLOptions struct {
+	AccessBases   []string `json:"accessBases,omitempty"`   // The base URLs an IIIF Access URL can start with
+	ManifestBases []string `json:"manifestBases,omitempty"` // The base URLs an IIIF Manifest URL can start with
+	NoManifest    []string `json:"noManifest,omitempty"`    // The Object Types that shouldn't have a manifest URL
+}
+
+// IIIFURLCheck validates that IIIF Access and Manifest URLs reference the Item ARK of their row.
+//
+// An IIIF Access URL is a base URL followed by the percent-encoded Item ARK. An IIIF Manifest URL is a base URL
+// followed by the percent-encoded Item ARK and "/manifest" or, for a Collection, by "collections/" and the
+// percent-encoded Item ARK. It implements the Validator interface and returns an error on failure to validate.
+type IIIFURLCheck struct {
+	profiles      *config.Profiles
+	accessBases   []string
+	manifestBases []string
+	noManifest    []string
+}
+
+// NewIIIFURLCheck creates a new IIIFURLCheck instance, which validates the IIIF URLs of a CSV's items.
+//
+// The supplied validation configures the base URLs that are allowed and the Object Types that shouldn't have manifests.
+// It returns an error if the profiles argument is nil or if the validation's options can't be decoded.
+func NewIIIFURLCheck(profiles *config.Profiles, validation config.Validation) (*IIIFURLCheck, error) {
+	var options IIIFURLOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &IIIFURLCheck{
+		profiles:      profiles,
+		accessBases:   normalizeBases(options.AccessBases, DefaultAccessBases),
+		manifestBases: normalizeBases(options.ManifestBases, DefaultManifestBases),
+		noManifest:    options.NoManifest,
+	}
+
+	if check.noManifest == nil {
+		check.noManifest = DefaultNoManifest
+	}
+
+	return check, nil
+}
+
+// Validate checks that an IIIF Access URL or IIIF Manifest URL has the expected form and encodes its row's Item ARK.
+//
+// This check doesn't care what profile is being used. Empty URLs are skipped, except that manifest URLs are reported
+// on rows with an Object Type that shouldn't have a manifest.
+func (check *IIIFURLCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Find the header and determine if it matches an IIIF URL header
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	// Skip if we don't have an IIIF URL cell, or we're on the first (i.e., header) row
+	if header != IIIFAccessURL && header != IIIFManifestURL || location.RowIndex == 0 {
+		return nil
+	}
+
+	value := strings.TrimSpace(csvData[location.RowIndex][location.ColIndex])
+	if value == "" {
+		return nil
+	}
+
+	// The Item ARK and Object Type are optional; without them, less of the URL can be checked
+	itemARK, _ := csv.GetRowValue(ItemARK, location, csvData, profile)
+	itemARK = strings.TrimSpace(itemARK)
+	objType, _ := csv.GetRowValue(ObjectType, location, csvData, profile)
+	objType = strings.TrimSpace(objType)
+
+	var errs error
+
+	if header == IIIFManifestURL && slices.Contains(check.noManifest, objType) {
+		errs = multierr.Combine(errs, csv.NewError(fmt.Sprintf(errors.IIIFManifestErr, objType), location, profile))
+	}
+
+	if parsed, parseErr := url.Parse(value); parseErr != nil || parsed.Host == "" ||
+		parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return multierr.Combine(errs, csv.NewError(fmt.Sprintf(errors.IIIFURLErr, header, value), location, profile))
+	}
+
+	bases := check.accessBases
+	if header == IIIFManifestURL {
+		bases = check.manifestBases
+	}
+
+	base, found := findBase(value, bases)
+	if !found {
+		return multierr.Combine(errs, csv.NewError(fmt.Sprintf(errors.IIIFBaseErr, header, value), location, profile))
+	}
+
+	encodedARK, found := cutARK(header, objType, strings.TrimPrefix(value, base))
+	if !found {
+		message := fmt.Sprintf(errors.IIIFShapeErr, header, value, iiifURL(header, objType, base, itemARK))
+		return multierr.Combine(errs, csv.NewError(message, location, profile))
+	}
+
+	// An encoded ARK that can't be decoded can't match the Item ARK either
+	ark, unescapeErr := url.PathUnescape(encodedARK)
+	if unescapeErr != nil {
+		ark = encodedARK
+	}
+
+	if itemARK != "" && ark != itemARK {
+		message := fmt.Sprintf(errors.IIIFArkErr, header, ark, itemARK)
+		errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+	}
+
+	return errs
+}
+
+// cutARK returns the encoded ARK from the part of an IIIF URL that follows its base URL.
+//
+// It returns false if the rest of the URL doesn't have the form expected for the supplied header and Object Type.
+func cutARK(header string, objType string, path string) (string, bool) {
+	var encodedARK string
+	var found bool
+
+	switch {
+	case header == IIIFAccessURL:
+		encodedARK, found = strings.TrimSuffix(path, "/"), true
+	case objType == "Collection":
+		encodedARK, found = strings.CutPrefix(path, iiifCollections)
+	case objType == "":
+		// Without an Object Type, a manifest URL can be either a Work's or a Collection's
+		if encodedARK, found = strings.CutSuffix(path, "/manifest"); !found {
+			encodedARK, found = strings.CutPrefix(path, iiifCollections)
+		}
+	default:
+		encodedARK, found = strings.CutSuffix(path, "/manifest")
+	}
+
+	// An ARK's slashes must be encoded, so the ARK is a single segment of the URL's path
+	if !found || encodedARK == "" || strings.ContainsAny(encodedARK, "/?#") {
+		return "", false
+	}
+
+	return encodedARK, true
+}
+
+// iiifURL returns the IIIF URL that's expected for the supplied header, Object Type, base URL, and Item ARK.
+func iiifURL(header string, objType string, base string, itemARK string) string {
+	encodedARK := "{ark}"
+	if itemARK != "" {
+		encodedARK = url.QueryEscape(itemARK)
+	}
+
+	switch {
+	case header == IIIFAccessURL:
+		return base + encodedARK
+	case objType == "Collection":
+		return base + iiifCollections + encodedARK
+	default:
+		return base + encodedARK + "/manifest"
+	}
+}
+
+// findBase returns the longest of the supplied base URLs that the IIIF URL starts with.
+func findBase(value string, bases []string) (string, bool) {
+	var match string
+
+	for _, base := range bases {
+		if strings.HasPrefix(value, base) && len(base) > len(match) {
+			match = base
+		}
+	}
+
+	return match, match != ""
+}
+
+// normalizeBases returns the supplied base URLs, or the defaults if none are supplied, each ending with a slash.
+func normalizeBases(bases []string, defaults []string) []string {
+	if len(bases) == 0 {
+		bases = defaults
+	}
+
+	normalized := make([]string, 0, len(bases))
+	for _, base := range bases {
+		normalized = append(normalized, strings.TrimSuffix(strings.TrimSpace(base), "/")+"/")
+	}
+
+	return normalized
+}
@@ -0,0 +1,144 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestIIIFURLCheck_Validate tests the Validate method on IIIFURLCheck.
+func TestIIIFURLCheck_Validate(t *testing.T) {
+	check, err := NewIIIFURLCheck(config.NewProfiles(), config.Validation{Name: "IIIFURLCheck"})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Object Type", "Item ARK", "IIIF Access URL", "IIIF Manifest URL"},
+		{"Collection", "ark:/21198/z1cz7hzc", "", "https://iiif.library.ucla.edu/collections/ark%3A%2F21198%2Fz1cz7hzc"},
+		{"Work", "ark:/21198/z1866s7c", "https://iiif.library.ucla.edu/iiif/2/ark%3A%2F21198%2Fz1866s7c",
+			"https://iiif.library.ucla.edu/ark%3A%2F21198%2Fz1866s7c/manifest"},
+		{"Work", "ark:/21198/z14f61gk", "https://iiif.library.ucla.edu/iiif/2/ark%3A%2F21198%2Fz1866s7c",
+			"https://iiif.library.ucla.edu/ark:/21198/z14f61gk/manifest"},
+		{"Page", "ark:/21198/z10332bc", "https://images.example.edu/iiif/2/ark%3A%2F21198%2Fz10332bc",
+			"https://iiif.library.ucla.edu/ark%3A%2F21198%2Fz10332bc/manifest"},
+		{"Work", "ark:/21198/z1090g34", "iiif.library.ucla.edu/iiif/2/ark%3A%2F21198%2Fz1090g34",
+			"https://iiif.library.ucla.edu/collections/ark%3A%2F21198%2Fz1090g34"},
+		{"Work", "", "https://iiif.library.ucla.edu/iiif/2/ark%3A%2F21198%2Fz10c95jb/", ""},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "Collection manifest URL",
+			location: csv.Location{RowIndex: 1, ColIndex: 3},
+		},
+		{
+			name:     "Empty access URL",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+		},
+		{
+			name:     "Work access URL",
+			location: csv.Location{RowIndex: 2, ColIndex: 2},
+		},
+		{
+			name:     "Work manifest URL",
+			location: csv.Location{RowIndex: 2, ColIndex: 3},
+		},
+		{
+			name:     "Access URL for a different ARK",
+			location: csv.Location{RowIndex: 3, ColIndex: 2},
+			expectedErr: []string{"IIIF Access URL encodes `ark:/21198/z1866s7c`, which doesn't match the Item ARK " +
+				"`ark:/21198/z14f61gk`"},
+		},
+		{
+			name:     "Manifest URL with an unencoded ARK",
+			location: csv.Location{RowIndex: 3, ColIndex: 3},
+			expectedErr: []string{"doesn't have the expected form " +
+				"(e.g., `https://iiif.library.ucla.edu/ark%3A%2F21198%2Fz14f61gk/manifest`)"},
+		},
+		{
+			name:        "Access URL with another base URL",
+			location:    csv.Location{RowIndex: 4, ColIndex: 2},
+			expectedErr: []string{"doesn't start with an allowed base URL"},
+		},
+		{
+			name:        "Page with a manifest URL",
+			location:    csv.Location{RowIndex: 4, ColIndex: 3},
+			expectedErr: []string{"a Page shouldn't have an IIIF Manifest URL"},
+		},
+		{
+			name:        "Access URL without a scheme",
+			location:    csv.Location{RowIndex: 5, ColIndex: 2},
+			expectedErr: []string{"is not an HTTP or HTTPS URL"},
+		},
+		{
+			name:        "Work with a Collection's manifest URL",
+			location:    csv.Location{RowIndex: 5, ColIndex: 3},
+			expectedErr: []string{"doesn't have the expected form"},
+		},
+		{
+			name:     "Access URL without an Item ARK to compare",
+			location: csv.Location{RowIndex: 6, ColIndex: 2},
+		},
+		{
+			name:     "Header row isn't checked",
+			location: csv.Location{RowIndex: 0, ColIndex: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+// TestNewIIIFURLCheck tests creating a new IIIFURLCheck with its options.
+func TestNewIIIFURLCheck(t *testing.T) {
+	_, err := NewIIIFURLCheck(nil, config.Validation{Name: "IIIFURLCheck"})
+	assert.Error(t, err)
+
+	_, err = NewIIIFURLCheck(config.NewProfiles(), config.Validation{
+		Name:    "IIIFURLCheck",
+		Options: json.RawMessage(`{"accessBase": ["https://images.example.edu/iiif/2"]}`),
+	})
+	assert.Error(t, err)
+
+	check, err := NewIIIFURLCheck(config.NewProfiles(), config.Validation{
+		Name: "IIIFURLCheck",
+		Options: json.RawMessage(`{"accessBases": ["https://images.example.edu/iiif/2"],
+			"manifestBases": ["https://images.example.edu/manifests"], "noManifest": []}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Object Type", "Item ARK", "IIIF Access URL", "IIIF Manifest URL"},
+		{"Page", "ark:/21198/z10332bc", "https://images.example.edu/iiif/2/ark%3A%2F21198%2Fz10332bc",
+			"https://images.example.edu/manifests/ark%3A%2F21198%2Fz10332bc/manifest"},
+		{"Work", "ark:/21198/z1866s7c", "https://iiif.library.ucla.edu/iiif/2/ark%3A%2F21198%2Fz1866s7c", ""},
+	}
+
+	// Configured base URLs are allowed, without their trailing slash, and Pages can have manifests
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 2}, data))
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 3}, data))
+
+	// The default base URLs aren't allowed when others are configured
+	assert.ErrorContains(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 2}, data),
+		"doesn't start with an allowed base URL")
+}
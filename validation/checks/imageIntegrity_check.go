@@ -0,0 +1,240 @@
+package checks
+
+import (
+	"bytes"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/media"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// DefaultDecodeTimeout is how long Kakadu is given to decode an image, unless the check is configured otherwise.
+const DefaultDecodeTimeout = 5 * time.Minute
+
+// ImageIntegrityOptions are the profile options that configure an ImageIntegrityCheck.
+type ImageIntegrityOptions struct {
+	Decode  bool   `json:"decode,omitempty"`  // Whether JPEG 2000 images are decoded with Kakadu, if it's installed
+	Workers int    `json:"workers,omitempty"` // How many images are inspected at the same time (default: CPU count)
+	Timeout string `json:"timeout,omitempty"` // How long a decode can take, as a Go duration (default: 5m)
+}
+
+// fileRow is the File Name of a row whose file is inspected.
+type fileRow struct {
+	row  int
+	name string
+}
+
+// ImageIntegrityCheck validates that the image files referenced in the File Name column are intact.
+//
+// Each file's magic bytes are compared to its extension and its header is decoded, which finds files that have been
+// truncated or damaged. When configured to, and Kakadu is installed, JPEG 2000 images are also decoded. Files that
+// don't exist, or that don't have an image extension, are left for other checks.
+type ImageIntegrityCheck struct {
+	profiles   *config.Profiles
+	fileSystem fs.FS
+	hostDir    string
+	workers    int
+	expander   *media.Expander
+	problems   csvCache[map[int]string]
+}
+
+// NewImageIntegrityCheck creates a new ImageIntegrityCheck instance, which validates that referenced images are intact.
+//
+// A file system can be supplied for the images to be read from; otherwise, they're read from the HOST_DIR. Images can
+// only be decoded by Kakadu when they're read from the HOST_DIR. It returns an error if the profiles argument is nil
+// or if the validation's options can't be decoded.
+func NewImageIntegrityCheck(profiles *config.Profiles, validation config.Validation, suppliedFS ...fs.FS) (
+	*ImageIntegrityCheck, error) {
+	var options ImageIntegrityOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &ImageIntegrityCheck{
+		profiles: profiles,
+		workers:  options.Workers,
+	}
+
+	if check.workers <= 0 {
+		check.workers = runtime.NumCPU()
+	}
+
+	if len(suppliedFS) > 0 && suppliedFS[0] != nil {
+		check.fileSystem = suppliedFS[0]
+	}
+
+	timeout := DefaultDecodeTimeout
+
+	if options.Timeout != "" {
+		var err error
+
+		if timeout, err = time.ParseDuration(options.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid decode timeout: %w", err)
+		}
+
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid decode timeout: %s isn't greater than zero", options.Timeout)
+		}
+	}
+
+	if options.Decode {
+		// Decoding is optional, so images are only decoded if Kakadu has been installed
+		if expander, err := media.NewExpander(timeout); err == nil {
+			check.expander = expander
+		}
+	}
+
+	return check, nil
+}
+
+// Validate checks that the image file named in a File Name cell is intact.
+//
+// This check doesn't care what profile is being used. The images referenced by a CSV are all inspected the first time
+// one of them is validated.
+func (check *ImageIntegrityCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Find the header and determine if it matches a File Name header
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	// Skip if we don't have a File Name header, or we're on the first (i.e., header) row
+	if header != FileName || location.RowIndex == 0 {
+		return nil
+	}
+
+	if check.fileSystem == nil {
+		if check.fileSystem, check.hostDir, err = openHostDir(location, profile); err != nil {
+			return err
+		}
+	}
+
+	problems := check.problems.get(csvData, func(csvData [][]string) map[int]string {
+		return check.findProblems(csvData, location.ColIndex)
+	})
+
+	if problem, found := problems[location.RowIndex]; found {
+		return csv.NewError(problem, location, profile)
+	}
+
+	return nil
+}
+
+// findProblems finds the problems with the images referenced in the supplied column of the CSV data, keyed by row.
+//
+// The images are inspected by a pool of workers.
+func (check *ImageIntegrityCheck) findProblems(csvData [][]string, column int) map[int]string {
+	problems := make(map[int]string)
+
+	files := make(chan fileRow)
+	var mutex sync.Mutex
+	var group sync.WaitGroup
+
+	for range check.workers {
+		group.Go(func() {
+			for file := range files {
+				if problem := check.inspect(file.name); problem != "" {
+					mutex.Lock()
+					problems[file.row] = problem
+					mutex.Unlock()
+				}
+			}
+		})
+	}
+
+	for row := 1; row < len(csvData); row++ {
+		if column < len(csvData[row]) {
+			files <- fileRow{row: row, name: csvData[row][column]}
+		}
+	}
+
+	close(files)
+	group.Wait()
+
+	return problems
+}
+
+// inspect checks that the supplied image file is intact, returning a description of its problem if it isn't.
+func (check *ImageIntegrityCheck) inspect(name string) string {
+	value := stripPrefix(name)
+
+	formats := media.FormatsFor(value)
+	if formats == nil {
+		return ""
+	}
+
+	// Paths that don't exist, or that lead outside the HOST_DIR, are reported by the FilePathCheck
+	path, err := csv.ResolveFS(check.fileSystem, value)
+	if err != nil {
+		return ""
+	}
+
+	info, err := inspectFile(check.fileSystem, path)
+	if goerrors.Is(err, media.ErrUnknownFormat) {
+		return fmt.Sprintf(errors.ImageUnknownErr, name)
+	} else if err != nil {
+		return fmt.Sprintf(errors.ImageDamagedErr, name, err)
+	}
+
+	if !slices.Contains(formats, info.Format) {
+		return fmt.Sprintf(errors.ImageFormatErr, name, info.Format)
+	}
+
+	// Kakadu needs a real path, so images are only decoded when they're read from the HOST_DIR
+	if check.expander != nil && check.hostDir != "" && (info.Format == media.JP2 || info.Format == media.J2K) {
+		if err = check.expander.Expand(filepath.Join(check.hostDir, filepath.FromSlash(path))); err != nil {
+			return fmt.Sprintf(errors.ImageDecodeErr, name, err)
+		}
+	}
+
+	return ""
+}
+
+// inspectFile opens an image file in the supplied file system and inspects it.
+func inspectFile(fileSystem fs.FS, path string) (media.Info, error) {
+	file, err := fileSystem.Open(path)
+	if err != nil {
+		return media.Info{}, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return media.Info{}, err
+	}
+
+	// Files that can't be read at an offset are read into memory instead
+	reader, ok := file.(io.ReaderAt)
+	if !ok {
+		data, readErr := io.ReadAll(file)
+		if readErr != nil {
+			return media.Info{}, readErr
+		}
+
+		return media.Inspect(bytes.NewReader(data), int64(len(data)))
+	}
+
+	return media.Inspect(reader, stat.Size())
+}
@@ -0,0 +1,149 @@
+//go:build unit
+
+package checks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+	"github.com/UCLALibrary/validation-service/validation/media"
+)
+
+// TestImageIntegrityCheck_Validate tests the Validate method on ImageIntegrityCheck.
+func TestImageIntegrityCheck_Validate(t *testing.T) {
+	codestream, err := os.ReadFile("../../testdata/images/test.jpx")
+	require.NoError(t, err)
+
+	pngData := &bytes.Buffer{}
+	require.NoError(t, png.Encode(pngData, image.NewGray(image.Rect(0, 0, 10, 10))))
+
+	fileSystem := fstest.MapFS{
+		"images/good.jpx":      {Data: codestream},
+		"images/truncated.jpx": {Data: codestream[:len(codestream)/2]},
+		"images/png.tif":       {Data: pngData.Bytes()},
+		"images/text.jpg":      {Data: []byte("not an image")},
+		"images/notes.txt":     {Data: []byte("not an image")},
+	}
+
+	check, err := NewImageIntegrityCheck(config.NewProfiles(), config.Validation{
+		Name:    "ImageIntegrityCheck",
+		Options: json.RawMessage(`{"workers": 2}`),
+	}, fileSystem)
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Title", "File Name"},
+		{"Good", "Masters/images/good.jpx"},
+		{"Truncated", "images/truncated.jpx"},
+		{"Mislabeled", "images/png.tif"},
+		{"Unknown", "images/text.jpg"},
+		{"Not an image", "images/notes.txt"},
+		{"Missing", "images/missing.jpx"},
+		{"Outside", "../images/good.jpx"},
+		{"Empty", ""},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr string
+	}{
+		{name: "header row", location: csv.Location{RowIndex: 0, ColIndex: 1}},
+		{name: "not a File Name", location: csv.Location{RowIndex: 2, ColIndex: 0}},
+		{name: "intact image", location: csv.Location{RowIndex: 1, ColIndex: 1}},
+		{name: "truncated image", location: csv.Location{RowIndex: 2, ColIndex: 1},
+			expectedErr: fmt.Sprintf(errors.ImageDamagedErr, "images/truncated.jpx", media.ErrTruncated)},
+		{name: "mislabeled image", location: csv.Location{RowIndex: 3, ColIndex: 1},
+			expectedErr: fmt.Sprintf(errors.ImageFormatErr, "images/png.tif", media.PNG)},
+		{name: "unknown format", location: csv.Location{RowIndex: 4, ColIndex: 1},
+			expectedErr: fmt.Sprintf(errors.ImageUnknownErr, "images/text.jpg")},
+		{name: "not an image extension", location: csv.Location{RowIndex: 5, ColIndex: 1}},
+		{name: "missing file", location: csv.Location{RowIndex: 6, ColIndex: 1}},
+		{name: "outside file system", location: csv.Location{RowIndex: 7, ColIndex: 1}},
+		{name: "empty value", location: csv.Location{RowIndex: 8, ColIndex: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("DLP Staff", tt.location, data)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestImageIntegrityCheck_Decode tests that JPEG 2000 images in the HOST_DIR are decoded, when Kakadu is installed.
+func TestImageIntegrityCheck_Decode(t *testing.T) {
+	codestream, err := os.ReadFile("../../testdata/images/test.jpx")
+	require.NoError(t, err)
+
+	hostDir := t.TempDir()
+	for _, name := range []string{"good.jpx", "bad.jpx"} {
+		require.NoError(t, os.WriteFile(filepath.Join(hostDir, name), codestream, 0o600))
+	}
+	t.Setenv("HOST_DIR", hostDir)
+
+	// A fake kdu_expand, which fails to decode images whose names contain "bad"
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$2\" in\n*bad*) echo 'Kakadu Error:' >&2; echo 'Corrupt codestream' >&2; exit 1;;\nesac\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, media.KakaduExpand), []byte(script), 0o755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	check, err := NewImageIntegrityCheck(config.NewProfiles(), config.Validation{
+		Name:    "ImageIntegrityCheck",
+		Options: json.RawMessage(`{"decode": true, "timeout": "10s"}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{{"File Name"}, {"good.jpx"}, {"bad.jpx"}}
+
+	assert.NoError(t, check.Validate("DLP Staff", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+
+	err = check.Validate("DLP Staff", csv.Location{RowIndex: 2, ColIndex: 0}, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf(errors.ImageDecodeErr, "bad.jpx", "Corrupt codestream"))
+}
+
+// TestNewImageIntegrityCheck tests that an ImageIntegrityCheck can't be created with invalid options.
+func TestNewImageIntegrityCheck(t *testing.T) {
+	_, err := NewImageIntegrityCheck(nil, config.Validation{Name: "ImageIntegrityCheck"})
+	assert.Error(t, err)
+
+	_, err = NewImageIntegrityCheck(config.NewProfiles(), config.Validation{
+		Name:    "ImageIntegrityCheck",
+		Options: json.RawMessage(`{"decode": true, "timeout": "soon"}`),
+	})
+	assert.Error(t, err)
+
+	// A timeout has to be greater than zero, or no image could be decoded
+	for _, timeout := range []string{"0s", "-1m"} {
+		_, err = NewImageIntegrityCheck(config.NewProfiles(), config.Validation{
+			Name:    "ImageIntegrityCheck",
+			Options: json.RawMessage(`{"decode": true, "timeout": "` + timeout + `"}`),
+		})
+		assert.ErrorContains(t, err, "isn't greater than zero")
+	}
+
+	_, err = NewImageIntegrityCheck(config.NewProfiles(), config.Validation{
+		Name:    "ImageIntegrityCheck",
+		Options: json.RawMessage(`{"bogus": true}`),
+	})
+	assert.Error(t, err)
+}
@@ -1,7 +1,13 @@
 package checks
 
 import (
+	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
 
 	"github.com/UCLALibrary/validation-service/validation/config"
 
@@ -9,21 +15,49 @@ import (
 	"github.com/UCLALibrary/validation-service/validation/csv"
 )
 
-// ItemSeqCheck type is a validator that checks for the presence of stray new lines.
+// ItemSequence is the position of a Page within its parent.
+const ItemSequence = "Item Sequence"
+
+// ItemSeqOptions are the profile options that configure an ItemSeqCheck.
+type ItemSeqOptions struct {
+	Contiguous bool `json:"contiguous,omitempty"` // Each parent's Pages must be numbered 1 to n, without gaps or repeats
+	Ordered    bool `json:"ordered,omitempty"`    // Each parent's Pages must appear in the CSV in sequence order
+}
+
+// pageSequence is the Item Sequence of a Page and the row in which it was found.
+type pageSequence struct {
+	row      int
+	sequence int
+}
+
+// ItemSeqCheck type is a validator that checks that Item Sequences are positive integers.
 //
-// It implements the Validator interface and returns an error on failure to validate.
+// When it's configured to, it also groups Pages by their Parent ARK and checks that each parent's sequences are
+// contiguous and in order. It implements the Validator interface and returns an error on failure to validate.
 type ItemSeqCheck struct {
 	profiles *config.Profiles
+	options  ItemSeqOptions
+	problems csvCache[map[int][]string]
 }
 
 // NewItemSeqCheck checks that all values in Item Sequence are positive integers.
-func NewItemSeqCheck(profiles *config.Profiles) (*ItemSeqCheck, error) {
+//
+// The supplied validation can enable checking the sequences of each parent's Pages. It returns an error if the
+// provided profiles argument is nil or if the validation's options can't be decoded.
+func NewItemSeqCheck(profiles *config.Profiles, validation config.Validation) (*ItemSeqCheck, error) {
+	var options ItemSeqOptions
+
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
 	return &ItemSeqCheck{
 		profiles: profiles,
+		options:  options,
 	}, nil
 }
 
@@ -43,7 +77,7 @@ func (check *ItemSeqCheck) Validate(profile string, location csv.Location, csvDa
 	}
 
 	// Skip if we don't have an Item Sequence cell, or we're on the first (i.e., header) row
-	if header != "Item Sequence" || location.RowIndex == 0 {
+	if header != ItemSequence || location.RowIndex == 0 {
 		return nil
 	}
 
@@ -67,5 +101,108 @@ func (check *ItemSeqCheck) Validate(profile string, location csv.Location, csvDa
 		return csv.NewError(errors.NotAPosIntErr, location, profile)
 	}
 
-	return nil
+	var errs error
+
+	// Problems with the sequence of the Page's parent are reported at the Page's Item Sequence
+	for _, problem := range check.problems.get(csvData, check.findProblems)[location.RowIndex] {
+		errs = multierr.Combine(errs, csv.NewError(problem, location, profile))
+	}
+
+	return errs
+}
+
+// findProblems finds the sequence problems of the supplied CSV data's parents, keyed by the row they're reported at.
+func (check *ItemSeqCheck) findProblems(csvData [][]string) map[int][]string {
+	problems := make(map[int][]string)
+
+	if !check.options.Contiguous && !check.options.Ordered {
+		return problems
+	}
+
+	columns, found := findColumns([]string{ObjectType, ParentARK, ItemSequence}, csvData[0])
+	if !found {
+		return problems
+	}
+
+	var parents []string
+
+	groups := make(map[string][]pageSequence)
+	for row := 1; row < len(csvData); row++ {
+		if len(csvData[row]) <= slices.Max(columns) || strings.TrimSpace(csvData[row][columns[0]]) != "Page" {
+			continue
+		}
+
+		// Sequences that aren't positive integers are reported by the cell check, so we leave them out
+		parent := strings.TrimSpace(csvData[row][columns[1]])
+		sequence, err := strconv.Atoi(csvData[row][columns[2]])
+		if err != nil || sequence <= 0 {
+			continue
+		}
+
+		if _, exists := groups[parent]; !exists {
+			parents = append(parents, parent)
+		}
+
+		groups[parent] = append(groups[parent], pageSequence{row: row, sequence: sequence})
+	}
+
+	for _, parent := range parents {
+		if check.options.Contiguous {
+			checkContiguous(problems, parent, groups[parent])
+		}
+
+		if check.options.Ordered {
+			checkOrdered(problems, parent, groups[parent])
+		}
+	}
+
+	return problems
+}
+
+// checkContiguous records repeated sequences, and sequences that don't start at one or that follow a gap.
+func checkContiguous(problems map[int][]string, parent string, pages []pageSequence) {
+	firstRows := make(map[int]int, len(pages))
+	unique := make([]pageSequence, 0, len(pages))
+
+	for _, page := range pages {
+		if first, exists := firstRows[page.sequence]; exists {
+			message := fmt.Sprintf(errors.SeqRepeatedErr, page.sequence, parent, first+1)
+			problems[page.row] = append(problems[page.row], message)
+			continue
+		}
+
+		firstRows[page.sequence] = page.row
+		unique = append(unique, page)
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		return unique[i].sequence < unique[j].sequence
+	})
+
+	if unique[0].sequence != 1 {
+		message := fmt.Sprintf(errors.SeqStartErr, parent, unique[0].sequence)
+		problems[unique[0].row] = append(problems[unique[0].row], message)
+	}
+
+	for index := 1; index < len(unique); index++ {
+		if expected := unique[index-1].sequence + 1; unique[index].sequence != expected {
+			message := fmt.Sprintf(errors.SeqGapErr, unique[index].sequence, parent, expected)
+			problems[unique[index].row] = append(problems[unique[index].row], message)
+		}
+	}
+}
+
+// checkOrdered records sequences that appear in the CSV after a higher sequence with the same parent.
+func checkOrdered(problems map[int][]string, parent string, pages []pageSequence) {
+	highest := pages[0]
+
+	for _, page := range pages[1:] {
+		if page.sequence < highest.sequence {
+			message := fmt.Sprintf(errors.SeqOrderErr, page.sequence, parent, highest.sequence, highest.row+1)
+			problems[page.row] = append(problems[page.row], message)
+			continue
+		}
+
+		highest = page
+	}
 }
@@ -3,16 +3,18 @@
 package checks
 
 import (
+	"encoding/json"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"testing"
 
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestValidateItemSeq tests the Validate method on ObjTypeCheck.
 func TestValidateItemSeq(t *testing.T) {
-	check, err := NewItemSeqCheck(config.NewProfiles())
+	check, err := NewItemSeqCheck(config.NewProfiles(), config.Validation{Name: "ItemSeqCheck"})
 	assert.NoError(t, err)
 
 	tests := []struct {
@@ -89,3 +91,80 @@ func TestValidateItemSeq(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateItemSeq_Sequences tests checking the sequences of each parent's Pages.
+func TestValidateItemSeq_Sequences(t *testing.T) {
+	check, err := NewItemSeqCheck(config.NewProfiles(), config.Validation{
+		Name:    "ItemSeqCheck",
+		Options: json.RawMessage(`{"contiguous": true, "ordered": true}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Object Type", "Parent ARK", "Item Sequence"},
+		{"Work", "ark:/21198/c1", ""},
+		{"Page", "ark:/21198/w1", "1"},
+		{"Page", "ark:/21198/w1", "3"},
+		{"Page", "ark:/21198/w1", "2"},
+		{"Page", "ark:/21198/w1", "2"},
+		{"Page", "ark:/21198/w1", "5"},
+		{"Page", "ark:/21198/w2", "2"},
+		{"Page", "ark:/21198/w2", "3"},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "Page that starts its parent's sequence",
+			location: csv.Location{RowIndex: 2, ColIndex: 2},
+		},
+		{
+			name:        "Page that's out of order",
+			location:    csv.Location{RowIndex: 4, ColIndex: 2},
+			expectedErr: []string{"Item Sequence 2 for parent `ark:/21198/w1` is out of order (follows 3 in row 4)"},
+		},
+		{
+			name:     "Page that's repeated and out of order",
+			location: csv.Location{RowIndex: 5, ColIndex: 2},
+			expectedErr: []string{
+				"Item Sequence 2 is repeated for parent `ark:/21198/w1` (first found in row 5)",
+				"Item Sequence 2 for parent `ark:/21198/w1` is out of order (follows 3 in row 4)",
+			},
+		},
+		{
+			name:        "Page that follows a gap",
+			location:    csv.Location{RowIndex: 6, ColIndex: 2},
+			expectedErr: []string{"Item Sequence 5 for parent `ark:/21198/w1` follows a gap (expected 4)"},
+		},
+		{
+			name:        "Parent whose sequence doesn't start at one",
+			location:    csv.Location{RowIndex: 7, ColIndex: 2},
+			expectedErr: []string{"Item Sequences for parent `ark:/21198/w2` start at 2 instead of 1"},
+		},
+		{
+			name:     "Page that follows a parent's first Page",
+			location: csv.Location{RowIndex: 8, ColIndex: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+
+	// Without the options, only the cells are checked
+	check, err = NewItemSeqCheck(config.NewProfiles(), config.Validation{Name: "ItemSeqCheck"})
+	require.NoError(t, err)
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 6, ColIndex: 2}, data))
+}
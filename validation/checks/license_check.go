@@ -1,10 +1,15 @@
 package checks
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net/http"
-	"regexp"
+	"net/url"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/UCLALibrary/validation-service/validation/config"
 
@@ -12,32 +17,125 @@ import (
 	"github.com/UCLALibrary/validation-service/validation/csv"
 )
 
+// Defaults for the optional network probe of licenses that aren't on the approved list
+const (
+	DefaultLicenseTimeout  = 10 * time.Second
+	DefaultLicenseCacheTTL = time.Hour
+)
+
+// maxLicenseCacheSize is the number of license probe results that are kept at once.
+const maxLicenseCacheSize = 1000
+
+// licenseRetryTTL is the longest a probe's result is kept when the license URL couldn't be reached, or its server had
+// a problem, since the URL may be retrievable again soon.
+const licenseRetryTTL = time.Minute
+
+// LicenseOptions are the profile options that configure a LicenseCheck.
+//
+// Licenses are compared without their scheme (i.e., http and https are treated the same) or trailing slash. If no
+// licenses are configured, the Creative Commons licenses and RightsStatements.org statements are approved.
+type LicenseOptions struct {
+	Licenses []string `json:"licenses,omitempty"` // The licenses that are approved
+	Probe    bool     `json:"probe,omitempty"`    // Whether unapproved licenses are accepted if they can be retrieved
+	Timeout  string   `json:"timeout,omitempty"`  // How long a probe can take, as a Go duration (default: 10s)
+	CacheTTL string   `json:"cacheTTL,omitempty"` // How long a probe's result is kept, as a Go duration (default: 1h)
+}
+
+// licenseResult is the cached result of probing a license URL.
+type licenseResult struct {
+	message string
+	expires time.Time
+}
+
+// licenseCache is a cache of license probe results that's shared by all LicenseChecks.
+var licenseCache = struct {
+	sync.Mutex
+	results map[string]licenseResult
+}{results: make(map[string]licenseResult)}
+
 // LicenseCheck validates the License field for a given profile.
 type LicenseCheck struct {
 	profiles *config.Profiles
+	licenses map[string]struct{}
+	probe    bool
+	timeout  time.Duration
+	cacheTTL time.Duration
+	client   *http.Client
 	valids   []string
 	invalids []string
 }
 
 // NewLicenseCheck creates a new LicenseCheck instance, which validates the License field for a given profile.
 //
-// It returns an error if the profiles argument is nil.
-func NewLicenseCheck(profiles *config.Profiles) (*LicenseCheck, error) {
+// The supplied validation configures the approved licenses and whether unapproved licenses are probed. An HTTP client
+// can be supplied for the probes; otherwise, a client with the configured timeout is used. It returns an error if the
+// profiles argument is nil or if the validation's options can't be decoded.
+func NewLicenseCheck(profiles *config.Profiles, validation config.Validation, suppliedClient ...*http.Client) (
+	*LicenseCheck, error) {
+	var options LicenseOptions
+	var err error
+
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
-	return &LicenseCheck{
+	if err = validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	check := &LicenseCheck{
 		profiles: profiles,
+		probe:    options.Probe,
+		timeout:  DefaultLicenseTimeout,
+		cacheTTL: DefaultLicenseCacheTTL,
 		valids:   make([]string, 0),
 		invalids: make([]string, 0),
-	}, nil
+	}
+
+	if options.Timeout != "" {
+		if check.timeout, err = time.ParseDuration(options.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid license timeout: %w", err)
+		}
+
+		if check.timeout <= 0 {
+			return nil, fmt.Errorf("invalid license timeout: %s isn't greater than zero", options.Timeout)
+		}
+	}
+
+	if options.CacheTTL != "" {
+		if check.cacheTTL, err = time.ParseDuration(options.CacheTTL); err != nil {
+			return nil, fmt.Errorf("invalid license cache TTL: %w", err)
+		}
+
+		if check.cacheTTL < 0 {
+			return nil, fmt.Errorf("invalid license cache TTL: %s is negative", options.CacheTTL)
+		}
+	}
+
+	if len(suppliedClient) > 0 && suppliedClient[0] != nil {
+		check.client = suppliedClient[0]
+	} else {
+		check.client = &http.Client{Timeout: check.timeout}
+	}
+
+	licenses := options.Licenses
+	if len(licenses) == 0 {
+		licenses = defaultLicenses()
+	}
+
+	check.licenses = make(map[string]struct{}, len(licenses))
+	for _, license := range licenses {
+		check.licenses[normalizeLicense(license)] = struct{}{}
+	}
+
+	return check, nil
 }
 
-// Validate checks if the License field in the CSV data is correctly formatted and points to a valid URL.
+// Validate checks if the License field in the CSV data is an approved license.
 //
 // If the profile is "bucketeer", the license check is skipped.
-// It checks if the header is "License" and verifies if the value is a valid URL and accessible.
+// It checks if the header is "License", skipping the header row itself, and verifies that the value is a well-formed
+// URL that's been approved or, if the check is configured to probe licenses, that can be retrieved.
 // It returns an error if the License field is invalid or there are issues with the URL.
 func (check *LicenseCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
 	// license not relevant to Bucketeer processing
@@ -56,7 +154,7 @@ func (check *LicenseCheck) Validate(profile string, location csv.Location, csvDa
 		return err
 	}
 
-	if header != "License" {
+	if header != "License" || location.RowIndex == 0 {
 		return nil
 	}
 
@@ -77,29 +175,156 @@ func (check *LicenseCheck) Validate(profile string, location csv.Location, csvDa
 	return nil
 }
 
-// verifyLicense checks if the given license string is a valid URL and if it can be accessed.
+// verifyLicense checks if the given license string is a well-formed URL and if it's been approved.
 //
-// It uses a regular expression to validate the URL format and sends an HTTP GET request to ensure the URL is reachable.
-// It returns an error if the URL is not formatted correctly or if the URL is not accessible.
+// If the license hasn't been approved and the check is configured to probe licenses, it's accepted if it can be
+// retrieved. It returns an error if the URL is not formatted correctly or if the license can't be accepted.
 func (check *LicenseCheck) verifyLicense(license string, profile string, location csv.Location) error {
-	r := regexp.MustCompile(`^^http\:\/\/[0-9a-zA-Z]([-.\w]*[0-9a-zA-Z])*(:(0-9)*)*(\/?)([a-zA-Z0-9\-\.\?\,\'\/\\\+&amp;%\$#_]*)?$`)
-	if !r.MatchString(license) {
+	parsed, err := url.Parse(license)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.User != nil ||
+		!strings.Contains(parsed.Hostname(), ".") {
 		return csv.NewError(errors.URLFormatErr, location, profile)
 	}
 
-	resp, err := http.Get(license)
+	if _, approved := check.licenses[normalizeLicense(license)]; approved {
+		return nil
+	}
+
+	if !check.probe {
+		return csv.NewError(fmt.Sprintf(errors.URLApprovalErr, license), location, profile)
+	}
+
+	if message := check.probeLicense(license); message != "" {
+		return csv.NewError(message, location, profile)
+	}
+
+	// Supplied license is valid
+	return nil
+}
+
+// probeLicense retrieves the license URL, returning an error message if it can't be retrieved.
+//
+// Results are cached, and shared between LicenseChecks, so each URL is only retrieved once per cache TTL. A result that
+// might change on the next try is only kept for a minute, at most, instead.
+func (check *LicenseCheck) probeLicense(license string) string {
+	licenseCache.Lock()
+	result, found := licenseCache.results[license]
+	licenseCache.Unlock()
+
+	if found && time.Now().Before(result.expires) {
+		return result.message
+	}
+
+	message, definite := check.fetchLicense(license)
+
+	ttl := check.cacheTTL
+	if !definite {
+		ttl = min(ttl, licenseRetryTTL)
+	}
+
+	cacheLicenseResult(license, licenseResult{message: message, expires: time.Now().Add(ttl)})
+
+	return message
+}
+
+// cacheLicenseResult stores a license probe's result in the shared cache.
+//
+// Expired results are removed first and, if the cache is still full, the result that expires soonest is evicted, so
+// the cache never holds more than maxLicenseCacheSize results.
+func cacheLicenseResult(license string, result licenseResult) {
+	licenseCache.Lock()
+	defer licenseCache.Unlock()
+
+	now := time.Now()
+
+	for cached, cachedResult := range licenseCache.results {
+		if !now.Before(cachedResult.expires) {
+			delete(licenseCache.results, cached)
+		}
+	}
+
+	if _, found := licenseCache.results[license]; !found && len(licenseCache.results) >= maxLicenseCacheSize {
+		var soonest string
+
+		for cached, cachedResult := range licenseCache.results {
+			if soonest == "" || cachedResult.expires.Before(licenseCache.results[soonest].expires) {
+				soonest = cached
+			}
+		}
+
+		delete(licenseCache.results, soonest)
+	}
+
+	licenseCache.results[license] = result
+}
+
+// fetchLicense sends an HTTP GET request for the license URL, returning an error message if it doesn't succeed and
+// whether that's a definite answer. A URL that can't be reached or read, or whose server is busy or has a problem,
+// might be retrieved on the next try.
+func (check *LicenseCheck) fetchLicense(license string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), check.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, license, nil)
 	if err != nil {
-		return csv.NewError(errors.URLConnectErr, location, profile)
+		return errors.URLConnectErr, true // The URL itself is malformed
+	}
+
+	resp, err := check.client.Do(request)
+	if err != nil {
+		return errors.URLConnectErr, false
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	_, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return csv.NewError(errors.URLReadErr, location, profile)
+	if _, err = io.Copy(io.Discard, resp.Body); err != nil {
+		return errors.URLReadErr, false
 	}
 
-	// Supplied license is valid
-	return nil
+	if resp.StatusCode >= http.StatusBadRequest {
+		transient := resp.StatusCode >= http.StatusInternalServerError ||
+			resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+
+		return fmt.Sprintf(errors.URLStatusErr, resp.StatusCode), !transient
+	}
+
+	return "", true
+}
+
+// normalizeLicense removes a license URL's scheme and trailing slash, and lowercases its host, for comparisons.
+func normalizeLicense(license string) string {
+	license = strings.TrimSpace(license)
+
+	for _, scheme := range []string{"https://", "http://"} {
+		if len(license) >= len(scheme) && strings.EqualFold(license[:len(scheme)], scheme) {
+			license = license[len(scheme):]
+			break
+		}
+	}
+
+	host, path, _ := strings.Cut(license, "/")
+	return strings.TrimRight(strings.ToLower(host)+"/"+path, "/")
+}
+
+// defaultLicenses returns the Creative Commons licenses and the RightsStatements.org rights statements.
+func defaultLicenses() []string {
+	licenses := []string{
+		"http://creativecommons.org/publicdomain/zero/1.0/",
+		"http://creativecommons.org/publicdomain/mark/1.0/",
+		"http://creativecommons.org/licenses/by-nd-nc/1.0/",
+	}
+
+	for _, version := range []string{"1.0", "2.0", "2.5", "3.0", "4.0"} {
+		for _, license := range []string{"by", "by-sa", "by-nd", "by-nc", "by-nc-sa", "by-nc-nd"} {
+			licenses = append(licenses, "http://creativecommons.org/licenses/"+license+"/"+version+"/")
+		}
+	}
+
+	for _, statement := range []string{"InC", "InC-OW-EU", "InC-EDU", "InC-NC", "InC-RUU", "NoC-CR", "NoC-NC",
+		"NoC-OKLR", "NoC-US", "CNE", "UND", "NKC"} {
+		licenses = append(licenses, "http://rightsstatements.org/vocab/"+statement+"/1.0/")
+	}
+
+	return licenses
 }
@@ -3,16 +3,23 @@
 package checks
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
 	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestVerifyLicense checks if verifyLicense throws the correct errors when given incorrect licenses
 func TestVerifyLicense(t *testing.T) {
-	check, err := NewLicenseCheck(config.NewProfiles())
+	check, err := NewLicenseCheck(config.NewProfiles(), config.Validation{Name: "LicenseCheck"})
 	assert.NoError(t, err)
 
 	// genericLocation provides a consistent location for the purposes of test comparison.
@@ -36,7 +43,7 @@ func TestVerifyLicense(t *testing.T) {
 			result:   true,
 		},
 		{
-			name:     "Invalid license (https prefix) with Festerize profile",
+			name:     "Invalid license (not approved) with Festerize profile",
 			profile:  "festerize",
 			location: genericLocation,
 			data:     [][]string{{"License"}, {"https://library.ucla.edu"}},
@@ -83,3 +90,177 @@ func TestVerifyLicense(t *testing.T) {
 	assert.True(t, slices.Equal(check.valids, testValids))
 	assert.True(t, slices.Equal(check.invalids, testInvalids))
 }
+
+// TestLicenseCheck_Allowlist tests that licenses are checked against the approved licenses.
+func TestLicenseCheck_Allowlist(t *testing.T) {
+	check, err := NewLicenseCheck(config.NewProfiles(), config.Validation{Name: "LicenseCheck"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		license     string
+		expectedErr bool
+	}{
+		{name: "Creative Commons license", license: "http://creativecommons.org/licenses/by/4.0/"},
+		{name: "HTTPS Creative Commons license", license: "https://creativecommons.org/licenses/by-sa/3.0/"},
+		{name: "License without a trailing slash", license: "https://creativecommons.org/licenses/by-nc-nd/4.0"},
+		{name: "License with an uppercase host", license: "http://CreativeCommons.org/licenses/by/2.0/"},
+		{name: "Rights statement", license: "http://rightsstatements.org/vocab/InC/1.0/"},
+		{name: "Unapproved license", license: "https://example.com/license", expectedErr: true},
+		{name: "License with a scheme that isn't HTTP", license: "ftp://creativecommons.org/licenses/by/4.0/",
+			expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, [][]string{{"License"}, {tt.license}})
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// A configured list of licenses replaces the default one
+	check, err = NewLicenseCheck(config.NewProfiles(), config.Validation{
+		Name:    "LicenseCheck",
+		Options: json.RawMessage(`{"licenses": ["https://example.com/license/"]}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{{"License"}, {"http://example.com/license"}, {"http://creativecommons.org/licenses/by/4.0/"}}
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 0, ColIndex: 0}, data))
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, data))
+	assert.Error(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, data))
+}
+
+// TestLicenseCheck_Probe tests that unapproved licenses can be accepted if they can be retrieved.
+func TestLicenseCheck_Probe(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+
+		switch request.URL.Path {
+		case "/found":
+			writer.WriteHeader(http.StatusOK)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	validation := config.Validation{
+		Name:    "LicenseCheck",
+		Options: json.RawMessage(`{"probe": true, "timeout": "50ms", "cacheTTL": "1m"}`),
+	}
+
+	for _, path := range []string{"/found", "/found"} {
+		// Each check is new, so the second check's result comes from the shared cache
+		check, err := NewLicenseCheck(config.NewProfiles(), validation, server.Client())
+		require.NoError(t, err)
+
+		err = check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, [][]string{{"License"}, {server.URL + path}})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), requests.Load())
+
+	check, err := NewLicenseCheck(config.NewProfiles(), validation, server.Client())
+	require.NoError(t, err)
+
+	data := [][]string{{"License"}, {server.URL + "/missing"}, {server.URL + "/slow"}}
+	assert.ErrorContains(t, check.Validate("Test", csv.Location{RowIndex: 1, ColIndex: 0}, data),
+		"license URL responded with HTTP status 404")
+	assert.ErrorContains(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, data),
+		"problem connecting to license URL")
+}
+
+// TestLicenseCheck_ProbeRetry tests that probe results that might change on the next try aren't kept for long.
+func TestLicenseCheck_ProbeRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/unavailable":
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	check, err := NewLicenseCheck(config.NewProfiles(), config.Validation{
+		Name:    "LicenseCheck",
+		Options: json.RawMessage(`{"probe": true, "timeout": "50ms", "cacheTTL": "1h"}`),
+	}, server.Client())
+	require.NoError(t, err)
+
+	data := [][]string{{"License"}, {server.URL + "/missing"}, {server.URL + "/unavailable"}, {server.URL + "/slow"}}
+	for row := 1; row < len(data); row++ {
+		assert.Error(t, check.Validate("Test", csv.Location{RowIndex: row, ColIndex: 0}, data))
+	}
+
+	retryBy := time.Now().Add(licenseRetryTTL)
+
+	licenseCache.Lock()
+	defer licenseCache.Unlock()
+
+	// A missing license is a definite answer, but an unavailable or unreachable one may not be
+	assert.True(t, licenseCache.results[server.URL+"/missing"].expires.After(retryBy))
+	assert.False(t, licenseCache.results[server.URL+"/unavailable"].expires.After(retryBy))
+	assert.False(t, licenseCache.results[server.URL+"/slow"].expires.After(retryBy))
+}
+
+// TestCacheLicenseResult tests that the shared cache of license probe results is pruned as results are stored.
+func TestCacheLicenseResult(t *testing.T) {
+	licenseCache.Lock()
+	saved := licenseCache.results
+	licenseCache.results = make(map[string]licenseResult)
+	licenseCache.Unlock()
+
+	defer func() {
+		licenseCache.Lock()
+		licenseCache.results = saved
+		licenseCache.Unlock()
+	}()
+
+	now := time.Now()
+
+	cacheLicenseResult("https://example.com/expired", licenseResult{expires: now.Add(-time.Minute)})
+	cacheLicenseResult("https://example.com/soonest", licenseResult{expires: now.Add(time.Minute)})
+
+	for index := range maxLicenseCacheSize - 1 {
+		license := fmt.Sprintf("https://example.com/license/%d", index)
+		cacheLicenseResult(license, licenseResult{expires: now.Add(time.Hour)})
+	}
+
+	// The expired result was removed when the next result was stored, and the cache is now full
+	assert.NotContains(t, licenseCache.results, "https://example.com/expired")
+	assert.Contains(t, licenseCache.results, "https://example.com/soonest")
+	assert.Len(t, licenseCache.results, maxLicenseCacheSize)
+
+	// Storing another result evicts the one that expires soonest
+	cacheLicenseResult("https://example.com/latest", licenseResult{expires: now.Add(time.Hour)})
+	assert.NotContains(t, licenseCache.results, "https://example.com/soonest")
+	assert.Contains(t, licenseCache.results, "https://example.com/latest")
+	assert.Len(t, licenseCache.results, maxLicenseCacheSize)
+}
+
+// TestNewLicenseCheck tests creating a new LicenseCheck.
+func TestNewLicenseCheck(t *testing.T) {
+	_, err := NewLicenseCheck(nil, config.Validation{Name: "LicenseCheck"})
+	assert.Error(t, err)
+
+	for _, options := range []string{`{"timeout": "soon"}`, `{"timeout": "0s"}`, `{"timeout": "-1s"}`,
+		`{"cacheTTL": "forever"}`, `{"cacheTTL": "-1m"}`, `{"allowlist": []}`} {
+		_, err = NewLicenseCheck(config.NewProfiles(), config.Validation{
+			Name:    "LicenseCheck",
+			Options: json.RawMessage(options),
+		})
+		assert.Error(t, err, options)
+	}
+}
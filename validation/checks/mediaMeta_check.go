@@ -1,10 +1,15 @@
 package checks
 
 import (
+	"fmt"
+	"io/fs"
 	"maps"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/media"
 
 	"go.uber.org/multierr"
 
@@ -13,6 +18,8 @@ import (
 )
 
 // MediaMetaCheck validates the media.* fields for the Fester profile.
+//
+// For rows with an image file, it also compares the media.* fields with the dimensions and format of the file.
 type MediaMetaCheck struct {
 	profiles          *config.Profiles
 	mediaCols         map[string]int
@@ -21,17 +28,20 @@ type MediaMetaCheck struct {
 	allFieldsFound    bool
 	allFieldsMissing  bool
 	someFieldsMissing bool
+	fileSystem        fs.FS
+	images            map[string]*media.Info
 }
 
 // NewMediaMetaCheck creates a new MediaMetaCheck instance, which validates the media.* fields for the Fester profile.
 //
-// It returns an error if the profiles argument is nil.
-func NewMediaMetaCheck(profiles *config.Profiles) (*MediaMetaCheck, error) {
+// A file system can be supplied for image files to be read from; otherwise, they're read from the HOST_DIR. It
+// returns an error if the profiles argument is nil.
+func NewMediaMetaCheck(profiles *config.Profiles, suppliedFS ...fs.FS) (*MediaMetaCheck, error) {
 	if profiles == nil {
 		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
 	}
 
-	return &MediaMetaCheck{
+	check := &MediaMetaCheck{
 		profiles:          profiles,
 		mediaCols:         make(map[string]int),
 		mediaTypes:        []string{"mov", "aud", "aum", "aun"},
@@ -39,7 +49,14 @@ func NewMediaMetaCheck(profiles *config.Profiles) (*MediaMetaCheck, error) {
 		allFieldsFound:    false,
 		allFieldsMissing:  false,
 		someFieldsMissing: false,
-	}, nil
+		images:            make(map[string]*media.Info),
+	}
+
+	if len(suppliedFS) > 0 && suppliedFS[0] != nil {
+		check.fileSystem = suppliedFS[0]
+	}
+
+	return check, nil
 }
 
 // Validate checks if the media.* fields have been added to the CSV, and if they have been populated  for A/V media entries.
@@ -49,6 +66,7 @@ func NewMediaMetaCheck(profiles *config.Profiles) (*MediaMetaCheck, error) {
 // Media types vocabulary: https://github.com/UCLALibrary/californica/blob/main/config/authorities/resource_types.yml
 // Media types examined by this check: moving image (mov). sound recording (aud), sound recording-musical (aum), sound recording-nonmusical (aun)
 // It returns an error if the media width/height/duration/format fields are missing or empty.
+// The media width/height/format fields of rows with an image file are compared with the file's, at the HOST_DIR.
 func (check *MediaMetaCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
 
 	// media metadata fields only relevant to Fester
@@ -67,7 +85,11 @@ func (check *MediaMetaCheck) Validate(profile string, location csv.Location, csv
 		return err
 	}
 
-	if header != "Type.typeOfResource" {
+	switch header {
+	case "media.width", "media.height", "media.format":
+		return check.verifyFile(header, profile, location, csvData)
+	case "Type.typeOfResource":
+	default:
 		return nil
 	}
 
@@ -136,7 +158,8 @@ func (check *MediaMetaCheck) verifyContent(profile string, location csv.Location
 	var errs error
 	//check media.* fields, compose error for all empty fields
 	for fieldName, colIndex := range check.mediaCols {
-		if csvData[location.RowIndex][colIndex] == "" || len(csvData[location.RowIndex][colIndex]) == 0 {
+		row := csvData[location.RowIndex]
+		if colIndex >= len(row) || row[colIndex] == "" {
 			switch fieldName {
 			case "media.width":
 				errs = multierr.Combine(errs, csv.NewError(errors.WidthEmptyErr, location, profile))
@@ -151,3 +174,68 @@ func (check *MediaMetaCheck) verifyContent(profile string, location csv.Location
 	}
 	return errs
 }
+
+// verifyFile compares the value of a media.* field with the dimensions or format of the row's image file.
+//
+// Rows without an image file, and empty values, are skipped. Files that don't exist or can't be inspected are left
+// for the FilePathCheck and ImageIntegrityCheck to report.
+func (check *MediaMetaCheck) verifyFile(field string, profile string, location csv.Location, csvData [][]string) error {
+	value := strings.TrimSpace(csvData[location.RowIndex][location.ColIndex])
+	if location.RowIndex == 0 || value == "" {
+		return nil
+	}
+
+	fileName, err := csv.GetRowValue(FileName, location, csvData, profile)
+	if err != nil || media.FormatsFor(stripPrefix(fileName)) == nil {
+		return nil
+	}
+
+	if check.fileSystem == nil {
+		if check.fileSystem, _, err = openHostDir(location, profile); err != nil {
+			return err
+		}
+	}
+
+	info := check.getImage(stripPrefix(fileName))
+	if info == nil {
+		return nil
+	}
+
+	switch field {
+	case "media.width":
+		if width, err := strconv.Atoi(value); err != nil || width != info.Width {
+			return csv.NewError(fmt.Sprintf(errors.MediaWidthErr, value, fileName, info.Width), location, profile)
+		}
+	case "media.height":
+		if height, err := strconv.Atoi(value); err != nil || height != info.Height {
+			return csv.NewError(fmt.Sprintf(errors.MediaHeightErr, value, fileName, info.Height), location, profile)
+		}
+	default:
+		if !info.Format.MatchesMIMEType(value) {
+			message := fmt.Sprintf(errors.MediaFormatErr, value, fileName, info.Format.MIMEType())
+			return csv.NewError(message, location, profile)
+		}
+	}
+
+	return nil
+}
+
+// getImage returns what's learned from inspecting the supplied image file, or nil if it can't be inspected.
+//
+// Each file is only inspected once, since its media.width, media.height, and media.format are all compared with it.
+func (check *MediaMetaCheck) getImage(name string) *media.Info {
+	if info, found := check.images[name]; found {
+		return info
+	}
+
+	var image *media.Info
+
+	if path, err := csv.ResolveFS(check.fileSystem, name); err == nil {
+		if info, inspectErr := inspectFile(check.fileSystem, path); inspectErr == nil {
+			image = &info
+		}
+	}
+
+	check.images[name] = image
+	return image
+}
@@ -3,10 +3,16 @@
 package checks
 
 import (
+	"fmt"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/UCLALibrary/validation-service/errors"
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"github.com/UCLALibrary/validation-service/validation/csv"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 )
 
 // TestVerifyMeta checks if MediaMetaCheck.Validate throws the correct errors when given missing media metadata
@@ -50,6 +56,13 @@ func TestVerifyMeta(t *testing.T) {
 			data:     [][]string{{"Type.typeOfResource", "media.width", "media.height", "media.duration", "media.format"}, {"mov", "5", "", "", "mov"}},
 			result:   false,
 		},
+		{
+			name:     "Fester profile, media resource, short row",
+			profile:  "fester",
+			location: startLocation,
+			data:     [][]string{{"Type.typeOfResource", "media.width", "media.height", "media.duration", "media.format"}, {"mov", "5"}},
+			result:   false,
+		},
 		{
 			name:     "Fester profile, media resource, missing some metadat columns",
 			profile:  "fester",
@@ -77,3 +90,62 @@ func TestVerifyMeta(t *testing.T) {
 		})
 	}
 }
+
+// TestVerifyMetaFile checks if MediaMetaCheck.Validate compares media metadata with the image file at File Name
+func TestVerifyMetaFile(t *testing.T) {
+	codestream, err := os.ReadFile("../../testdata/images/test.jpx")
+	require.NoError(t, err)
+
+	fileSystem := fstest.MapFS{
+		"images/test.jpx":      {Data: codestream},
+		"images/truncated.jpx": {Data: codestream[:100]},
+	}
+
+	check, err := NewMediaMetaCheck(config.NewProfiles(), fileSystem)
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"File Name", "media.width", "media.height", "media.format"},
+		{"Masters/images/test.jpx", "2000", "2000", "image/jpx"},
+		{"images/test.jpx", "1999", "two thousand", "image/tiff"},
+		{"images/test.mp4", "1999", "1999", "video/mp4"},
+		{"images/missing.jpx", "1999", "1999", "image/jp2"},
+		{"images/truncated.jpx", "1999", "1999", "image/jp2"},
+		{"images/test.jpx", "", "", ""},
+	}
+
+	tests := []struct {
+		name        string
+		profile     string
+		location    csv.Location
+		expectedErr string
+	}{
+		{name: "non-Fester profile", profile: "bucketeer", location: csv.Location{RowIndex: 2, ColIndex: 1}},
+		{name: "header row", profile: "fester", location: csv.Location{RowIndex: 0, ColIndex: 1}},
+		{name: "matching width", profile: "fester", location: csv.Location{RowIndex: 1, ColIndex: 1}},
+		{name: "matching height", profile: "fester", location: csv.Location{RowIndex: 1, ColIndex: 2}},
+		{name: "matching format", profile: "fester", location: csv.Location{RowIndex: 1, ColIndex: 3}},
+		{name: "mismatched width", profile: "fester", location: csv.Location{RowIndex: 2, ColIndex: 1},
+			expectedErr: fmt.Sprintf(errors.MediaWidthErr, "1999", "images/test.jpx", 2000)},
+		{name: "non-integer height", profile: "fester", location: csv.Location{RowIndex: 2, ColIndex: 2},
+			expectedErr: fmt.Sprintf(errors.MediaHeightErr, "two thousand", "images/test.jpx", 2000)},
+		{name: "mismatched format", profile: "fester", location: csv.Location{RowIndex: 2, ColIndex: 3},
+			expectedErr: fmt.Sprintf(errors.MediaFormatErr, "image/tiff", "images/test.jpx", "image/j2c")},
+		{name: "not an image", profile: "fester", location: csv.Location{RowIndex: 3, ColIndex: 1}},
+		{name: "missing image", profile: "fester", location: csv.Location{RowIndex: 4, ColIndex: 1}},
+		{name: "damaged image", profile: "fester", location: csv.Location{RowIndex: 5, ColIndex: 1}},
+		{name: "empty value", profile: "fester", location: csv.Location{RowIndex: 6, ColIndex: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate(tt.profile, tt.location, data)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
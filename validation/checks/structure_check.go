@@ -0,0 +1,139 @@
+package checks
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// StructureCheck is a validator that checks the shape of a CSV, rather than its values.
+//
+// It reports headers that are blank, repeated, or padded with whitespace, at their header cells; rows that are shorter
+// or longer than the header row, at their last cell or first extra cell; rows that are empty, at their first cell; and
+// columns that are empty, including their headers, at their header cells. Rows can only be shorter or longer than the
+// header row if the CSV was read tolerantly. It implements the Validator interface and returns an error on failure to
+// validate.
+type StructureCheck struct {
+	profiles *config.Profiles
+	problems csvCache[structureProblems]
+}
+
+// structureProblems are the structural problems of a CSV, keyed by the location they're reported at.
+type structureProblems map[csv.Location][]string
+
+// NewStructureCheck returns a new StructureCheck, which validates that a CSV's headers and rows are well-formed.
+//
+// It returns an error if the provided profiles argument is nil.
+func NewStructureCheck(profiles *config.Profiles) (*StructureCheck, error) {
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	return &StructureCheck{
+		profiles: profiles,
+	}, nil
+}
+
+// Validate checks whether any structural problems are reported at the cell at the supplied location.
+//
+// This check doesn't care what profile is being used.
+func (check *StructureCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	var errs error
+
+	for _, problem := range check.problems.get(csvData, findStructureProblems)[location] {
+		errs = multierr.Combine(errs, csv.NewError(problem, location, profile))
+	}
+
+	return errs
+}
+
+// findStructureProblems finds the structural problems of the supplied CSV data.
+func findStructureProblems(csvData [][]string) structureProblems {
+	problems := make(structureProblems)
+
+	headers := csvData[0]
+	firstColumns := make(map[string]int, len(headers))
+
+	for colIndex, header := range headers {
+		location := csv.Location{RowIndex: 0, ColIndex: colIndex}
+		name := strings.TrimSpace(header)
+
+		switch {
+		case isEmptyColumn(csvData, colIndex):
+			problems.add(location, errors.ColumnEmptyErr)
+			continue
+		case name == "":
+			problems.add(location, errors.HeaderBlankErr)
+			continue
+		case name != header:
+			problems.add(location, fmt.Sprintf(errors.HeaderPaddedErr, name))
+		}
+
+		if first, found := firstColumns[name]; found {
+			problems.add(location, fmt.Sprintf(errors.HeaderRepeatedErr, name, first+1))
+		} else {
+			firstColumns[name] = colIndex
+		}
+	}
+
+	for rowIndex := 1; rowIndex < len(csvData); rowIndex++ {
+		row := csvData[rowIndex]
+
+		// A row without any cells can't be reported, but it can only be read from a record that couldn't be parsed
+		if len(row) == 0 {
+			continue
+		}
+
+		switch {
+		case len(row) < len(headers):
+			location := csv.Location{RowIndex: rowIndex, ColIndex: len(row) - 1}
+			problems.add(location, fmt.Sprintf(errors.RowShortErr, len(row), len(headers)))
+		case len(row) > len(headers):
+			location := csv.Location{RowIndex: rowIndex, ColIndex: len(headers)}
+			problems.add(location, fmt.Sprintf(errors.RowLongErr, len(row), len(headers)))
+		}
+
+		if isEmptyRow(row) {
+			problems.add(csv.Location{RowIndex: rowIndex, ColIndex: 0}, errors.RowEmptyErr)
+		}
+	}
+
+	return problems
+}
+
+// add records a problem that's reported at the supplied location.
+func (problems structureProblems) add(location csv.Location, problem string) {
+	problems[location] = append(problems[location], problem)
+}
+
+// isEmptyRow returns whether all of a row's cells are blank.
+func isEmptyRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEmptyColumn returns whether all of a column's cells, including its header, are blank; a row that's too short to
+// have a cell in the column doesn't have a value in it.
+func isEmptyColumn(csvData [][]string, colIndex int) bool {
+	for _, row := range csvData {
+		if colIndex < len(row) && strings.TrimSpace(row[colIndex]) != "" {
+			return false
+		}
+	}
+
+	return true
+}
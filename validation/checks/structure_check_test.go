@@ -0,0 +1,121 @@
+//go:build unit
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestStructureCheck_Validate tests the Validate method on StructureCheck.
+func TestStructureCheck_Validate(t *testing.T) {
+	check, err := NewStructureCheck(config.NewProfiles())
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Item ARK", "", " Title", "Item ARK", "Title", ""},
+		{"ark:/21198/w1", "", "One", "ark:/21198/w1", "One", ""},
+		{"ark:/21198/w2", "x", "Two"},
+		{"ark:/21198/w3", "", "Three", "", "", "", "extra"},
+		{"", " ", "", "", "", ""},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "Header that's well-formed",
+			location: csv.Location{RowIndex: 0, ColIndex: 0},
+		},
+		{
+			name:        "Blank header",
+			location:    csv.Location{RowIndex: 0, ColIndex: 1},
+			expectedErr: []string{"the header is blank"},
+		},
+		{
+			name:        "Padded header",
+			location:    csv.Location{RowIndex: 0, ColIndex: 2},
+			expectedErr: []string{"header `Title` has whitespace around it"},
+		},
+		{
+			name:        "Repeated header",
+			location:    csv.Location{RowIndex: 0, ColIndex: 3},
+			expectedErr: []string{"header `Item ARK` is repeated (first found in column 1)"},
+		},
+		{
+			name:        "Repeated header that was first found padded",
+			location:    csv.Location{RowIndex: 0, ColIndex: 4},
+			expectedErr: []string{"header `Title` is repeated (first found in column 3)"},
+		},
+		{
+			name:        "Empty column",
+			location:    csv.Location{RowIndex: 0, ColIndex: 5},
+			expectedErr: []string{"the column is empty, including its header"},
+		},
+		{
+			name:     "Row that's well-formed",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+		},
+		{
+			name:        "Short row",
+			location:    csv.Location{RowIndex: 2, ColIndex: 2},
+			expectedErr: []string{"the row has 3 field(s), but the header row has 6"},
+		},
+		{
+			name:        "Long row",
+			location:    csv.Location{RowIndex: 3, ColIndex: 6},
+			expectedErr: []string{"the row has 7 field(s), but the header row has only 6"},
+		},
+		{
+			name:        "Empty row",
+			location:    csv.Location{RowIndex: 4, ColIndex: 0},
+			expectedErr: []string{"the row is empty"},
+		},
+		{
+			name:     "Empty row is only reported at its first cell",
+			location: csv.Location{RowIndex: 4, ColIndex: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			assert.Len(t, multierr.Errors(err), len(tt.expectedErr))
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+// TestStructureCheck_NewData tests that a StructureCheck finds the problems of each CSV it's given.
+func TestStructureCheck_NewData(t *testing.T) {
+	check, err := NewStructureCheck(config.NewProfiles())
+	require.NoError(t, err)
+
+	location := csv.Location{RowIndex: 0, ColIndex: 1}
+	assert.Error(t, check.Validate("Test", location, [][]string{{"Title", ""}, {"One", "Two"}}))
+	assert.NoError(t, check.Validate("Test", location, [][]string{{"Title", "Creator"}, {"One", "Two"}}))
+}
+
+// TestNewStructureCheck tests creating a new StructureCheck.
+func TestNewStructureCheck(t *testing.T) {
+	_, err := NewStructureCheck(nil)
+	assert.Error(t, err)
+
+	check, err := NewStructureCheck(config.NewProfiles())
+	require.NoError(t, err)
+	assert.Error(t, check.Validate("Test", csv.Location{RowIndex: 2, ColIndex: 0}, [][]string{{"Title"}}))
+}
@@ -0,0 +1,291 @@
+package checks
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+
+	"github.com/UCLALibrary/validation-service/errors"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// DefaultDelimiter is the delimiter between the multiple values of a cell, unless a check is configured otherwise.
+const DefaultDelimiter = "|~|"
+
+// VocabularyOptions are the profile options that configure a VocabularyCheck.
+//
+// Vocabulary files are relative to the directory of the PROFILES_FILE, and can't be outside of it.
+type VocabularyOptions struct {
+	Vocabularies map[string]string `json:"vocabularies"`         // The vocabulary file for each header that's checked
+	Delimiter    string            `json:"delimiter,omitempty"`  // The delimiter between a cell's values (default: |~|)
+	IgnoreCase   bool              `json:"ignoreCase,omitempty"` // Whether values can differ from terms in their case
+}
+
+// vocabulary is the list of terms that are allowed in a column.
+type vocabulary struct {
+	terms  []string
+	lookup map[string]string // The terms, keyed by their lowercase form
+}
+
+// vocabularyEntry is a cached vocabulary, along with the state of its file when it was read.
+type vocabularyEntry struct {
+	vocabulary *vocabulary
+	modTime    time.Time
+	size       int64
+}
+
+// vocabularyCache is a cache of vocabularies that's shared by all VocabularyChecks, so files are only read again if
+// they've changed.
+var vocabularyCache = struct {
+	sync.Mutex
+	entries map[string]vocabularyEntry
+}{entries: make(map[string]vocabularyEntry)}
+
+// VocabularyCheck validates that a column's values are terms in the column's controlled vocabulary.
+//
+// Vocabularies are read from text files with one term per line. Blank lines and lines starting with '#' are skipped,
+// and anything after a tab (e.g., a term's label) is ignored. It implements the Validator interface and returns an
+// error on failure to validate.
+type VocabularyCheck struct {
+	profiles     *config.Profiles
+	vocabularies map[string]string
+	delimiter    string
+	ignoreCase   bool
+}
+
+// NewVocabularyCheck creates a new VocabularyCheck instance, which validates values against controlled vocabularies.
+//
+// The supplied validation maps headers to their vocabulary files. It returns an error if the profiles argument is nil
+// or if the validation's options can't be decoded.
+func NewVocabularyCheck(profiles *config.Profiles, validation config.Validation) (*VocabularyCheck, error) {
+	var options VocabularyOptions
+
+	if profiles == nil {
+		return nil, csv.NewError(errors.NilProfileErr, csv.Location{}, "nil")
+	}
+
+	if err := validation.DecodeOptions(&options); err != nil {
+		return nil, err
+	}
+
+	if err := checkLocalPaths("vocabularies", slices.Sorted(maps.Values(options.Vocabularies))); err != nil {
+		return nil, err
+	}
+
+	return &VocabularyCheck{
+		profiles:     profiles,
+		vocabularies: options.Vocabularies,
+		delimiter:    cmp.Or(options.Delimiter, DefaultDelimiter),
+		ignoreCase:   options.IgnoreCase,
+	}, nil
+}
+
+// Validate checks that each of the values in a cell is a term in its column's vocabulary.
+//
+// This check doesn't care what profile is being used. Vocabularies that can't be read are reported at their column's
+// header, and values that aren't terms are reported along with the closest term, if there's one that's close enough.
+func (check *VocabularyCheck) Validate(profile string, location csv.Location, csvData [][]string) error {
+	if err := csv.IsValidLocation(location, csvData, profile); err != nil {
+		return err
+	}
+
+	// Find the header and determine if it has a vocabulary
+	header, err := csv.GetHeader(location, csvData, profile)
+	if err != nil {
+		return err
+	}
+
+	file, found := check.vocabularies[header]
+	if !found {
+		return nil
+	}
+
+	vocab, err := loadVocabulary(file)
+	if err != nil {
+		// Vocabularies that can't be read are reported once, at their column's header
+		if location.RowIndex == 0 {
+			return csv.NewError(fmt.Sprintf(errors.VocabFileErr, header, err), location, profile)
+		}
+
+		return nil
+	}
+
+	// Skip if we're on the first (i.e., header) row
+	if location.RowIndex == 0 {
+		return nil
+	}
+
+	var errs error
+
+	for value := range strings.SplitSeq(csvData[location.RowIndex][location.ColIndex], check.delimiter) {
+		value = strings.TrimSpace(value)
+		if value == "" || vocab.contains(value, check.ignoreCase) {
+			continue
+		}
+
+		message := fmt.Sprintf(errors.VocabTermErr, value, header)
+		if suggestion, found := vocab.closest(value); found {
+			message = fmt.Sprintf(errors.VocabSuggestErr, value, header, suggestion)
+		}
+
+		errs = multierr.Combine(errs, csv.NewError(message, location, profile))
+	}
+
+	return errs
+}
+
+// contains returns whether the supplied value is one of the vocabulary's terms.
+func (vocab *vocabulary) contains(value string, ignoreCase bool) bool {
+	term, found := vocab.lookup[strings.ToLower(value)]
+	return found && (ignoreCase || term == value)
+}
+
+// closest returns the vocabulary's term that's closest to the supplied value, and whether it's close enough to be
+// suggested.
+//
+// Terms are compared by their edit distance, ignoring case. A term is close enough if it's no more than a third of the
+// value's length away from it, or only differs from it in its case.
+func (vocab *vocabulary) closest(value string) (string, bool) {
+	lowerValue := strings.ToLower(value)
+	if term, found := vocab.lookup[lowerValue]; found {
+		return term, true
+	}
+
+	limit := max(1, len([]rune(lowerValue))/3)
+	suggestion, best := "", limit+1
+
+	for _, term := range vocab.terms {
+		if distance := editDistance(lowerValue, strings.ToLower(term), best); distance < best {
+			suggestion, best = term, distance
+		}
+	}
+
+	return suggestion, suggestion != ""
+}
+
+// editDistance returns the Levenshtein distance between two strings, or the limit if it's at least the limit.
+func editDistance(first string, second string, limit int) int {
+	source, target := []rune(first), []rune(second)
+
+	// The difference in length is the fewest edits that could be needed
+	if len(source)-len(target) >= limit || len(target)-len(source) >= limit {
+		return limit
+	}
+
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for index := range previous {
+		previous[index] = index
+	}
+
+	for sourceIndex := 1; sourceIndex <= len(source); sourceIndex++ {
+		current[0] = sourceIndex
+		rowMinimum := current[0]
+
+		for targetIndex := 1; targetIndex <= len(target); targetIndex++ {
+			cost := 1
+			if source[sourceIndex-1] == target[targetIndex-1] {
+				cost = 0
+			}
+
+			current[targetIndex] = min(previous[targetIndex]+1, current[targetIndex-1]+1,
+				previous[targetIndex-1]+cost)
+			rowMinimum = min(rowMinimum, current[targetIndex])
+		}
+
+		// Once every edit in a row reaches the limit, the distance can't get any smaller
+		if rowMinimum >= limit {
+			return limit
+		}
+
+		previous, current = current, previous
+	}
+
+	return min(previous[len(target)], limit)
+}
+
+// loadVocabulary returns the vocabulary in the supplied file, reading it if it's not cached or if it's changed.
+//
+// The file must be inside the directory of the PROFILES_FILE. Its errors only name the file as it was configured, since
+// they're reported to whoever uploaded the CSV being checked.
+func loadVocabulary(file string) (*vocabulary, error) {
+	path, err := csv.ResolvePath(filepath.Dir(os.Getenv(config.ConfigFile)), file)
+	if err != nil {
+		return nil, configuredFileError(file, "the profiles directory", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, configuredFileError(file, "the profiles directory", err)
+	}
+
+	vocabularyCache.Lock()
+	defer vocabularyCache.Unlock()
+
+	if entry, found := vocabularyCache.entries[path]; found && entry.modTime.Equal(info.ModTime()) &&
+		entry.size == info.Size() {
+		return entry.vocabulary, nil
+	}
+
+	vocab, err := readVocabulary(path)
+	if err != nil {
+		return nil, configuredFileError(file, "the profiles directory", err)
+	}
+
+	vocabularyCache.entries[path] = vocabularyEntry{vocabulary: vocab, modTime: info.ModTime(), size: info.Size()}
+
+	return vocab, nil
+}
+
+// readVocabulary reads the terms in a vocabulary file.
+func readVocabulary(path string) (*vocabulary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	vocab := &vocabulary{lookup: make(map[string]string)}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		term, _, _ := strings.Cut(scanner.Text(), "\t")
+
+		// Files saved with a byte order mark have it at the start of their first term
+		term = strings.TrimSpace(strings.TrimPrefix(term, "\ufeff"))
+
+		if term == "" || strings.HasPrefix(term, "#") {
+			continue
+		}
+
+		vocab.terms = append(vocab.terms, term)
+		vocab.lookup[strings.ToLower(term)] = term
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(vocab.terms) == 0 {
+		return nil, fmt.Errorf("%s doesn't have any terms", filepath.Base(path))
+	}
+
+	// Sorted terms make the suggestion for a value the same, whatever order the file is in
+	slices.Sort(vocab.terms)
+
+	return vocab, nil
+}
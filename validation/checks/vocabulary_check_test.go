@@ -0,0 +1,229 @@
+//go:build unit
+
+package checks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// TestVocabularyCheck_Validate tests the Validate method on VocabularyCheck.
+func TestVocabularyCheck_Validate(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.ConfigFile, filepath.Join(dir, "profiles.json"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "languages.txt"),
+		[]byte("\ufeff# ISO 639-2 codes\nspa\tSpanish\neng\tEnglish\n\narn\tMapudungun\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "vocabularies"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vocabularies", "types.txt"),
+		[]byte("still image\nmoving image\ntext\n"), 0o600))
+
+	check, err := NewVocabularyCheck(config.NewProfiles(), config.Validation{
+		Name: "VocabularyCheck",
+		Options: json.RawMessage(`{"vocabularies": {"Language": "languages.txt",
+			"Type.typeOfResource": "vocabularies/types.txt", "Genre": "missing.txt"}}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{
+		{"Language", "Type.typeOfResource", "Genre", "Title"},
+		{"spa", "still image", "posters", "Arte Libertad"},
+		{"spa|~|arn", "Still Image", "", ""},
+		{"spa|~|enh", "stil image", "", ""},
+		{"xyz", "sculpture", "", ""},
+		{"", "", "", ""},
+	}
+
+	tests := []struct {
+		name        string
+		location    csv.Location
+		expectedErr []string
+	}{
+		{
+			name:     "Term",
+			location: csv.Location{RowIndex: 1, ColIndex: 0},
+		},
+		{
+			name:     "Term with spaces",
+			location: csv.Location{RowIndex: 1, ColIndex: 1},
+		},
+		{
+			name:     "Multiple terms",
+			location: csv.Location{RowIndex: 2, ColIndex: 0},
+		},
+		{
+			name:     "Term with a different case",
+			location: csv.Location{RowIndex: 2, ColIndex: 1},
+			expectedErr: []string{"value `Still Image` for `Type.typeOfResource` isn't in its vocabulary " +
+				"(did you mean `still image`?)"},
+		},
+		{
+			name:        "Typo in one of multiple values",
+			location:    csv.Location{RowIndex: 3, ColIndex: 0},
+			expectedErr: []string{"value `enh` for `Language` isn't in its vocabulary (did you mean `eng`?)"},
+		},
+		{
+			name:        "Typo in a term",
+			location:    csv.Location{RowIndex: 3, ColIndex: 1},
+			expectedErr: []string{"(did you mean `still image`?)"},
+		},
+		{
+			name:        "Value without a close term",
+			location:    csv.Location{RowIndex: 4, ColIndex: 0},
+			expectedErr: []string{"value `xyz` for `Language` isn't in its vocabulary"},
+		},
+		{
+			name:     "Empty value",
+			location: csv.Location{RowIndex: 5, ColIndex: 0},
+		},
+		{
+			name:     "Column without a vocabulary",
+			location: csv.Location{RowIndex: 1, ColIndex: 3},
+		},
+		{
+			name:        "Vocabulary that can't be read",
+			location:    csv.Location{RowIndex: 0, ColIndex: 2},
+			expectedErr: []string{"vocabulary for `Genre` could not be read: `missing.txt` (it doesn't exist)"},
+		},
+		{
+			name:     "Values aren't checked without their vocabulary",
+			location: csv.Location{RowIndex: 1, ColIndex: 2},
+		},
+		{
+			name:     "Header row isn't checked",
+			location: csv.Location{RowIndex: 0, ColIndex: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check.Validate("Test", tt.location, data)
+			if len(tt.expectedErr) == 0 {
+				assert.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedErr {
+				assert.ErrorContains(t, err, expected)
+			}
+		})
+	}
+
+	// Without a close term, there's no suggestion
+	assert.NotContains(t, check.Validate("Test", csv.Location{RowIndex: 4, ColIndex: 1}, data).Error(), "did you mean")
+}
+
+// TestVocabularyCheck_Options tests the delimiter and case options of a VocabularyCheck.
+func TestVocabularyCheck_Options(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(config.ConfigFile, filepath.Join(dir, "profiles.json"))
+
+	file := filepath.Join(dir, "statuses.txt")
+	require.NoError(t, os.WriteFile(file, []byte("copyrighted\npd\nunknown\n"), 0o600))
+
+	options, err := json.Marshal(map[string]any{
+		"vocabularies": map[string]string{"Rights.copyrightStatus": "statuses.txt"},
+		"delimiter":    ";",
+		"ignoreCase":   true,
+	})
+	require.NoError(t, err)
+
+	check, err := NewVocabularyCheck(config.NewProfiles(), config.Validation{Name: "VocabularyCheck", Options: options})
+	require.NoError(t, err)
+
+	data := [][]string{{"Rights.copyrightStatus"}, {"Copyrighted; PD"}, {"copyrighted|~|pd"}}
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1}, data))
+	assert.Error(t, check.Validate("Test", csv.Location{RowIndex: 2}, data))
+
+	// Vocabularies are read again when they change
+	require.NoError(t, os.WriteFile(file, []byte("copyrighted|~|pd\n"), 0o600))
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 2}, data))
+	assert.Error(t, check.Validate("Test", csv.Location{RowIndex: 1}, data))
+
+	_, err = NewVocabularyCheck(nil, config.Validation{Name: "VocabularyCheck"})
+	assert.Error(t, err)
+
+	_, err = NewVocabularyCheck(config.NewProfiles(), config.Validation{
+		Name:    "VocabularyCheck",
+		Options: json.RawMessage(`{"vocabulary": {"Language": "languages.txt"}}`),
+	})
+	assert.Error(t, err)
+}
+
+// TestVocabularyCheck_Paths tests that vocabulary files must be inside the directory of the PROFILES_FILE.
+func TestVocabularyCheck_Paths(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "profiles")
+	t.Setenv(config.ConfigFile, filepath.Join(dir, "profiles.json"))
+
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secrets.txt"), []byte("password\n"), 0o600))
+
+	for _, file := range []string{filepath.Join(root, "secrets.txt"), "../secrets.txt", "vocabularies/../../secrets.txt"} {
+		options, err := json.Marshal(map[string]any{"vocabularies": map[string]string{"Language": file}})
+		require.NoError(t, err)
+
+		_, err = NewVocabularyCheck(config.NewProfiles(), config.Validation{Name: "VocabularyCheck", Options: options})
+		assert.ErrorContains(t, err, "it must be relative and stay inside its directory", file)
+	}
+
+	// Links can't lead out of the directory either, and the file's location isn't revealed
+	require.NoError(t, os.Symlink(filepath.Join(root, "secrets.txt"), filepath.Join(dir, "link.txt")))
+
+	check, err := NewVocabularyCheck(config.NewProfiles(), config.Validation{
+		Name:    "VocabularyCheck",
+		Options: json.RawMessage(`{"vocabularies": {"Language": "link.txt"}}`),
+	})
+	require.NoError(t, err)
+
+	data := [][]string{{"Language"}, {"passwor"}}
+	err = check.Validate("Test", csv.Location{}, data)
+	assert.ErrorContains(t, err, "vocabulary for `Language` could not be read: `link.txt` (it's outside of the "+
+		"profiles directory)")
+	assert.NotContains(t, err.Error(), root)
+	assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: 1}, data))
+}
+
+// TestEditDistance tests the edit distances that suggestions are based on.
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("spa", "spa", 5))
+	assert.Equal(t, 1, editDistance("enh", "eng", 5))
+	assert.Equal(t, 1, editDistance("stil image", "still image", 5))
+	assert.Equal(t, 3, editDistance("kitten", "sitting", 5))
+	assert.Equal(t, 2, editDistance("kitten", "sitting", 2))
+	assert.Equal(t, 2, editDistance("a", "abcdef", 2))
+}
+
+// TestVocabularyCheck_Shipped tests that the vocabularies that are shipped with the service accept the sample CSVs.
+func TestVocabularyCheck_Shipped(t *testing.T) {
+	// The vocabularies directory is found alongside the profiles file, as it is in the Docker image
+	t.Setenv(config.ConfigFile, filepath.Join("..", "..", "profiles.json"))
+
+	check, err := NewVocabularyCheck(config.NewProfiles(), config.Validation{
+		Name: "VocabularyCheck",
+		Options: json.RawMessage(`{"vocabularies": {"Language": "vocabularies/iso639-2.txt",
+			"Type.typeOfResource": "vocabularies/mods-resource-types.txt",
+			"Rights.copyrightStatus": "vocabularies/copyright-statuses.txt"}}`),
+	})
+	require.NoError(t, err)
+
+	for _, file := range []string{"cct-collection.csv", "cct-works-simple.csv"} {
+		data, _, err := csv.ReadFile(filepath.Join("..", "..", "testdata", file), csv.ReadOptions{},
+			zaptest.NewLogger(t))
+		require.NoError(t, err)
+
+		for row := range data {
+			for col := range data[row] {
+				assert.NoError(t, check.Validate("Test", csv.Location{RowIndex: row, ColIndex: col}, data))
+			}
+		}
+	}
+}
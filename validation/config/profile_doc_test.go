@@ -10,8 +10,8 @@ func ExampleProfiles() {
 	profiles := NewProfiles()
 
 	profile, err := NewProfile("example", []Validation{
-		{"Validation1", "Validation 1 description"},
-		{"Validation2", "Validation 2 description"},
+		{Name: "Validation1", Description: "Validation 1 description"},
+		{Name: "Validation2", Description: "Validation 2 description"},
 	})
 	if err != nil {
 		panic(err)
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,10 +17,16 @@ const LogLevel string = "LOG_LEVEL"
 // ConfigFile is the ENV property for the location of the persisted JSON Profiles file.
 const ConfigFile string = "PROFILES_FILE"
 
+// PollInterval is the ENV property for how often the persisted JSON Profiles file is checked for changes (e.g., "5s").
+const PollInterval string = "PROFILES_POLL_INTERVAL"
+
 // Validation is a single validation.
+//
+// Options is an optional, validation-specific JSON block that's passed to the validation's check when it's created.
 type Validation struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     json.RawMessage `json:"options,omitempty"`
 }
 
 // Profile is a single thread-safe validation profile.
@@ -52,6 +59,24 @@ type profilesSnapshot struct {
 	LastUpdate time.Time                  `json:"lastUpdate"`
 }
 
+// DecodeOptions decodes the Validation's options into the supplied target, leaving it unchanged if there are none.
+//
+// Options with fields that the target doesn't have are rejected, so typos in a profile configuration are caught.
+func (validation Validation) DecodeOptions(target interface{}) error {
+	if len(validation.Options) == 0 || string(validation.Options) == "null" {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(validation.Options))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("invalid options for validation '%s': %w", validation.Name, err)
+	}
+
+	return nil
+}
+
 // NewProfile is a constructor function to initialize a new Profile.
 func NewProfile(name string, validations []Validation) (*Profile, error) {
 	if name == "" {
@@ -76,18 +101,31 @@ func NewProfiles() *Profiles {
 //
 // This overwrites the current in-memory values.
 func (profiles *Profiles) Refresh() error {
+	refreshedProfiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+
+	profiles.Replace(refreshedProfiles)
+	return nil
+}
+
+// LoadProfiles loads new Profiles from the last persisted version on disk.
+//
+// Unlike Refresh, this doesn't change any in-memory Profiles, so the loaded Profiles can be checked before they're used.
+func LoadProfiles() (*Profiles, error) {
 	var refreshedProfiles profilesSnapshot
 
 	// Get the location of the persisted Profiles file
 	filePath := os.Getenv(ConfigFile)
 	if filePath == "" {
-		return fmt.Errorf("environment variable %s is not set or empty", ConfigFile)
+		return nil, fmt.Errorf("environment variable %s is not set or empty", ConfigFile)
 	}
 
 	// Open the persisted JSON file
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open '%s' file: %w", filePath, err)
+		return nil, fmt.Errorf("failed to open '%s' file: %w", filePath, err)
 	}
 	defer func() {
 		_ = file.Close()
@@ -96,12 +134,12 @@ func (profiles *Profiles) Refresh() error {
 	// Decode the persisted JSON file into a profilesSnapshot
 	decoder := json.NewDecoder(file)
 	if err := decoder.Decode(&refreshedProfiles); err != nil {
-		return fmt.Errorf("failed to decode JSON: %w", err)
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
 	}
 
 	// Check that our JSON file actually has some profiles
 	if len(refreshedProfiles.Profile) == 0 {
-		return fmt.Errorf("no profiles found in refreshed data")
+		return nil, fmt.Errorf("no profiles found in refreshed data")
 	}
 
 	// Create a new temporary map for refreshed *Profile(s)
@@ -111,35 +149,49 @@ func (profiles *Profiles) Refresh() error {
 	for _, refreshedProfile := range refreshedProfiles.Profile {
 		profile, err := NewProfile(refreshedProfile.Name, refreshedProfile.Validations)
 		if err != nil {
-			return fmt.Errorf("failed to create new profile '%s': %w", refreshedProfile.Name, err)
+			return nil, fmt.Errorf("failed to create new profile '%s': %w", refreshedProfile.Name, err)
 		}
 
 		// Check to see if our tempMap already has a Profile with the same name
 		profileName := profile.GetName()
 		if _, exists := tempMap[profileName]; exists {
-			return fmt.Errorf("profile '%s' already exists", profileName)
+			return nil, fmt.Errorf("profile '%s' already exists", profileName)
 		}
 
 		tempMap[profileName] = profile
 	}
 
+	return &Profiles{
+		profile:    tempMap,
+		lastUpdate: refreshedProfiles.LastUpdate,
+	}, nil
+}
+
+// Replace overwrites the current in-memory values with those of the supplied Profiles.
+func (profiles *Profiles) Replace(replacement *Profiles) {
+	if replacement == nil || replacement == profiles {
+		return
+	}
+
+	replacement.mutex.RLock()
+	profileMap := make(map[string]*Profile, len(replacement.profile))
+	for name, profile := range replacement.profile {
+		profileMap[name] = profile
+	}
+	lastUpdate := replacement.lastUpdate
+	replacement.mutex.RUnlock()
+
 	profiles.mutex.Lock()
 	defer profiles.mutex.Unlock()
 
 	// Update the Profiles map and set a new lastUpdate time
-	profiles.profile = tempMap
-	profiles.lastUpdate = refreshedProfiles.LastUpdate
-
-	return nil
+	profiles.profile = profileMap
+	profiles.lastUpdate = lastUpdate
 }
 
 // String returns a string representation of the Profiles instance.
 func (profiles *Profiles) String() (string, error) {
-	// Lock the Profiles struct for thread-safe access
-	profiles.mutex.RLock()
-	defer profiles.mutex.RUnlock()
-
-	// Take a snapshot of the current profiles for serialization
+	// Take a thread-safe snapshot of the current profiles for serialization
 	snapshot := profiles.snapshot()
 
 	// Serialize the snapshot to JSON
@@ -165,11 +217,7 @@ func (profiles *Profiles) Save() error {
 		return fmt.Errorf("failed to create directory '%s': %w", dirPath, err)
 	}
 
-	// Lock the Profiles struct for thread-safe access
-	profiles.mutex.RLock()
-	defer profiles.mutex.RUnlock()
-
-	// Take a snapshot of the current profiles for serialization
+	// Take a thread-safe snapshot of the current profiles for serialization
 	snapshot := profiles.snapshot()
 
 	// Serialize the snapshot to JSON
@@ -178,10 +226,10 @@ func (profiles *Profiles) Save() error {
 		return fmt.Errorf("failed to serialize profiles to JSON: %w", jsonErr)
 	}
 
-	// Write to a temporary file first for atomic updates
-	tempFile, tempFileErr := os.CreateTemp("", "profile-*.json")
+	// Write to a temporary file in the same directory first, so the rename is atomic
+	tempFile, tempFileErr := os.CreateTemp(dirPath, "profile-*.json")
 	if tempFileErr != nil {
-		return fmt.Errorf("failed to create temporary file '%s': %w", tempFile.Name(), tempFileErr)
+		return fmt.Errorf("failed to create temporary file in '%s': %w", dirPath, tempFileErr)
 	}
 
 	// Write the JSON data to the temporary file
@@ -202,6 +250,20 @@ func (profiles *Profiles) Save() error {
 	return nil
 }
 
+// GetProfileNames gets the names of the Profile(s) in this Profiles instance, sorted alphabetically.
+func (profiles *Profiles) GetProfileNames() []string {
+	profiles.mutex.RLock()
+	defer profiles.mutex.RUnlock()
+
+	names := make([]string, 0, len(profiles.profile))
+	for name := range profiles.profile {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 // Count the number of Profile(s) in this Profiles instance.
 func (profiles *Profiles) Count() int {
 	profiles.mutex.RLock()
@@ -237,6 +299,27 @@ func (profile *Profile) GetValidations() []string {
 	return append([]string(nil), validations...)
 }
 
+// GetValidationDetails gets the validations, with their descriptions, of the current Profile.
+func (profile *Profile) GetValidationDetails() []Validation {
+	profile.mutex.RLock()
+	defer profile.mutex.RUnlock()
+	return append([]Validation(nil), profile.validations...)
+}
+
+// GetValidation gets the validation with the supplied name from the current Profile.
+func (profile *Profile) GetValidation(name string) (Validation, bool) {
+	profile.mutex.RLock()
+	defer profile.mutex.RUnlock()
+
+	for _, validation := range profile.validations {
+		if validation.Name == name {
+			return validation, true
+		}
+	}
+
+	return Validation{}, false
+}
+
 // AddValidation adds a new validation name to the current Profile.
 func (profile *Profile) AddValidation(name string, description string) {
 	profile.mutex.Lock()
@@ -248,7 +331,7 @@ func (profile *Profile) AddValidation(name string, description string) {
 		}
 	}
 
-	profile.validations = append(profile.validations, Validation{name, description})
+	profile.validations = append(profile.validations, Validation{Name: name, Description: description})
 }
 
 // GetProfile gets the Profile with the supplied name.
@@ -279,15 +362,15 @@ func (profile *Profile) SetValidations(validations []Validation) {
 	defer profile.mutex.Unlock()
 
 	profile.lastUpdate = time.Now()
-	uniqueValidations := make(map[Validation]struct{})
+	uniqueValidations := make(map[string]struct{})
+	profile.validations = make([]Validation, 0, len(validations))
 
+	// A validation can only be configured once per profile, so we keep the first one with a given name
 	for _, validation := range validations {
-		uniqueValidations[validation] = struct{}{}
-	}
-
-	profile.validations = make([]Validation, 0, len(uniqueValidations))
-	for validation := range uniqueValidations {
-		profile.validations = append(profile.validations, validation)
+		if _, exists := uniqueValidations[validation.Name]; !exists {
+			uniqueValidations[validation.Name] = struct{}{}
+			profile.validations = append(profile.validations, validation)
+		}
 	}
 
 	// Sort for consistency
@@ -317,6 +400,26 @@ func (profiles *Profiles) SetProfile(profile *Profile) error {
 	return nil
 }
 
+// DeleteProfile removes the Profile with the supplied name from Profiles.
+func (profiles *Profiles) DeleteProfile(name string) error {
+	profiles.mutex.Lock()
+	defer profiles.mutex.Unlock()
+
+	if _, exists := profiles.profile[name]; !exists {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+
+	profiles.lastUpdate = time.Now()
+	delete(profiles.profile, name)
+
+	return nil
+}
+
+// MarshalJSON returns a JSON representation of the current Profile.
+func (profile *Profile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(profile.snapshot())
+}
+
 // ByName implements sort.Interface for []Validation based on the Name field.
 //
 // This is used to sort the validations in a Profile by name.
@@ -19,14 +19,14 @@ import (
 func TestProfiles(t *testing.T) {
 	profiles := NewProfiles()
 	defaultProfile, errP1 := NewProfile("DLP Staff", []Validation{
-		{"SpaceCheck", "A space validator"},
-		{"ARKFormat", "An ARK validator"},
-		{"EOLCheck", "An EOL validator"},
+		{Name: "SpaceCheck", Description: "A space validator"},
+		{Name: "ARKFormat", Description: "An ARK validator"},
+		{Name: "EOLCheck", Description: "An EOL validator"},
 	})
 	require.NoError(t, errP1)
 	otherProfile, errP2 := NewProfile("other", []Validation{
-		{"SpaceCheck", "A space validator"},
-		{"ARKFormat", "An ARK validator"},
+		{Name: "SpaceCheck", Description: "A space validator"},
+		{Name: "ARKFormat", Description: "An ARK validator"},
 	})
 	require.NoError(t, errP2)
 
@@ -44,6 +44,100 @@ func TestProfiles(t *testing.T) {
 	assert.False(t, profiles.GetProfile("other").GetLastUpdate().IsZero())
 }
 
+// TestProfiles_DeleteProfile tests removing a Profile from Profiles.
+func TestProfiles_DeleteProfile(t *testing.T) {
+	profiles := NewProfiles()
+	for _, name := range []string{"other", "DLP Staff"} {
+		profile, err := NewProfile(name, []Validation{{Name: "EOLCheck", Description: "An EOL validator"}})
+		require.NoError(t, err)
+		require.NoError(t, profiles.SetProfile(profile))
+	}
+
+	assert.Equal(t, []string{"DLP Staff", "other"}, profiles.GetProfileNames())
+
+	require.NoError(t, profiles.DeleteProfile("other"))
+	assert.Nil(t, profiles.GetProfile("other"))
+	assert.Equal(t, []string{"DLP Staff"}, profiles.GetProfileNames())
+
+	// Deleting a profile that doesn't exist is an error
+	assert.Error(t, profiles.DeleteProfile("other"))
+}
+
+// TestProfile_MarshalJSON tests marshaling a single Profile to JSON.
+func TestProfile_MarshalJSON(t *testing.T) {
+	profile, err := NewProfile("example", []Validation{{Name: "Validation1", Description: "Validation 1 description"}})
+	require.NoError(t, err)
+
+	jsonData, err := json.Marshal(profile)
+	require.NoError(t, err)
+
+	var snapshot profileSnapshot
+	require.NoError(t, json.Unmarshal(jsonData, &snapshot))
+	assert.Equal(t, "example", snapshot.Name)
+	assert.Equal(t, profile.GetValidationDetails(), snapshot.Validations)
+}
+
+// TestProfile_SetValidations tests that validations are unique by name and keep their options.
+func TestProfile_SetValidations(t *testing.T) {
+	profile, err := NewProfile("example", nil)
+	require.NoError(t, err)
+
+	profile.SetValidations([]Validation{
+		{Name: "EOLCheck", Description: "An EOL validator"},
+		{Name: "ARKCheck", Description: "An ARK validator", Options: json.RawMessage(`{"naans": ["21198"]}`)},
+		{Name: "EOLCheck", Description: "A duplicate EOL validator"},
+	})
+
+	assert.Equal(t, []string{"ARKCheck", "EOLCheck"}, profile.GetValidations())
+
+	validation, found := profile.GetValidation("ARKCheck")
+	require.True(t, found)
+	assert.JSONEq(t, `{"naans": ["21198"]}`, string(validation.Options))
+
+	validation, found = profile.GetValidation("EOLCheck")
+	require.True(t, found)
+	assert.Equal(t, "An EOL validator", validation.Description)
+
+	_, found = profile.GetValidation("UnicodeCheck")
+	assert.False(t, found)
+}
+
+// TestValidation_DecodeOptions tests decoding a validation's options.
+func TestValidation_DecodeOptions(t *testing.T) {
+	type testOptions struct {
+		Values []string `json:"values"`
+	}
+
+	tests := []struct {
+		name      string
+		options   string
+		expected  []string
+		expectErr bool
+	}{
+		{name: "No options", expected: nil},
+		{name: "Null options", options: "null", expected: nil},
+		{name: "Valid options", options: `{"values": ["a", "b"]}`, expected: []string{"a", "b"}},
+		{name: "Unknown option", options: `{"value": "a"}`, expectErr: true},
+		{name: "Malformed options", options: `{"values": "a"}`, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var options testOptions
+
+			validation := Validation{Name: "TestCheck", Options: json.RawMessage(tt.options)}
+			err := validation.DecodeOptions(&options)
+
+			if tt.expectErr {
+				assert.ErrorContains(t, err, "TestCheck")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, options.Values)
+			}
+		})
+	}
+}
+
 // TestProfiles_Snapshot tests creating a bare-bones snapshot through marshaling it to JSON.
 func TestProfiles_Snapshot(t *testing.T) {
 	profiles := NewProfiles()
@@ -105,8 +199,8 @@ func TestProfiles_Save(t *testing.T) {
 	// Create a new Profile for testing
 	profiles := NewProfiles()
 	profile, _ := NewProfile("example", []Validation{
-		Validation{"Validation1", "Validation 1 description"},
-		Validation{"Validation2", "Validation 2 description"},
+		Validation{Name: "Validation1", Description: "Validation 1 description"},
+		Validation{Name: "Validation2", Description: "Validation 2 description"},
 	})
 	_ = profiles.SetProfile(profile)
 
@@ -133,8 +227,8 @@ func TestExampleCode(t *testing.T) {
 	output := captureOutput(t, func() {
 		profiles := NewProfiles()
 		if profile, err := NewProfile("example", []Validation{
-			Validation{"Validation1", "Validation 1 description"},
-			Validation{"Validation2", "Validation 2 description"},
+			Validation{Name: "Validation1", Description: "Validation 1 description"},
+			Validation{Name: "Validation2", Description: "Validation 2 description"},
 		}); err == nil {
 			err = profiles.SetProfile(profile)
 			require.NoError(t, err)
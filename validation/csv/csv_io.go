@@ -2,19 +2,47 @@
 package csv
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"os"
+	"strings"
 
 	"go.uber.org/zap"
 )
 
-// ReadUpload reads the CSV file from the supplied FileHeader and returns a string matrix.
-func ReadUpload(fileHeader *multipart.FileHeader, logger *zap.Logger) ([][]string, error) {
+// Source describes how a file's data was read, so that it can be recorded in the file's validation report.
+type Source struct {
+	Format   string   `json:"format"`             // The format the file was read from: csv, xlsx, or ods
+	Encoding string   `json:"encoding,omitempty"` // The character encoding a CSV file was transcoded to UTF-8 from
+	Sheet    string   `json:"sheet,omitempty"`    // The sheet a workbook's data was read from
+	Dialect  *Dialect `json:"dialect,omitempty"`  // The dialect a CSV file was read in
+}
+
+// ReadOptions are the options that configure how a file's data is read.
+type ReadOptions struct {
+	Sheet    string   // The workbook sheet to read, by name or number (default: the first sheet that isn't hidden)
+	Dialect  *Dialect // The CSV dialect to read, where it's set (default: the dialect that's sniffed from the file)
+	Tolerant bool     // Whether to keep reading past a CSV file's parse problems, so they can all be reported at once
+
+	// Whether short and long rows are left for a StructureCheck to report when a CSV file is read tolerantly
+	StructureChecked bool
+}
+
+// ReadUpload reads the CSV file, or spreadsheet workbook, from the supplied FileHeader and returns a string matrix,
+// along with how it was read.
+//
+// An error wrapping ErrEncoding is returned if a CSV file's character encoding can't be read, and one wrapping
+// ErrWorkbook is returned if a workbook, or the requested sheet in it, can't be read.
+func ReadUpload(fileHeader *multipart.FileHeader, options ReadOptions, logger *zap.Logger) ([][]string, *Source,
+	error) {
 	file, err := fileHeader.Open()
 	if err != nil {
 		logger.Error("Failed to open uploaded file", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to open file '%s': %w", fileHeader.Filename, err)
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -22,23 +50,18 @@ func ReadUpload(fileHeader *multipart.FileHeader, logger *zap.Logger) ([][]strin
 		}
 	}()
 
-	// Create a new CSV reader from the opened CSV file
-	reader := csv.NewReader(file)
-
-	// Read all records from the CSV reader
-	csvData, csvErr := reader.ReadAll()
-	if csvErr != nil || len(csvData) < 1 {
-		return nil, fmt.Errorf("failed to parse file '%s': %w", fileHeader.Filename, csvErr)
-	}
-
-	return csvData, nil
+	return read(file, fileHeader.Filename, options)
 }
 
-// ReadFile reads the CSV file at the supplied file path and returns a string matrix.
-func ReadFile(filePath string, logger *zap.Logger) ([][]string, error) {
+// ReadFile reads the CSV file, or spreadsheet workbook, at the supplied file path and returns a string matrix, along
+// with how it was read.
+//
+// An error wrapping ErrEncoding is returned if a CSV file's character encoding can't be read, and one wrapping
+// ErrWorkbook is returned if a workbook, or the requested sheet in it, can't be read.
+func ReadFile(filePath string, options ReadOptions, logger *zap.Logger) ([][]string, *Source, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+		return nil, nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
 	}
 	defer func() {
 		if err := file.Close(); err != nil {
@@ -46,16 +69,78 @@ func ReadFile(filePath string, logger *zap.Logger) ([][]string, error) {
 		}
 	}()
 
-	// Create a new CSV reader from the opened CSV file
-	reader := csv.NewReader(file)
+	return read(file, filePath, options)
+}
+
+// ReadMessage returns the reason a file couldn't be read, without the file's server path, if it's a reason the user
+// can do something about (i.e., its character encoding, the dialect it was read in, or, for a workbook, its sheet).
+func ReadMessage(err error) (string, bool) {
+	for _, readErr := range []error{ErrEncoding, ErrWorkbook, ErrDialect} {
+		if !errors.Is(err, readErr) {
+			continue
+		}
+
+		if _, reason, found := strings.Cut(err.Error(), readErr.Error()+": "); found {
+			return reason, true
+		}
+
+		return readErr.Error(), true
+	}
+
+	return "", false
+}
+
+// read parses the data from the supplied reader into a string matrix.
+//
+// Workbooks are recognized by their content, rather than their file extension, so they can be read even when they've
+// been uploaded as CSV files. CSV data is transcoded to UTF-8 before it's parsed in its dialect.
+func read(reader io.Reader, name string, options ReadOptions) ([][]string, *Source, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file '%s': %w", name, err)
+	}
+
+	switch {
+	case bytes.HasPrefix(data, zipSignature):
+		csvData, source, err := readWorkbook(data, options.Sheet)
+		if err == nil && len(csvData) < 1 {
+			err = fmt.Errorf("%w: sheet '%s' doesn't have any data", ErrWorkbook, source.Sheet)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse file '%s': %w", name, err)
+		}
+
+		return csvData, source, nil
+	case bytes.HasPrefix(data, xlsSignature):
+		return nil, nil, fmt.Errorf("failed to parse file '%s': %w: the file is a legacy Excel (.xls) workbook; please "+
+			"save it as an Excel Workbook (.xlsx) or as a CSV file", name, ErrWorkbook)
+	default:
+	}
+
+	decoded, encoding, err := Decode(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse file '%s': %w", name, err)
+	}
+
+	// A BOM that survived transcoding (e.g., one that was saved twice) would end up in the first header
+	decoded = bytes.TrimPrefix(decoded, []byte("\ufeff"))
+
+	// Read all records in the file's dialect
+	csvData, dialect, csvErr := readCSV(decoded, options)
+	source := &Source{Format: FormatCSV, Encoding: encoding, Dialect: dialect}
+
+	// A file with records that can't be parsed can still be reported on, so its error records how it was read
+	var parseErr *ParseError
+	if errors.As(csvErr, &parseErr) {
+		parseErr.Source = source
+	}
 
-	// Read all records from the CSV reader
-	csvData, csvErr := reader.ReadAll()
 	if csvErr != nil || len(csvData) < 1 {
-		return nil, fmt.Errorf("failed to parse file '%s': %w", filePath, csvErr)
+		return nil, nil, fmt.Errorf("failed to parse file '%s': %w", name, csvErr)
 	}
 
-	return csvData, nil
+	return csvData, source, nil
 }
 
 // WriteFile writes a supplied string matrix to a CSV file.
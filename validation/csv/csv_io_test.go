@@ -107,11 +107,15 @@ func TestReadFile(t *testing.T) {
 	}
 
 	// Call the ReadFile function
-	readData, readFileErr := ReadFile(tmpFile.Name(), logger)
+	readData, source, readFileErr := ReadFile(tmpFile.Name(), ReadOptions{}, logger)
 	if readFileErr != nil {
 		t.Fatalf("ReadFile failed: %v", readFileErr)
 	}
 
+	if source.Encoding != UTF8 {
+		t.Errorf("Expected the file to be read as %s, got %s", UTF8, source.Encoding)
+	}
+
 	// Check if the read data matches the expected data
 	if len(readData) != len(data) {
 		t.Fatalf("Expected %d rows, got %d", len(data), len(readData))
@@ -130,7 +134,7 @@ func TestReadFile(t *testing.T) {
 func TestReadFile_FileNotFound(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 
-	_, err := ReadFile("non_existent_file.csv", logger)
+	_, _, err := ReadFile("non_existent_file.csv", ReadOptions{}, logger)
 	if err == nil {
 		t.Fatal("Expected an error for a missing file, but got nil")
 	}
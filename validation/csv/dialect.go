@@ -0,0 +1,332 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// The quote characters a CSV file's fields can be quoted with
+const (
+	DoubleQuote = `"`
+	SingleQuote = `'`
+	NoQuote     = "none" // Quotes aren't special, so fields can't be quoted
+)
+
+// NoComment is the comment character of a dialect override that says a CSV file doesn't have any comment lines.
+const NoComment = "none"
+
+// The number of bytes and records that are looked at when a CSV file's dialect is sniffed
+const (
+	sniffBytes   = 64 << 10
+	sniffRecords = 50
+)
+
+// delimiters are the delimiters a CSV file's dialect can be sniffed as, in the order they're preferred.
+var delimiters = []string{",", "\t", ";", "|"}
+
+// delimiterNames are the names that delimiters can be supplied as, in a dialect override.
+var delimiterNames = map[string]string{"comma": ",", "tab": "\t", "semicolon": ";", "pipe": "|"}
+
+// quotedFieldRegexes find the fields that are quoted with each of the quote characters a dialect can be sniffed as.
+var quotedFieldRegexes = map[string]*regexp.Regexp{
+	DoubleQuote: regexp.MustCompile(`(?m)(^|[,\t;|]) *"[^"]*" *([,\t;|]|\r?$)`),
+	SingleQuote: regexp.MustCompile(`(?m)(^|[,\t;|]) *'[^']*' *([,\t;|]|\r?$)`),
+}
+
+// ErrDialect is returned when a dialect override can't be parsed or isn't one that CSV files can be read with.
+var ErrDialect = errors.New("invalid CSV dialect")
+
+// Dialect describes how a CSV file's records and fields are written.
+//
+// When a dialect is supplied to override the one that's sniffed from a file, its empty fields are still sniffed.
+type Dialect struct {
+	Delimiter  string `json:"delimiter,omitempty"`  // The character between fields (e.g., "," or "\t")
+	Quote      string `json:"quote,omitempty"`      // The character fields are quoted with: ", ', or none
+	Comment    string `json:"comment,omitempty"`    // The character that starts a comment line, if there is one
+	LazyQuotes *bool  `json:"lazyQuotes,omitempty"` // Whether quotes can appear in unquoted fields
+}
+
+// ParseDialect parses a dialect override, which is either a delimiter (e.g., "tab", "semicolon", or ";") or a JSON
+// object with any of a Dialect's fields (e.g., `{"delimiter": ";", "comment": "#"}`).
+func ParseDialect(text string) (*Dialect, error) {
+	dialect := &Dialect{}
+	text = strings.TrimSpace(text)
+
+	if strings.HasPrefix(text, "{") {
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(dialect); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDialect, err)
+		}
+	} else {
+		dialect.Delimiter = text
+	}
+
+	if delimiter, found := delimiterNames[strings.ToLower(dialect.Delimiter)]; found {
+		dialect.Delimiter = delimiter
+	}
+
+	if err := dialect.verify(); err != nil {
+		return nil, err
+	}
+
+	return dialect, nil
+}
+
+// verify checks that the dialect's characters are ones that CSV files can be read with.
+func (dialect *Dialect) verify() error {
+	if dialect.Delimiter != "" && !isDialectChar(dialect.Delimiter) {
+		return fmt.Errorf("%w: the delimiter must be a single character, other than a quote or line break",
+			ErrDialect)
+	}
+
+	if dialect.Quote != "" && dialect.Quote != DoubleQuote && dialect.Quote != SingleQuote && dialect.Quote != NoQuote {
+		return fmt.Errorf("%w: the quote must be %s, %s, or %s", ErrDialect, DoubleQuote, SingleQuote, NoQuote)
+	}
+
+	if dialect.Comment != "" && dialect.Comment != NoComment && !isDialectChar(dialect.Comment) {
+		return fmt.Errorf("%w: the comment must be a single character, other than a quote or line break, or %s",
+			ErrDialect, NoComment)
+	}
+
+	if dialect.Comment != "" && dialect.Comment == dialect.Delimiter {
+		return fmt.Errorf("%w: the comment and delimiter characters must be different", ErrDialect)
+	}
+
+	return nil
+}
+
+// String returns a description of the dialect, like "tab-separated values, with # comments".
+func (dialect Dialect) String() string {
+	name := fmt.Sprintf("%q", dialect.Delimiter)
+	for delimiterName, delimiter := range delimiterNames {
+		if delimiter == dialect.Delimiter {
+			name = delimiterName
+		}
+	}
+
+	details := []string{name + "-separated values"}
+
+	switch dialect.Quote {
+	case SingleQuote:
+		details = append(details, "with ' quotes")
+	case NoQuote:
+		details = append(details, "without quotes")
+	default:
+	}
+
+	if dialect.Comment != "" {
+		details = append(details, fmt.Sprintf("with %s comments", dialect.Comment))
+	}
+
+	if dialect.LazyQuotes != nil && *dialect.LazyQuotes {
+		details = append(details, "with lazy quotes")
+	}
+
+	return strings.Join(details, ", ")
+}
+
+// IsDefault returns whether the dialect is the one CSV files are written in by default.
+func (dialect Dialect) IsDefault() bool {
+	return dialect.Delimiter == "," && dialect.Quote == DoubleQuote && dialect.Comment == "" &&
+		(dialect.LazyQuotes == nil || !*dialect.LazyQuotes)
+}
+
+// readCSV parses CSV data with the supplied options' dialect override, sniffing whatever the override doesn't set.
+//
+// Lazy quotes are never sniffed, since a quote in an unquoted field is as likely to be a mistake as it is to be meant,
+// and lazily parsing a misquoted field can hide the records that follow it. They're only used if the override asks for
+// them. Records that can't be parsed are returned as a ParseError.
+func readCSV(data []byte, options ReadOptions) ([][]string, *Dialect, error) {
+	dialect := sniffDialect(data, options.Dialect)
+
+	csvData, problems := parseCSV(data, dialect, options)
+
+	if len(problems) > 0 {
+		return nil, &dialect, newParseError(problems, csvData)
+	}
+
+	return csvData, &dialect, nil
+}
+
+// sniffDialect returns the dialect of the supplied CSV data, using the override's fields where they're set.
+func sniffDialect(data []byte, override *Dialect) Dialect {
+	lazyQuotes := false
+	dialect := Dialect{LazyQuotes: &lazyQuotes}
+
+	if override != nil {
+		dialect.Delimiter, dialect.Quote, dialect.Comment = override.Delimiter, override.Quote, override.Comment
+		if override.LazyQuotes != nil {
+			dialect.LazyQuotes = override.LazyQuotes
+		}
+	}
+
+	// Only whole lines at the start of the data are looked at
+	sample := data[:min(len(data), sniffBytes)]
+	if index := bytes.LastIndexByte(sample, '\n'); len(sample) < len(data) && index >= 0 {
+		sample = sample[:index+1]
+	}
+
+	if dialect.Comment == "" && dialect.Delimiter != "#" {
+		dialect.Comment = sniffComment(sample)
+	} else if dialect.Comment == NoComment {
+		dialect.Comment = ""
+	}
+
+	if dialect.Quote == "" {
+		dialect.Quote = DoubleQuote
+
+		// Single quotes are only used if there are more fields quoted with them than with double quotes
+		singleQuoted := len(quotedFieldRegexes[SingleQuote].FindAllIndex(sample, -1))
+		if singleQuoted > 0 && singleQuoted > len(quotedFieldRegexes[DoubleQuote].FindAllIndex(sample, -1)) {
+			dialect.Quote = SingleQuote
+		}
+	}
+
+	if dialect.Delimiter == "" {
+		dialect.Delimiter = sniffDelimiter(sample, dialect)
+	}
+
+	return dialect
+}
+
+// sniffComment returns "#" if the data starts with a line that looks like a comment and not every line does.
+func sniffComment(sample []byte) string {
+	if !bytes.HasPrefix(sample, []byte("#")) {
+		return ""
+	}
+
+	for line := range bytes.Lines(sample) {
+		if !bytes.HasPrefix(line, []byte("#")) && len(bytes.TrimSpace(line)) > 0 {
+			return "#"
+		}
+	}
+
+	return ""
+}
+
+// sniffDelimiter returns the delimiter that splits the sample's records into the most consistent number of fields.
+//
+// Delimiters that split records into the same number of fields as often are compared by that number, so a delimiter
+// that's only found in some of a file's values loses out to the one that's between its fields.
+func sniffDelimiter(sample []byte, dialect Dialect) string {
+	best, bestConsistency, bestFields := delimiters[0], 0.0, 1
+
+	for _, delimiter := range delimiters {
+		if delimiter == dialect.Comment {
+			continue
+		}
+
+		lazyQuotes := true
+		candidate := Dialect{Delimiter: delimiter, Quote: dialect.Quote, Comment: dialect.Comment,
+			LazyQuotes: &lazyQuotes}
+
+		swapped, restore := swapQuotes(sample, candidate)
+		reader := newReader(swapped, candidate)
+
+		counts := map[int]int{}
+		records := 0
+
+		for records < sniffRecords {
+			record, err := reader.Read()
+			if err != nil {
+				break
+			}
+
+			counts[len(restore(record))]++
+			records++
+		}
+
+		if records == 0 {
+			continue
+		}
+
+		// The number of fields that most records have, and how many records have it
+		fields, matches := 0, 0
+		for count, total := range counts {
+			if total > matches || total == matches && count > fields {
+				fields, matches = count, total
+			}
+		}
+
+		consistency := float64(matches) / float64(records)
+		if fields > 1 && (consistency > bestConsistency || consistency == bestConsistency && fields > bestFields) {
+			best, bestConsistency, bestFields = delimiter, consistency, fields
+		}
+	}
+
+	return best
+}
+
+// swapQuotes returns the data with its quote characters swapped for double quotes, along with a function that restores
+// the values of the records that are read from it.
+//
+// The standard CSV reader only quotes fields with double quotes, so other quote characters are swapped with them before
+// the data is read and swapped back in the values that are read.
+func swapQuotes(data []byte, dialect Dialect) ([]byte, func([]string) []string) {
+	swap := '"'
+	switch dialect.Quote {
+	case SingleQuote:
+		swap = '\''
+	case NoQuote:
+		// A character that isn't in the data stands in for the double quotes, so none of them start a quoted field
+		swap = '\uE000'
+		for bytes.ContainsRune(data, swap) {
+			swap++
+		}
+	default:
+	}
+
+	if swap == '"' {
+		return data, func(record []string) []string { return record }
+	}
+
+	swapper := func(char rune) rune {
+		switch char {
+		case '"':
+			return swap
+		case swap:
+			return '"'
+		default:
+			return char
+		}
+	}
+
+	return bytes.Map(swapper, data), func(record []string) []string {
+		for index, value := range record {
+			record[index] = strings.Map(swapper, value)
+		}
+
+		return record
+	}
+}
+
+// newReader returns a CSV reader, for data in the supplied dialect that's had its quotes swapped, that reads records
+// with any number of fields.
+func newReader(data []byte, dialect Dialect) *csv.Reader {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma, _ = utf8.DecodeRuneInString(dialect.Delimiter)
+	reader.LazyQuotes = dialect.LazyQuotes != nil && *dialect.LazyQuotes
+	reader.FieldsPerRecord = -1
+
+	if dialect.Comment != "" {
+		reader.Comment, _ = utf8.DecodeRuneInString(dialect.Comment)
+	}
+
+	return reader
+}
+
+// isDialectChar returns whether the text is a single character that can be a delimiter or comment character.
+func isDialectChar(text string) bool {
+	char, size := utf8.DecodeRuneInString(text)
+
+	return size == len(text) && char != utf8.RuneError && !slices.Contains([]rune{'"', '\'', '\r', '\n'}, char)
+}
@@ -0,0 +1,155 @@
+//go:build unit
+
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestReadCSV tests sniffing the dialects of CSV data and reading the data in them.
+func TestReadCSV(t *testing.T) {
+	lazyQuotes := true
+
+	tests := []struct {
+		name     string
+		data     string
+		override *Dialect
+		expected [][]string
+		dialect  string
+	}{
+		{name: "Comma-separated", data: "Title,Creator\n\"Café, the\",José\n",
+			expected: [][]string{{"Title", "Creator"}, {"Café, the", "José"}}, dialect: "comma-separated values"},
+		{name: "Tab-separated", data: "Title\tCreator\nA, B\tJosé\n",
+			expected: [][]string{{"Title", "Creator"}, {"A, B", "José"}}, dialect: "tab-separated values"},
+		{name: "Semicolon-separated", data: "Title;Creator;Date\n1,5;José;2001\nA;B;C\n",
+			expected: [][]string{{"Title", "Creator", "Date"}, {"1,5", "José", "2001"}, {"A", "B", "C"}},
+			dialect:  "semicolon-separated values"},
+		{name: "Pipe-separated", data: "Title|Creator\nA|B\n",
+			expected: [][]string{{"Title", "Creator"}, {"A", "B"}}, dialect: "pipe-separated values"},
+		{name: "Single quotes", data: "'Title','Creator'\n'A, B','José \"Pepe\"'\n",
+			expected: [][]string{{"Title", "Creator"}, {"A, B", "José \"Pepe\""}},
+			dialect:  "comma-separated values, with ' quotes"},
+		{name: "Comment lines", data: "# Exported 2024-01-01\nTitle,Creator\nA,B\n",
+			expected: [][]string{{"Title", "Creator"}, {"A", "B"}}, dialect: "comma-separated values, with # comments"},
+		{name: "Lazy quotes override", data: "Title,Creator\nThe 12\" record,B\n",
+			override: &Dialect{LazyQuotes: &lazyQuotes}, expected: [][]string{{"Title", "Creator"},
+				{"The 12\" record", "B"}}, dialect: "comma-separated values, with lazy quotes"},
+		{name: "Single column", data: "Title\nA\n", expected: [][]string{{"Title"}, {"A"}},
+			dialect: "comma-separated values"},
+		{name: "Delimiter override", data: "Title;Creator,Date\nA;B,2001\n", override: &Dialect{Delimiter: ","},
+			expected: [][]string{{"Title;Creator", "Date"}, {"A;B", "2001"}}, dialect: "comma-separated values"},
+		{name: "Comment override", data: "#Title,Creator\nA,B\n", override: &Dialect{Comment: NoComment},
+			expected: [][]string{{"#Title", "Creator"}, {"A", "B"}}, dialect: "comma-separated values"},
+		{name: "No quotes override", data: "Title,Creator\n\"A,B\n", override: &Dialect{Quote: NoQuote},
+			expected: [][]string{{"Title", "Creator"}, {"\"A", "B"}}, dialect: "comma-separated values, without quotes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvData, dialect, err := readCSV([]byte(tt.data), ReadOptions{Dialect: tt.override})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, csvData)
+			assert.Equal(t, tt.dialect, dialect.String())
+		})
+	}
+}
+
+// TestReadCSV_BareQuotes tests that quotes in unquoted fields are reported, unless an override allows them.
+func TestReadCSV_BareQuotes(t *testing.T) {
+	data := []byte("A,B,C\n1,x\"y,3\n")
+	expected := Warning{
+		Message: "Error: the record can't be parsed because a quote was found in a field that isn't quoted " +
+			"(line 2, column 4)",
+		Header:   "B",
+		ColIndex: 1,
+		RowIndex: 1,
+		Value:    "1,x\"y,3",
+		Line:     2,
+	}
+
+	// Without an override, the quote is a parse problem
+	_, _, err := readCSV(data, ReadOptions{})
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, []Warning{expected}, parseErr.Warnings)
+
+	// When the data is read tolerantly, it's still reported, at the field it was found in
+	_, _, err = readCSV(data, ReadOptions{Tolerant: true})
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, []Warning{expected}, parseErr.Warnings)
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"1", "x\"y", "3"}}, parseErr.Data)
+
+	// An override that says quotes aren't allowed in unquoted fields doesn't change that
+	lazyQuotes := false
+	_, _, err = readCSV(data, ReadOptions{Dialect: &Dialect{LazyQuotes: &lazyQuotes}})
+	assert.ErrorAs(t, err, &parseErr)
+
+	// And one that says they're allowed reads the data without any problems
+	lazyQuotes = true
+	csvData, dialect, err := readCSV(data, ReadOptions{Dialect: &Dialect{LazyQuotes: &lazyQuotes}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"1", "x\"y", "3"}}, csvData)
+	assert.Equal(t, "comma-separated values, with lazy quotes", dialect.String())
+}
+
+// TestParseDialect tests parsing dialect overrides from delimiters and JSON objects.
+func TestParseDialect(t *testing.T) {
+	lazyQuotes := true
+
+	tests := []struct {
+		name        string
+		text        string
+		expected    *Dialect
+		expectedErr string
+	}{
+		{name: "Delimiter name", text: "Tab", expected: &Dialect{Delimiter: "\t"}},
+		{name: "Delimiter character", text: ";", expected: &Dialect{Delimiter: ";"}},
+		{name: "JSON object", text: `{"delimiter": "pipe", "quote": "'", "comment": "#", "lazyQuotes": true}`,
+			expected: &Dialect{Delimiter: "|", Quote: SingleQuote, Comment: "#", LazyQuotes: &lazyQuotes}},
+		{name: "Long delimiter", text: "::", expectedErr: "the delimiter must be a single character"},
+		{name: "Quote delimiter", text: `"`, expectedErr: "the delimiter must be a single character"},
+		{name: "Unknown quote", text: `{"quote": "*"}`, expectedErr: "the quote must be"},
+		{name: "Same comment and delimiter", text: `{"delimiter": ";", "comment": ";"}`,
+			expectedErr: "the comment and delimiter characters must be different"},
+		{name: "Unknown field", text: `{"separator": ";"}`, expectedErr: "unknown field"},
+		{name: "Invalid JSON", text: `{"delimiter": `, expectedErr: "unexpected EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialect, err := ParseDialect(tt.text)
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, ErrDialect)
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dialect)
+		})
+	}
+}
+
+// TestReadFile_Dialect tests that files are read in their dialects and their dialects are recorded.
+func TestReadFile_Dialect(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.tsv")
+	require.NoError(t, os.WriteFile(path, []byte("Title\tCreator\r\nCafé\tJosé\r\n"), 0o600))
+
+	csvData, source, err := ReadFile(path, ReadOptions{}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title", "Creator"}, {"Café", "José"}}, csvData)
+	require.NotNil(t, source.Dialect)
+	assert.Equal(t, "\t", source.Dialect.Delimiter)
+	assert.False(t, source.Dialect.IsDefault())
+
+	csvData, source, err = ReadFile(path, ReadOptions{Dialect: &Dialect{Delimiter: ","}}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title\tCreator"}, {"Café\tJosé"}}, csvData)
+	assert.True(t, source.Dialect.IsDefault())
+}
@@ -0,0 +1,166 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+// The character encodings that CSV files can be read from
+const (
+	UTF8        = "UTF-8"
+	UTF8BOM     = "UTF-8 with BOM"
+	UTF16LE     = "UTF-16LE"
+	UTF16BE     = "UTF-16BE"
+	UTF32LE     = "UTF-32LE"
+	UTF32BE     = "UTF-32BE"
+	Windows1252 = "Windows-1252"
+)
+
+// ErrEncoding is returned when a file's character encoding isn't one that can be read.
+var ErrEncoding = errors.New("unsupported character encoding")
+
+// sampleSize is the number of bytes that are looked at when guessing whether a file without a BOM is UTF-16.
+const sampleSize = 4096
+
+// byteOrderMarks are the BOMs that identify a file's encoding, with the longer ones that share a prefix listed first.
+var byteOrderMarks = []struct {
+	bom      []byte
+	name     string
+	encoding encoding.Encoding
+}{
+	{[]byte{0xFF, 0xFE, 0x00, 0x00}, UTF32LE, utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM)},
+	{[]byte{0x00, 0x00, 0xFE, 0xFF}, UTF32BE, utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM)},
+	{[]byte{0xEF, 0xBB, 0xBF}, UTF8BOM, nil},
+	{[]byte{0xFF, 0xFE}, UTF16LE, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)},
+	{[]byte{0xFE, 0xFF}, UTF16BE, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)},
+}
+
+// Decode detects the character encoding of the supplied data and returns it transcoded to UTF-8, along with the name
+// of the encoding it was read from.
+//
+// Files with a BOM are read in the encoding it identifies, and the BOM is removed. Files without one are read as UTF-8
+// if they're valid UTF-8, as UTF-16 if their NUL bytes follow its pattern, and otherwise as Windows-1252 (what Excel
+// uses for "CSV" exports on Windows). An error wrapping ErrEncoding is returned if the data can't be any of these.
+func Decode(data []byte) ([]byte, string, error) {
+	for _, mark := range byteOrderMarks {
+		if !bytes.HasPrefix(data, mark.bom) {
+			continue
+		}
+
+		body := data[len(mark.bom):]
+		if mark.encoding == nil {
+			if !utf8.Valid(body) {
+				return nil, "", invalidUTF8(body)
+			}
+
+			return body, mark.name, nil
+		}
+
+		decoded, err := mark.encoding.NewDecoder().Bytes(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: the file has a %s byte order mark but couldn't be read as %s: %w",
+				ErrEncoding, mark.name, mark.name, err)
+		}
+
+		return decoded, mark.name, nil
+	}
+
+	if name, found := guessUTF16(data); found {
+		order := unicode.LittleEndian
+		if name == UTF16BE {
+			order = unicode.BigEndian
+		}
+
+		decoded, err := unicode.UTF16(order, unicode.IgnoreBOM).NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: the file looks like %s but couldn't be read as it: %w", ErrEncoding,
+				name, err)
+		}
+
+		return decoded, name, nil
+	}
+
+	if index := bytes.IndexByte(data, 0); index >= 0 {
+		return nil, "", fmt.Errorf("%w: the file has a NUL byte on line %d, so it looks like a binary file rather "+
+			"than a CSV file", ErrEncoding, lineNumber(data, index))
+	}
+
+	if utf8.Valid(data) {
+		return data, UTF8, nil
+	}
+
+	// Windows-1252 leaves a few bytes undefined; finding one means the file is in some other encoding
+	for index, char := range data {
+		if char == 0x81 || char == 0x8D || char == 0x8F || char == 0x90 || char == 0x9D {
+			return nil, "", fmt.Errorf("%w: the file isn't UTF-8, and byte 0x%02X on line %d isn't a Windows-1252 "+
+				"character either; please save the file as UTF-8", ErrEncoding, char, lineNumber(data, index))
+		}
+	}
+
+	decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: the file couldn't be read as Windows-1252: %w", ErrEncoding, err)
+	}
+
+	return decoded, Windows1252, nil
+}
+
+// guessUTF16 returns whether the supplied data, which doesn't have a BOM, looks like UTF-16 and, if so, its byte order.
+//
+// Mostly ASCII text in UTF-16 has a NUL in nearly every other byte, so that's what's looked for.
+func guessUTF16(data []byte) (string, bool) {
+	sample := data[:min(len(data), sampleSize)]
+	pairs := len(sample) / 2
+
+	if pairs == 0 {
+		return "", false
+	}
+
+	evenNULs, oddNULs := 0, 0
+	for index := 0; index+1 < len(sample); index += 2 {
+		if sample[index] == 0 {
+			evenNULs++
+		}
+
+		if sample[index+1] == 0 {
+			oddNULs++
+		}
+	}
+
+	switch {
+	case oddNULs > pairs/2 && evenNULs <= pairs/10:
+		return UTF16LE, true
+	case evenNULs > pairs/2 && oddNULs <= pairs/10:
+		return UTF16BE, true
+	default:
+		return "", false
+	}
+}
+
+// invalidUTF8 returns an error that locates the first invalid UTF-8 in data that claims to be UTF-8.
+func invalidUTF8(data []byte) error {
+	index := 0
+	for index < len(data) {
+		char, size := utf8.DecodeRune(data[index:])
+		if char == utf8.RuneError && size == 1 {
+			break
+		}
+
+		index += size
+	}
+
+	return fmt.Errorf("%w: the file has a UTF-8 byte order mark, but byte 0x%02X on line %d isn't valid UTF-8",
+		ErrEncoding, data[index], lineNumber(data, index))
+}
+
+// lineNumber returns the 1-based line of the supplied byte index.
+func lineNumber(data []byte, index int) int {
+	return bytes.Count(data[:index], []byte{'\n'}) + 1
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestDecode tests detecting the character encodings of CSV data and transcoding it to UTF-8.
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+		encoding string
+	}{
+		{name: "UTF-8", data: []byte("Title\nJosé\n"), expected: "Title\nJosé\n", encoding: UTF8},
+		{name: "UTF-8 with BOM", data: []byte("\ufeffTitle\nJosé\n"), expected: "Title\nJosé\n", encoding: UTF8BOM},
+		{name: "Windows-1252", data: []byte("Title\nJos\xe9 \x93quoted\x94\n"), expected: "Title\nJosé “quoted”\n",
+			encoding: Windows1252},
+		{name: "UTF-16LE with BOM", data: []byte("\xff\xfeT\x00i\x00\xe9\x00\n\x00"), expected: "Tié\n",
+			encoding: UTF16LE},
+		{name: "UTF-16BE with BOM", data: []byte("\xfe\xff\x00T\x00i\x00\xe9\x00\n"), expected: "Tié\n",
+			encoding: UTF16BE},
+		{name: "UTF-16LE without BOM", data: []byte("T\x00i\x00t\x00l\x00e\x00\n\x00"), expected: "Title\n",
+			encoding: UTF16LE},
+		{name: "UTF-32LE with BOM", data: []byte("\xff\xfe\x00\x00T\x00\x00\x00\xe9\x00\x00\x00"), expected: "Té",
+			encoding: UTF32LE},
+		{name: "Empty", data: []byte{}, expected: "", encoding: UTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, encoding, err := Decode(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(decoded))
+			assert.Equal(t, tt.encoding, encoding)
+		})
+	}
+}
+
+// TestDecode_Errors tests that data in encodings that can't be read is rejected with a precise message.
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		expectedErr string
+	}{
+		{name: "Undefined Windows-1252 byte", data: []byte("Title\nA title\nJos\x81\n"),
+			expectedErr: "byte 0x81 on line 3 isn't a Windows-1252 character"},
+		{name: "Invalid UTF-8 after a BOM", data: []byte("\ufeffTitle\nJos\xe9\n"),
+			expectedErr: "byte 0xE9 on line 2 isn't valid UTF-8"},
+		{name: "Binary file", data: []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00\x00\x00\x00\x00\x00\x00\x00\x00"),
+			expectedErr: "looks like a binary file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Decode(tt.data)
+			assert.ErrorIs(t, err, ErrEncoding)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// TestReadFile_Encoding tests that files are transcoded to UTF-8 and their encoding is recorded.
+func TestReadFile_Encoding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "windows.csv")
+	require.NoError(t, os.WriteFile(path, []byte("\"Title\",Creator\r\nCaf\xe9,\"Jos\xe9\"\r\n"), 0o600))
+
+	csvData, source, err := ReadFile(path, ReadOptions{}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title", "Creator"}, {"Café", "José"}}, csvData)
+	assert.Equal(t, Windows1252, source.Encoding)
+
+	// A BOM before a quoted header doesn't end up in the header or break its quoting
+	require.NoError(t, os.WriteFile(path, []byte("\ufeff\"Title\"\nCafé\n"), 0o600))
+
+	csvData, source, err = ReadFile(path, ReadOptions{}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title"}, {"Café"}}, csvData)
+	assert.Equal(t, UTF8BOM, source.Encoding)
+}
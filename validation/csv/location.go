@@ -45,6 +45,11 @@ func GetHeader(location Location, csvData [][]string, profile string) (string, e
 		return "", NewError("the first row of csvData is empty", location, profile)
 	}
 
+	// The extra cells of a row that's longer than the header row don't have a header
+	if index >= len(headers) {
+		return "", nil
+	}
+
 	return headers[index], nil
 }
 
@@ -71,5 +76,10 @@ func GetRowValue(header string, location Location, csvData [][]string, profile s
 		return "", NewError(fmt.Sprintf("conditional field '%s' was not found", header), location, profile, err)
 	}
 
+	// A row that's shorter than the header row doesn't have a value for the headers it's missing
+	if colIndex >= len(csvData[location.RowIndex]) {
+		return "", nil
+	}
+
 	return csvData[location.RowIndex][colIndex], nil
 }
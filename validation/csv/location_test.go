@@ -86,6 +86,17 @@ func TestGetHeader(t *testing.T) {
 			expected:    "",
 			expectError: true,
 		},
+		{
+			name:     "Extra cell of a long row",
+			location: Location{RowIndex: 1, ColIndex: 3},
+			csvData: [][]string{
+				{"ID", "Name", "Age"},
+				{"1", "Alice", "30", "extra"},
+			},
+			profile:     "DLP Staff",
+			expected:    "",
+			expectError: false,
+		},
 		{
 			name:        "Empty first row",
 			location:    Location{ColIndex: 0},
@@ -117,3 +128,24 @@ func TestGetHeader(t *testing.T) {
 		})
 	}
 }
+
+// TestGetRowValue verifies that GetRowValue returns the value of a header in the row being checked
+func TestGetRowValue(t *testing.T) {
+	csvData := [][]string{
+		{"ID", "Name", "Age"},
+		{"1", "Alice", "30"},
+		{"2"},
+	}
+
+	value, err := GetRowValue("Name", Location{RowIndex: 1}, csvData, "DLP Staff")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", value)
+
+	// A row that's shorter than the header row doesn't have values for its missing cells
+	value, err = GetRowValue("Age", Location{RowIndex: 2}, csvData, "DLP Staff")
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	_, err = GetRowValue("Email", Location{RowIndex: 1}, csvData, "DLP Staff")
+	assert.Error(t, err)
+}
@@ -0,0 +1,332 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// odsMaxRepeat is the most times an ODS row or cell with a value can be repeated; empty rows and cells are often
+// repeated to the end of the sheet, but they're only kept if there's a value after them.
+const odsMaxRepeat = 1048576
+
+// odsCell is an ODS table cell's value and the number of times it's repeated.
+type odsCell struct {
+	value  string
+	repeat int
+}
+
+// readODS reads the data in the requested sheet of an OpenDocument spreadsheet.
+//
+// The spreadsheet's content is read as a stream, so only the requested sheet is kept in memory.
+func readODS(archive *zip.Reader, sheetName string) ([][]string, *Source, error) {
+	content, err := readPart(archive, "content.xml")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: content.xml can't be read: %w", ErrWorkbook, err)
+	}
+
+	sheets, err := odsSheets(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index, err := selectSheet(sheets, sheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	tableIndex := -1
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: content.xml can't be parsed: %w", ErrWorkbook, err)
+		}
+
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "table" {
+			if tableIndex++; tableIndex != index {
+				if err = decoder.Skip(); err != nil {
+					return nil, nil, fmt.Errorf("%w: content.xml can't be parsed: %w", ErrWorkbook, err)
+				}
+
+				continue
+			}
+
+			rows, err := readODSTable(decoder)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: sheet '%s' can't be parsed: %w", ErrWorkbook, sheets[index].name, err)
+			}
+
+			return sheetMatrix(rows), &Source{Format: FormatODS, Sheet: sheets[index].name}, nil
+		}
+	}
+}
+
+// odsSheets lists the sheets (i.e., the top-level tables) in a spreadsheet's content.
+func odsSheets(content []byte) ([]sheet, error) {
+	var sheets []sheet
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return sheets, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: content.xml can't be parsed: %w", ErrWorkbook, err)
+		}
+
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "table" {
+			sheets = append(sheets, sheet{name: odsAttr(element, "name")})
+
+			if err = decoder.Skip(); err != nil {
+				return nil, fmt.Errorf("%w: content.xml can't be parsed: %w", ErrWorkbook, err)
+			}
+		}
+	}
+}
+
+// readODSTable reads the rows of the table whose start element was just read.
+//
+// Rows can be nested in header rows and row groups, which are read as if they weren't there. Empty rows are only
+// kept if there's a row with a value after them.
+func readODSTable(decoder *xml.Decoder) ([][]string, error) {
+	var rows [][]string
+
+	pending := 0 // The number of empty rows that haven't been kept yet
+	width := 0   // The number of columns the table will be read into
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "table-row":
+				repeat := odsRepeat(element, "number-rows-repeated")
+
+				row, err := readODSRow(decoder)
+				if err != nil {
+					return nil, err
+				}
+
+				if len(row) == 0 {
+					pending += repeat
+					continue
+				}
+
+				width = max(width, len(row))
+				height := len(rows) + min(pending, odsMaxRepeat) + min(repeat, odsMaxRepeat)
+				if err = checkSheetSize(height, width); err != nil {
+					return nil, err
+				}
+
+				for range min(pending, odsMaxRepeat) {
+					rows = append(rows, nil)
+				}
+
+				for range min(repeat, odsMaxRepeat) {
+					rows = append(rows, row)
+				}
+
+				pending = 0
+			case "table-header-rows", "table-row-group", "table-rows":
+				// The rows in these are read as a part of the table
+			default:
+				if err = decoder.Skip(); err != nil {
+					return nil, err
+				}
+			}
+		case xml.EndElement:
+			if element.Name.Local == "table" {
+				return rows, nil
+			}
+		default:
+		}
+	}
+}
+
+// readODSRow reads the cells of the row whose start element was just read.
+//
+// Empty cells are only kept if there's a cell with a value after them, so a row without any values is empty.
+func readODSRow(decoder *xml.Decoder) ([]string, error) {
+	var row []string
+
+	pending := 0 // The number of empty cells that haven't been kept yet
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			cell, err := readODSCell(decoder, element)
+			if err != nil {
+				return nil, err
+			}
+
+			if cell.value == "" {
+				pending += cell.repeat
+				continue
+			}
+
+			if err = checkSheetSize(1, len(row)+min(pending, odsMaxRepeat)+min(cell.repeat, odsMaxRepeat)); err != nil {
+				return nil, err
+			}
+
+			for range min(pending, odsMaxRepeat) {
+				row = append(row, "")
+			}
+
+			for range min(cell.repeat, odsMaxRepeat) {
+				row = append(row, cell.value)
+			}
+
+			pending = 0
+		case xml.EndElement:
+			return row, nil
+		default:
+		}
+	}
+}
+
+// readODSCell reads the cell whose start element was just read.
+//
+// Covered cells (i.e., those hidden by merged cells) are empty. Dates are read in their ISO 8601 form, numbers without
+// their formatting, and everything else as the cell's text, with its paragraphs on separate lines.
+func readODSCell(decoder *xml.Decoder, element xml.StartElement) (odsCell, error) {
+	cell := odsCell{repeat: odsRepeat(element, "number-columns-repeated")}
+
+	if element.Name.Local != "table-cell" {
+		return cell, decoder.Skip()
+	}
+
+	var paragraphs []string
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return cell, err
+		}
+
+		switch child := token.(type) {
+		case xml.StartElement:
+			if child.Name.Local != "p" && child.Name.Local != "h" {
+				// Comments and other things in a cell aren't a part of its value
+				if err = decoder.Skip(); err != nil {
+					return cell, err
+				}
+
+				continue
+			}
+
+			paragraph, err := readODSText(decoder)
+			if err != nil {
+				return cell, err
+			}
+
+			paragraphs = append(paragraphs, paragraph)
+		case xml.EndElement:
+			cell.value = odsValue(element, strings.Join(paragraphs, "\n"))
+			return cell, nil
+		default:
+		}
+	}
+}
+
+// readODSText reads the text of the element whose start element was just read, including that of any spans or links
+// in it.
+func readODSText(decoder *xml.Decoder) (string, error) {
+	var builder strings.Builder
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+
+		switch element := token.(type) {
+		case xml.CharData:
+			builder.Write(element)
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "s":
+				builder.WriteString(strings.Repeat(" ", odsRepeat(element, "c")))
+				err = decoder.Skip()
+			case "tab":
+				builder.WriteString("\t")
+				err = decoder.Skip()
+			case "line-break":
+				builder.WriteString("\n")
+				err = decoder.Skip()
+			case "note", "annotation", "ruby-text":
+				err = decoder.Skip()
+			default:
+				var text string
+
+				text, err = readODSText(decoder)
+				builder.WriteString(text)
+			}
+
+			if err != nil {
+				return "", err
+			}
+		case xml.EndElement:
+			return builder.String(), nil
+		default:
+		}
+	}
+}
+
+// odsValue returns a cell's value, as it would be written in a CSV file, based on its value type.
+func odsValue(element xml.StartElement, text string) string {
+	switch odsAttr(element, "value-type") {
+	case "float", "percentage", "currency":
+		if value := odsAttr(element, "value"); value != "" {
+			return formatNumber(value)
+		}
+	case "date":
+		if value := odsAttr(element, "date-value"); value != "" {
+			return strings.TrimSuffix(value, "T00:00:00")
+		}
+	case "boolean":
+		if value := odsAttr(element, "boolean-value"); value != "" {
+			return strings.ToUpper(value)
+		}
+	default:
+	}
+
+	return text
+}
+
+// odsAttr returns the value of an element's attribute, by its local name.
+func odsAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// odsRepeat returns the number in a repeat attribute, which is 1 if it's not set.
+func odsRepeat(element xml.StartElement, name string) int {
+	repeat, err := strconv.Atoi(odsAttr(element, name))
+	if err != nil || repeat < 1 {
+		return 1
+	}
+
+	return repeat
+}
@@ -0,0 +1,222 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// parseKinds describe the kinds of problems that keep a CSV record from being parsed, in the words of a warning.
+var parseKinds = map[error]string{
+	csv.ErrBareQuote: "a quote was found in a field that isn't quoted",
+	csv.ErrQuote:     "a quoted field has a stray quote in it, or is missing its closing quote",
+}
+
+// ParseError is returned when a CSV file has records that can't be parsed, or that don't have the same number of
+// fields as its header row. Each of these problems is described by a warning, with the line it was found on, so that
+// it can be reported just like a validation warning.
+//
+// When a file is read tolerantly, all of its problems are found and Data has its records, with short and long records
+// kept as they are and each record that couldn't be parsed read as well as it can be, so the file can still be
+// validated. Short and long records are only left out of the problems if a StructureCheck will report them. Otherwise,
+// only the file's first problem is found and Data is nil.
+type ParseError struct {
+	Warnings []Warning
+	Data     [][]string
+	Source   *Source // How the file was read
+}
+
+// Error returns a description of the file's first parse problem.
+func (err *ParseError) Error() string {
+	if len(err.Warnings) == 0 {
+		return "CSV data could not be parsed"
+	}
+
+	message := strings.TrimPrefix(err.Warnings[0].Message, "Error: ")
+	if len(err.Warnings) > 1 {
+		return fmt.Sprintf("%s (and %d more problems)", message, len(err.Warnings)-1)
+	}
+
+	return message
+}
+
+// newParseError returns a ParseError for the supplied problems and the records that could be parsed, if any.
+func newParseError(problems []Warning, csvData [][]string) *ParseError {
+	return &ParseError{Warnings: problems, Data: csvData}
+}
+
+// parseCSV parses CSV data, written in the supplied dialect, into a string matrix.
+//
+// Parsing stops at the first record that can't be parsed, or that doesn't have the same number of fields as the first
+// record, unless it's tolerant. Tolerant parsing keeps going, so all the data's problems are found at once, and keeps
+// whatever can be parsed: a record that can't be parsed is parsed again with lazy quotes (or left empty, if it still
+// can't be parsed) so the records after it keep their rows, and short and long records are kept as they are. They're
+// still reported, unless the options say a StructureCheck will report them.
+func parseCSV(data []byte, dialect Dialect, options ReadOptions) ([][]string, []Warning) {
+	tolerant := options.Tolerant
+	var csvData [][]string
+	var problems []Warning
+
+	swapped, restore := swapQuotes(data, dialect)
+	reader := newReader(swapped, dialect)
+	lines := &lineIndex{data: swapped}
+
+	for {
+		start := reader.InputOffset()
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		// The data is read from memory, so the only errors it can have are parse errors
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			problems = append(problems, quoteProblem(parseErr, csvData, lines, dialect, restore))
+			if !tolerant {
+				return nil, problems
+			}
+
+			record = reparse(swapped[start:reader.InputOffset()], dialect)
+		}
+
+		record = restore(record)
+
+		if len(csvData) > 0 && len(record) != len(csvData[0]) && parseErr == nil &&
+			(!tolerant || !options.StructureChecked) {
+			line, _ := reader.FieldPos(0)
+			problems = append(problems, fieldCountProblem(record, csvData, line))
+
+			if !tolerant {
+				return nil, problems
+			}
+		}
+
+		csvData = append(csvData, record)
+	}
+
+	return csvData, problems
+}
+
+// quoteProblem returns the problem for a record that couldn't be parsed because of a misplaced quote.
+//
+// The problem is located at the field the quote was found in, and its value is the text of the line it was found on,
+// since the field itself couldn't be parsed.
+func quoteProblem(parseErr *csv.ParseError, csvData [][]string, lines *lineIndex, dialect Dialect,
+	restore func([]string) []string) Warning {
+	start := lines.start(parseErr.StartLine)
+	lineStart := lines.start(parseErr.Line)
+	offset := min(lineStart+max(0, parseErr.Column-1), len(lines.data))
+
+	lineEnd := len(lines.data)
+	if index := bytes.IndexByte(lines.data[lineStart:], '\n'); index >= 0 {
+		lineEnd = lineStart + index
+	}
+
+	location := Location{RowIndex: len(csvData), ColIndex: fieldIndex(lines.data[start:offset], dialect)}
+	kind := parseKinds[parseErr.Err]
+	if kind == "" {
+		kind = parseErr.Err.Error()
+	}
+
+	return Warning{
+		Message: fmt.Sprintf("Error: the record can't be parsed because %s (line %d, column %d)", kind,
+			parseErr.Line, utf8.RuneCount(lines.data[lineStart:offset])+1),
+		Header:   headerAt(csvData, location.ColIndex),
+		ColIndex: location.ColIndex,
+		RowIndex: location.RowIndex,
+		Value:    restore([]string{strings.TrimSuffix(string(lines.data[lineStart:lineEnd]), "\r")})[0],
+		Line:     parseErr.Line,
+	}
+}
+
+// fieldCountProblem returns the problem for a record that doesn't have the same number of fields as the header.
+//
+// The problem is located at the header's first missing field, or at the record's first extra field.
+func fieldCountProblem(record []string, csvData [][]string, line int) Warning {
+	location := Location{RowIndex: len(csvData), ColIndex: min(len(record), len(csvData[0]))}
+
+	value := ""
+	if location.ColIndex < len(record) {
+		value = record[location.ColIndex]
+	}
+
+	return Warning{
+		Message: fmt.Sprintf("Error: the row has %d field(s), but the header row has %d (line %d)", len(record),
+			len(csvData[0]), line),
+		Header:   headerAt(csvData, location.ColIndex),
+		ColIndex: location.ColIndex,
+		RowIndex: location.RowIndex,
+		Value:    strings.ReplaceAll(value, "\n", "\\n"),
+		Line:     line,
+	}
+}
+
+// reparse parses a record that couldn't be parsed again, with lazy quotes, returning an empty record if it still can't
+// be parsed as a single record.
+func reparse(raw []byte, dialect Dialect) []string {
+	lazyQuotes := true
+	dialect.LazyQuotes = &lazyQuotes
+
+	records, err := newReader(raw, dialect).ReadAll()
+	if err != nil || len(records) != 1 {
+		return []string{}
+	}
+
+	return records[0]
+}
+
+// fieldIndex returns the index of the field that the end of a record's text is in.
+func fieldIndex(text []byte, dialect Dialect) int {
+	delimiter, _ := utf8.DecodeRuneInString(dialect.Delimiter)
+	index, quoted := 0, false
+
+	for _, char := range string(text) {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case char == delimiter && !quoted:
+			index++
+		default:
+		}
+	}
+
+	return index
+}
+
+// headerAt returns the header of the column with the supplied index, if the data has a header for it.
+func headerAt(csvData [][]string, colIndex int) string {
+	if len(csvData) == 0 || colIndex >= len(csvData[0]) {
+		return ""
+	}
+
+	return csvData[0][colIndex]
+}
+
+// lineIndex finds where the lines of CSV data start, which is only worked out if a problem is found in the data.
+type lineIndex struct {
+	data   []byte
+	starts []int
+}
+
+// start returns the offset of the supplied (1-based) line in the data, or the end of the data if there's no such line.
+func (lines *lineIndex) start(line int) int {
+	if lines.starts == nil {
+		lines.starts = []int{0}
+		for index, char := range lines.data {
+			if char == '\n' {
+				lines.starts = append(lines.starts, index+1)
+			}
+		}
+	}
+
+	if line < 1 || line > len(lines.starts) {
+		return len(lines.data)
+	}
+
+	return lines.starts[line-1]
+}
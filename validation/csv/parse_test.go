@@ -0,0 +1,121 @@
+//go:build unit
+
+package csv
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestReadCSV_ParseErrors tests that the records that can't be parsed are reported as warnings, located by their rows,
+// columns, and lines.
+func TestReadCSV_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		tolerant bool
+		checked  bool
+		warnings []Warning
+		expected [][]string
+	}{
+		{
+			name: "Stray quote",
+			data: "# Exported 2024-01-01\nTitle,Creator\nA,\"B \"C\" D\"\nE,F\n",
+			warnings: []Warning{{Message: "Error: the record can't be parsed because a quoted field has a stray " +
+				"quote in it, or is missing its closing quote (line 3, column 6)", Header: "Creator", ColIndex: 1,
+				RowIndex: 1, Value: "A,\"B \"C\" D\"", Line: 3}},
+		},
+		{
+			name: "Long row",
+			data: "Title,Creator\nA,B,C\nD\n",
+			warnings: []Warning{{Message: "Error: the row has 3 field(s), but the header row has 2 (line 2)",
+				ColIndex: 2, RowIndex: 1, Value: "C", Line: 2}},
+		},
+		{
+			name:     "Tolerant",
+			data:     "Title,Creator\nA,B,C\nD\n\"Café\" \"E\",F\nG,H\n",
+			tolerant: true,
+			warnings: []Warning{
+				{Message: "Error: the row has 3 field(s), but the header row has 2 (line 2)", ColIndex: 2,
+					RowIndex: 1, Value: "C", Line: 2},
+				{Message: "Error: the row has 1 field(s), but the header row has 2 (line 3)", Header: "Creator",
+					ColIndex: 1, RowIndex: 2, Line: 3},
+				{Message: "Error: the record can't be parsed because a quoted field has a stray quote in it, or is " +
+					"missing its closing quote (line 4, column 6)", Header: "Title", RowIndex: 3,
+					Value: "\"Café\" \"E\",F", Line: 4},
+			},
+			expected: [][]string{{"Title", "Creator"}, {"A", "B", "C"}, {"D"}, {"Café\" \"E", "F"}, {"G", "H"}},
+		},
+		{
+			name:     "Tolerant, with a StructureCheck",
+			data:     "Title,Creator\nA,B,C\nD\n\"Café\" \"E\",F\nG,H\n",
+			tolerant: true,
+			checked:  true,
+			warnings: []Warning{
+				{Message: "Error: the record can't be parsed because a quoted field has a stray quote in it, or is " +
+					"missing its closing quote (line 4, column 6)", Header: "Title", RowIndex: 3,
+					Value: "\"Café\" \"E\",F", Line: 4},
+			},
+			expected: [][]string{{"Title", "Creator"}, {"A", "B", "C"}, {"D"}, {"Café\" \"E", "F"}, {"G", "H"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvData, _, err := readCSV([]byte(tt.data), ReadOptions{Tolerant: tt.tolerant, StructureChecked: tt.checked})
+			assert.Nil(t, csvData)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, tt.warnings, parseErr.Warnings)
+			assert.Equal(t, tt.expected, parseErr.Data)
+		})
+	}
+}
+
+// TestReadFile_ParseError tests that a file with records that can't be parsed returns a ParseError that records how
+// the file was read.
+func TestReadFile_ParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ragged.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Title;Creator\nA;B;C\n\"D\" \"E\";F\nG\n"), 0o600))
+
+	_, _, err := ReadFile(path, ReadOptions{Tolerant: true}, zaptest.NewLogger(t))
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.EqualError(t, err, "failed to parse file '"+path+"': the row has 3 field(s), but the header row has 2 "+
+		"(line 2) (and 2 more problems)")
+	assert.Len(t, parseErr.Warnings, 3)
+	assert.Equal(t, [][]string{{"Title", "Creator"}, {"A", "B", "C"}, {"D\" \"E", "F"}, {"G"}}, parseErr.Data)
+	require.NotNil(t, parseErr.Source)
+	assert.Equal(t, ";", parseErr.Source.Dialect.Delimiter)
+}
+
+// TestReadFile_Ragged tests that a file with short and long rows can only be read tolerantly, which keeps its rows as
+// they are.
+func TestReadFile_Ragged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ragged.csv")
+	require.NoError(t, os.WriteFile(path, []byte("Title,Creator\nA,B,C\nD\n"), 0o600))
+
+	_, _, err := ReadFile(path, ReadOptions{}, zaptest.NewLogger(t))
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	assert.Len(t, parseErr.Warnings, 1)
+
+	// They're reported, unless a StructureCheck will report them
+	_, _, err = ReadFile(path, ReadOptions{Tolerant: true}, zaptest.NewLogger(t))
+	require.True(t, errors.As(err, &parseErr))
+	assert.Len(t, parseErr.Warnings, 2)
+	assert.Equal(t, [][]string{{"Title", "Creator"}, {"A", "B", "C"}, {"D"}}, parseErr.Data)
+
+	csvData, _, err := ReadFile(path, ReadOptions{Tolerant: true, StructureChecked: true}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"Title", "Creator"}, {"A", "B", "C"}, {"D"}}, csvData)
+}
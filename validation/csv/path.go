@@ -0,0 +1,128 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrPathEscape is returned when a path resolves to a location outside of the directory it must be in.
+var ErrPathEscape = errors.New("path is outside of its root directory")
+
+// maxLinks is the number of symbolic links that can be followed while resolving a path in a file system.
+const maxLinks = 40
+
+// ResolvePath resolves a relative path to a location inside the supplied root directory.
+//
+// It returns an error wrapping ErrPathEscape if the path is absolute, if it climbs out of the root directory, or if it
+// follows a symbolic link to a location outside of the root directory. Otherwise, it returns an error if the path
+// doesn't exist.
+func ResolvePath(root string, relPath string) (string, error) {
+	cleanPath := filepath.Clean(filepath.FromSlash(strings.TrimSpace(relPath)))
+	if !filepath.IsLocal(cleanPath) && cleanPath != "." {
+		return "", fmt.Errorf("%w: %s", ErrPathEscape, relPath)
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root directory: %w", err)
+	}
+
+	realPath, err := filepath.EvalSymlinks(filepath.Join(realRoot, cleanPath))
+	if err != nil {
+		return "", err
+	}
+
+	// A path inside the root can still link to a location outside of it
+	if rel, relErr := filepath.Rel(realRoot, realPath); relErr != nil || !filepath.IsLocal(rel) && rel != "." {
+		return "", fmt.Errorf("%w: %s", ErrPathEscape, relPath)
+	}
+
+	return realPath, nil
+}
+
+// FindCSVFiles finds the regular CSV files beneath the supplied directory, returning their sorted paths.
+func FindCSVFiles(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Symbolic links aren't followed, since they could lead outside of the directory
+		if entry.Type().IsRegular() && strings.EqualFold(filepath.Ext(path), ".csv") {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
+
+// ResolveFS resolves a slash-separated path to an existing location inside the supplied file system.
+//
+// Symbolic links are followed, if the file system implements fs.ReadLinkFS, and it returns an error wrapping
+// ErrPathEscape if the path, or a link in it, leads outside of the file system's root. Otherwise, it returns an error
+// if the path doesn't exist.
+func ResolveFS(fileSystem fs.FS, name string) (string, error) {
+	cleanName := path.Clean(filepath.ToSlash(strings.TrimSpace(name)))
+	if path.IsAbs(cleanName) || filepath.IsAbs(name) || escapes(cleanName) {
+		return "", fmt.Errorf("%w: %s", ErrPathEscape, name)
+	}
+
+	resolved := "."
+	pending := strings.Split(cleanName, "/")
+
+	for links := 0; len(pending) > 0; {
+		candidate := path.Join(resolved, pending[0])
+		pending = pending[1:]
+
+		info, err := fs.Lstat(fileSystem, candidate)
+		if err != nil {
+			return "", err
+		}
+
+		if info.Mode().Type() != fs.ModeSymlink {
+			resolved = candidate
+			continue
+		}
+
+		if links++; links > maxLinks {
+			return "", &fs.PathError{Op: "resolve", Path: name, Err: errors.New("too many links")}
+		}
+
+		target, err := fs.ReadLink(fileSystem, candidate)
+		if err != nil {
+			return "", err
+		}
+
+		// A link's target is relative to the directory that the link is in, and must stay inside the file system
+		if target = filepath.ToSlash(target); path.IsAbs(target) || filepath.IsAbs(target) {
+			return "", fmt.Errorf("%w: %s", ErrPathEscape, name)
+		}
+
+		if target = path.Join(resolved, target); escapes(target) {
+			return "", fmt.Errorf("%w: %s", ErrPathEscape, name)
+		}
+
+		resolved = "."
+		pending = append(strings.Split(target, "/"), pending...)
+	}
+
+	return resolved, nil
+}
+
+// escapes returns whether a clean, slash-separated path climbs out of the directory that it's relative to.
+func escapes(cleanPath string) bool {
+	return cleanPath == ".." || strings.HasPrefix(cleanPath, "../")
+}
@@ -0,0 +1,136 @@
+//go:build unit
+
+package csv
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolvePath checks that paths are resolved inside their root directory.
+func TestResolvePath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "project"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "project", "works.csv"), []byte("Title\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.csv"), []byte("Title\n"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.csv"), filepath.Join(root, "link.csv")))
+
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		escapes   bool
+		expectErr bool
+	}{
+		{name: "file", path: "project/works.csv", expected: "project/works.csv"},
+		{name: "directory", path: "project", expected: "project"},
+		{name: "root", path: "", expected: "."},
+		{name: "cleaned path", path: "project/../project/works.csv", expected: "project/works.csv"},
+		{name: "parent directory", path: "../secret.csv", escapes: true, expectErr: true},
+		{name: "absolute path", path: filepath.Join(outside, "secret.csv"), escapes: true, expectErr: true},
+		{name: "symbolic link", path: "link.csv", escapes: true, expectErr: true},
+		{name: "missing file", path: "missing.csv", expectErr: true},
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ResolvePath(root, tt.path)
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.escapes, errors.Is(err, ErrPathEscape))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, filepath.Join(realRoot, filepath.FromSlash(tt.expected)), path)
+			}
+		})
+	}
+}
+
+// TestFindCSVFiles checks that the CSV files in a directory tree are found.
+func TestFindCSVFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	for _, name := range []string{"b.csv", "a.CSV", "notes.txt", filepath.Join("nested", "c.csv")} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("Title\n"), 0o600))
+	}
+	require.NoError(t, os.Symlink(filepath.Join(dir, "b.csv"), filepath.Join(dir, "link.csv")))
+
+	files, err := FindCSVFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.CSV"),
+		filepath.Join(dir, "b.csv"),
+		filepath.Join(dir, "nested", "c.csv"),
+	}, files)
+
+	_, err = FindCSVFiles(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+// TestResolveFS checks that paths are resolved inside a file system, following its symbolic links.
+func TestResolveFS(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"project/works.csv": {Data: []byte("Title\n")},
+		"project/link.csv":  {Data: []byte("works.csv"), Mode: fs.ModeSymlink},
+		"current":           {Data: []byte("project"), Mode: fs.ModeSymlink},
+		"escape":            {Data: []byte("project/../.."), Mode: fs.ModeSymlink},
+		"loop":              {Data: []byte("loop"), Mode: fs.ModeSymlink},
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		expected  string
+		escapes   bool
+		expectErr bool
+	}{
+		{name: "file", path: "project/works.csv", expected: "project/works.csv"},
+		{name: "root", path: "", expected: "."},
+		{name: "link", path: "project/link.csv", expected: "project/works.csv"},
+		{name: "linked directory", path: "current/link.csv", expected: "project/works.csv"},
+		{name: "parent directory", path: "project/../../works.csv", escapes: true, expectErr: true},
+		{name: "absolute path", path: "/project/works.csv", escapes: true, expectErr: true},
+		{name: "link outside", path: "escape/works.csv", escapes: true, expectErr: true},
+		{name: "link loop", path: "loop", expectErr: true},
+		{name: "missing file", path: "current/missing.csv", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ResolveFS(fileSystem, tt.path)
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.escapes, errors.Is(err, ErrPathEscape))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, path)
+			}
+		})
+	}
+
+	// A HOST_DIR's root can't be escaped with a link either
+	root := t.TempDir()
+	require.NoError(t, os.Symlink(t.TempDir(), filepath.Join(root, "outside")))
+
+	hostRoot, err := os.OpenRoot(root)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, hostRoot.Close())
+	}()
+
+	_, err = ResolveFS(hostRoot.FS(), "outside")
+	assert.ErrorIs(t, err, ErrPathEscape)
+}
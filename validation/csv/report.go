@@ -17,13 +17,18 @@ type Warning struct {
 	ColIndex int    `json:"column"`
 	RowIndex int    `json:"row"`
 	Value    string `json:"value"`
+	File     string `json:"file,omitempty"` // Only set in reports that combine more than one file
+	Cell     string `json:"cell,omitempty"` // Only set for files that were read from a workbook (e.g., "Sheet1!D14")
+	Line     int    `json:"line,omitempty"` // Only set for records that couldn't be parsed, since lines and rows differ
 }
 
 // Report is a collection of validation warnings.
 type Report struct {
-	Profile  string    `json:"profile"`
-	Time     time.Time `json:"time"`
-	Warnings []Warning `json:"warnings"`
+	Profile  string             `json:"profile"`
+	Time     time.Time          `json:"time"`
+	Warnings []Warning          `json:"warnings"`
+	Source   *Source            `json:"source,omitempty"`  // How the file was read, in single file reports
+	Sources  map[string]*Source `json:"sources,omitempty"` // How each file was read, in reports that combine files
 }
 
 // NewReport creates a report of validation warnings.
@@ -53,11 +58,11 @@ func NewReport(multiErr error, csvData [][]string, logger *zap.Logger) (*Report,
 			}
 
 			report.Warnings = append(report.Warnings, Warning{
-				strings.ReplaceAll(err.String(), "\n", "<br/>"),
-				header,
-				err.Location.ColIndex, // The front-end should make this 1-based
-				err.Location.RowIndex, // The front-end should make this 1-based
-				strings.ReplaceAll(csvData[location.RowIndex][location.ColIndex], "\n", "\\n"),
+				Message:  strings.ReplaceAll(err.String(), "\n", "<br/>"),
+				Header:   header,
+				ColIndex: err.Location.ColIndex, // The front-end should make this 1-based
+				RowIndex: err.Location.RowIndex, // The front-end should make this 1-based
+				Value:    strings.ReplaceAll(csvData[location.RowIndex][location.ColIndex], "\n", "\\n"),
 			})
 		} else {
 			logger.Error("Unexpected error", zap.Error(err), zap.Stack("stacktrace"))
@@ -67,6 +72,21 @@ func NewReport(multiErr error, csvData [][]string, logger *zap.Logger) (*Report,
 	return report, nil
 }
 
+// SetSource records how the report's file was read and, if it was read from a workbook, the address of the sheet cell
+// each of the report's warnings was found in.
+func (report *Report) SetSource(source *Source) {
+	report.Source = source
+
+	if source == nil || source.Sheet == "" {
+		return
+	}
+
+	for index := range report.Warnings {
+		warning := &report.Warnings[index]
+		warning.Cell = CellAddress(source.Sheet, Location{RowIndex: warning.RowIndex, ColIndex: warning.ColIndex})
+	}
+}
+
 // SerializeReport serializes the Report to JSON for return to the Web browser.
 func SerializeReport(report *Report) (string, error) {
 	jsonData, err := json.MarshalIndent(report, "", "  ")
@@ -0,0 +1,199 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The formats that files can be read from
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatODS  = "ods"
+)
+
+// ErrWorkbook is returned when a spreadsheet workbook, or the requested sheet in it, can't be read.
+var ErrWorkbook = errors.New("unreadable workbook")
+
+// The signatures at the start of workbook files: XLSX and ODS files are ZIP archives, and legacy XLS files are OLE2
+// compound documents
+var (
+	zipSignature = []byte("PK\x03\x04")
+	xlsSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+)
+
+// odsMimeType is the content of the mimetype file in an OpenDocument spreadsheet.
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+// maxWorkbookPart is the largest uncompressed part of a workbook that will be read, so a small upload can't expand to
+// fill the server's memory. It's a little larger than the default upload limit, since a workbook's XML is compressed.
+const maxWorkbookPart = 64 << 20
+
+// maxSheetCells is the most cells (i.e., rows times columns) a sheet can be read into, so a small workbook with cells
+// that are far apart, or that are repeated many times, can't expand to fill the server's memory.
+const maxSheetCells = 10_000_000
+
+// sheet is a sheet in a workbook, in the order it appears in the workbook.
+type sheet struct {
+	name   string
+	hidden bool
+}
+
+// readWorkbook reads the data in the requested sheet of an XLSX or ODS workbook.
+func readWorkbook(data []byte, sheetName string) ([][]string, *Source, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: the file looks like a ZIP archive, but it can't be opened: %w", ErrWorkbook, err)
+	}
+
+	if mimeType, err := readPart(archive, "mimetype"); err == nil && strings.TrimSpace(string(mimeType)) == odsMimeType {
+		return readODS(archive, sheetName)
+	}
+
+	if slices.ContainsFunc(archive.File, func(file *zip.File) bool { return file.Name == "xl/workbook.xml" }) {
+		return readXLSX(archive, sheetName)
+	}
+
+	return nil, nil, fmt.Errorf("%w: the file is a ZIP archive, but not an Excel (.xlsx) or OpenDocument (.ods) "+
+		"spreadsheet", ErrWorkbook)
+}
+
+// readPart reads the named part of a workbook's archive.
+func readPart(archive *zip.Reader, name string) ([]byte, error) {
+	part, err := archive.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = part.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(part, maxWorkbookPart+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxWorkbookPart {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, maxWorkbookPart)
+	}
+
+	return data, nil
+}
+
+// selectSheet returns the index of the requested sheet, which can be named or numbered (from 1).
+//
+// If no sheet is requested, the first sheet that isn't hidden is selected.
+func selectSheet(sheets []sheet, requested string) (int, error) {
+	if len(sheets) == 0 {
+		return -1, fmt.Errorf("%w: the workbook doesn't have any sheets", ErrWorkbook)
+	}
+
+	if requested == "" {
+		if index := slices.IndexFunc(sheets, func(sheet sheet) bool { return !sheet.hidden }); index >= 0 {
+			return index, nil
+		}
+
+		return 0, nil
+	}
+
+	if index := slices.IndexFunc(sheets, func(sheet sheet) bool { return sheet.name == requested }); index >= 0 {
+		return index, nil
+	}
+
+	if number, err := strconv.Atoi(requested); err == nil && number >= 1 && number <= len(sheets) {
+		return number - 1, nil
+	}
+
+	names := make([]string, 0, len(sheets))
+	for _, sheet := range sheets {
+		names = append(names, fmt.Sprintf("'%s'", sheet.name))
+	}
+
+	return -1, fmt.Errorf("%w: the workbook doesn't have a sheet named '%s' (its sheets are %s)", ErrWorkbook,
+		requested, strings.Join(names, ", "))
+}
+
+// sheetMatrix turns a sheet's cells into a rectangular string matrix, like the one a CSV file is read into.
+//
+// Trailing empty rows and columns are dropped, since spreadsheets often have formatting, but no values, beyond their
+// data. The sheet's first cell (i.e., A1) is always the first cell in the matrix, so a cell's location in the matrix is
+// the same as its address in the sheet.
+func sheetMatrix(rows [][]string) [][]string {
+	for len(rows) > 0 && !slices.ContainsFunc(rows[len(rows)-1], isNotEmpty) {
+		rows = rows[:len(rows)-1]
+	}
+
+	width := 0
+	for _, row := range rows {
+		for index := len(row) - 1; index >= width; index-- {
+			if row[index] != "" {
+				width = index + 1
+				break
+			}
+		}
+	}
+
+	matrix := make([][]string, len(rows))
+	for index, row := range rows {
+		matrix[index] = make([]string, width)
+		copy(matrix[index], row[:min(len(row), width)])
+	}
+
+	return matrix
+}
+
+// checkSheetSize returns an error if a sheet with the supplied number of rows and columns has too many cells to read.
+func checkSheetSize(rows int, columns int) error {
+	if rows > 0 && columns > maxSheetCells/rows {
+		return fmt.Errorf("the sheet has more than %d cells", maxSheetCells)
+	}
+
+	return nil
+}
+
+// isNotEmpty returns whether a cell has a value.
+func isNotEmpty(value string) bool {
+	return value != ""
+}
+
+// formatNumber formats a spreadsheet number the way it would be written in a CSV file, without any floating point
+// noise (e.g., "0.10000000000000001" is formatted as "0.1").
+func formatNumber(value string) string {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// columnName returns the spreadsheet name (e.g., "A", "Z", "AA") of a zero-based column index.
+func columnName(index int) string {
+	name := ""
+
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+
+	return name
+}
+
+// CellAddress returns the spreadsheet address (e.g., "Sheet1!D14") of a location in the supplied sheet.
+//
+// Sheet names with anything other than letters, digits, and underscores are quoted, as they are in spreadsheets.
+func CellAddress(sheetName string, location Location) string {
+	if strings.ContainsFunc(sheetName, func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_'
+	}) {
+		sheetName = "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+	}
+
+	return fmt.Sprintf("%s!%s%d", sheetName, columnName(location.ColIndex), location.RowIndex+1)
+}
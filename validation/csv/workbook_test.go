@@ -0,0 +1,307 @@
+//go:build unit
+
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// xlsxParts are the parts of a small Excel workbook, with a hidden sheet before the one with the data.
+var xlsxParts = map[string]string{
+	"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+	<sheets>
+		<sheet name="Lists" sheetId="1" state="hidden" r:id="rId1"/>
+		<sheet name="Works" sheetId="2" r:id="rId2"/>
+		<sheet name="Other Sheet" sheetId="3" r:id="rId3"/>
+	</sheets>
+</workbook>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+	<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+		Target="worksheets/sheet1.xml"/>
+	<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+		Target="/xl/worksheets/sheet2.xml"/>
+	<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+		Target="worksheets/sheet3.xml"/>
+	<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+		Target="sharedStrings.xml"/>
+	<Relationship Id="rId5" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+		Target="styles.xml"/>
+</Relationships>`,
+	"xl/sharedStrings.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<si><t>Title</t></si>
+	<si><t>Date.created</t></si>
+	<si><r><t>Arte</t></r><r><rPr><b/></rPr><t xml:space="preserve"> Libertad</t></r><rPh><t>ignored</t></rPh></si>
+	<si><t>A_x000D_
+title</t></si>
+</sst>`,
+	"xl/styles.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd;@"/></numFmts>
+	<cellXfs count="4">
+		<xf numFmtId="0"/>
+		<xf numFmtId="14"/>
+		<xf numFmtId="164"/>
+		<xf numFmtId="2"/>
+	</cellXfs>
+</styleSheet>`,
+	"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>spa</t></is></c></row>
+		</sheetData></worksheet>`,
+	"xl/worksheets/sheet2.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+	<sheetData>
+		<row r="1">
+			<c r="A1" t="s"><v>0</v></c>
+			<c r="B1" t="s"><v>1</v></c>
+			<c r="C1" t="inlineStr"><is><t>Count</t></is></c>
+			<c r="D1" t="inlineStr"><is><t>Flag</t></is></c>
+		</row>
+		<row r="2">
+			<c r="A2" t="s"><v>2</v></c>
+			<c r="B2" s="1"><v>31627</v></c>
+			<c r="C2" s="3"><v>0.10000000000000001</v></c>
+			<c r="D2" t="b"><v>1</v></c>
+			<c r="F2" s="3"/>
+		</row>
+		<row r="4">
+			<c r="A4" t="s"><v>3</v></c>
+			<c r="B4" s="2"><v>1</v></c>
+			<c r="C4"><v>42</v></c>
+		</row>
+		<row r="6"><c r="A6" s="1"/></row>
+	</sheetData>
+</worksheet>`,
+	"xl/worksheets/sheet3.xml": `<worksheet><sheetData><row><c t="str"><v>Formula</v></c><c><v>1E-3</v></c></row>
+		</sheetData></worksheet>`,
+}
+
+// odsContent is the content of a small OpenDocument spreadsheet, with two sheets.
+const odsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet>
+<table:table table:name="Works">
+	<table:table-column table:number-columns-repeated="1024"/>
+	<table:table-header-rows><table:table-row>
+		<table:table-cell office:value-type="string"><text:p>Title</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Date.created</text:p></table:table-cell>
+		<table:table-cell table:number-columns-repeated="1022"/>
+	</table:table-row></table:table-header-rows>
+	<table:table-row>
+		<table:table-cell office:value-type="string">
+			<text:p>Arte<text:s text:c="2"/><text:span>Libertad</text:span></text:p>
+			<text:p>second</text:p>
+			<office:annotation><text:p>A comment</text:p></office:annotation>
+		</table:table-cell>
+		<table:table-cell office:value-type="date" office:date-value="1986-08-03">
+			<text:p>08/03/86</text:p>
+		</table:table-cell>
+		<table:table-cell table:number-columns-repeated="2"/>
+		<table:table-cell office:value-type="float" office:value="1234.5">
+			<text:p>1,234.50</text:p>
+		</table:table-cell>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="2">
+		<table:table-cell table:number-columns-repeated="1024"/>
+	</table:table-row>
+	<table:table-row>
+		<table:covered-table-cell/>
+		<table:table-cell office:value-type="boolean" office:boolean-value="true">
+			<text:p>TRUE</text:p>
+		</table:table-cell>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="1048570">
+		<table:table-cell table:number-columns-repeated="1024"/>
+	</table:table-row>
+</table:table>
+<table:table table:name="Second Sheet">
+	<table:table-row>
+		<table:table-cell office:value-type="string"><text:p>Other</text:p></table:table-cell>
+	</table:table-row>
+</table:table>
+</office:spreadsheet></office:body></office:document-content>`
+
+// hostileXLSXParts are the parts of an Excel workbook with values in its first and last cells, which would be read
+// into more than 17 billion cells.
+var hostileXLSXParts = map[string]string{
+	"xl/workbook.xml": `<workbook><sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+	"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`,
+	"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>A</t></is></c></row>` +
+		`<row r="1048576"><c r="XFD1048576" t="inlineStr"><is><t>B</t></is></c></row></sheetData></worksheet>`,
+}
+
+// newZip creates a ZIP archive with the supplied files.
+func newZip(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+
+	writer := zip.NewWriter(&buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+// TestReadFile_Workbook tests reading the sheets of Excel and OpenDocument workbooks.
+func TestReadFile_Workbook(t *testing.T) {
+	dir := t.TempDir()
+	xlsxFile := filepath.Join(dir, "works.xlsx")
+	odsFile := filepath.Join(dir, "works.ods")
+
+	require.NoError(t, os.WriteFile(xlsxFile, newZip(t, xlsxParts), 0o600))
+	require.NoError(t, os.WriteFile(odsFile, newZip(t, map[string]string{"mimetype": odsMimeType,
+		"content.xml": odsContent}), 0o600))
+
+	tests := []struct {
+		name     string
+		file     string
+		sheet    string
+		expected [][]string
+		source   Source
+	}{
+		{
+			name:  "First visible Excel sheet",
+			file:  xlsxFile,
+			sheet: "",
+			expected: [][]string{
+				{"Title", "Date.created", "Count", "Flag"},
+				{"Arte Libertad", "1986-08-03", "0.1", "TRUE"},
+				{"", "", "", ""},
+				{"A\r\ntitle", "1900-01-01", "42", ""},
+			},
+			source: Source{Format: FormatXLSX, Sheet: "Works"},
+		},
+		{
+			name:     "Excel sheet by name",
+			file:     xlsxFile,
+			sheet:    "Other Sheet",
+			expected: [][]string{{"Formula", "0.001"}},
+			source:   Source{Format: FormatXLSX, Sheet: "Other Sheet"},
+		},
+		{
+			name:     "Hidden Excel sheet by number",
+			file:     xlsxFile,
+			sheet:    "1",
+			expected: [][]string{{"spa"}},
+			source:   Source{Format: FormatXLSX, Sheet: "Lists"},
+		},
+		{
+			name: "First OpenDocument sheet",
+			file: odsFile,
+			expected: [][]string{
+				{"Title", "Date.created", "", "", ""},
+				{"Arte  Libertad\nsecond", "1986-08-03", "", "", "1234.5"},
+				{"", "", "", "", ""},
+				{"", "", "", "", ""},
+				{"", "TRUE", "", "", ""},
+			},
+			source: Source{Format: FormatODS, Sheet: "Works"},
+		},
+		{
+			name:     "OpenDocument sheet by name",
+			file:     odsFile,
+			sheet:    "Second Sheet",
+			expected: [][]string{{"Other"}},
+			source:   Source{Format: FormatODS, Sheet: "Second Sheet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csvData, source, err := ReadFile(tt.file, ReadOptions{Sheet: tt.sheet}, zaptest.NewLogger(t))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, csvData)
+			assert.Equal(t, tt.source, *source)
+		})
+	}
+}
+
+// TestReadFile_WorkbookErrors tests that workbooks that can't be read are rejected with a precise message.
+func TestReadFile_WorkbookErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		data        []byte
+		sheet       string
+		expectedErr string
+	}{
+		{name: "Unknown sheet", data: newZip(t, xlsxParts), sheet: "Missing",
+			expectedErr: "the workbook doesn't have a sheet named 'Missing' (its sheets are 'Lists', 'Works', " +
+				"'Other Sheet')"},
+		{name: "Unknown sheet number", data: newZip(t, xlsxParts), sheet: "4",
+			expectedErr: "the workbook doesn't have a sheet named '4'"},
+		{name: "ZIP archive that isn't a workbook", data: newZip(t, map[string]string{"works.csv": "Title\n"}),
+			expectedErr: "not an Excel (.xlsx) or OpenDocument (.ods) spreadsheet"},
+		{name: "Empty sheet", data: newZip(t, map[string]string{"mimetype": odsMimeType,
+			"content.xml": `<document-content><body><spreadsheet><table name="Empty"/></spreadsheet></body>` +
+				`</document-content>`}),
+			expectedErr: "sheet 'Empty' doesn't have any data"},
+		{name: "Legacy Excel workbook", data: append(xlsSignature, make([]byte, 512)...),
+			expectedErr: "the file is a legacy Excel (.xls) workbook"},
+		{name: "Excel sheet with cells that are far apart", data: newZip(t, hostileXLSXParts),
+			expectedErr: "the sheet has more than 10000000 cells"},
+		{name: "OpenDocument sheet with repeated rows", data: newZip(t, map[string]string{"mimetype": odsMimeType,
+			"content.xml": `<document-content><body><spreadsheet><table name="Repeated">` +
+				`<table-row number-rows-repeated="1048576"><table-cell number-columns-repeated="1048576">` +
+				`<p>x</p></table-cell></table-row></table></spreadsheet></body></document-content>`}),
+			expectedErr: "sheet 'Repeated' can't be parsed: the sheet has more than 10000000 cells"},
+		{name: "OpenDocument sheet with a repeated row", data: newZip(t, map[string]string{"mimetype": odsMimeType,
+			"content.xml": `<document-content><body><spreadsheet><table name="Repeated">` +
+				`<table-row><table-cell number-columns-repeated="1048576"><p>x</p></table-cell></table-row>` +
+				`<table-row number-rows-repeated="1048576"><table-cell><p>y</p></table-cell></table-row>` +
+				`</table></spreadsheet></body></document-content>`}),
+			expectedErr: "the sheet has more than 10000000 cells"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, "workbook")
+			require.NoError(t, os.WriteFile(file, tt.data, 0o600))
+
+			_, _, err := ReadFile(file, ReadOptions{Sheet: tt.sheet}, zaptest.NewLogger(t))
+			assert.ErrorIs(t, err, ErrWorkbook)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// TestCellAddress tests creating the spreadsheet addresses of locations in a sheet.
+func TestCellAddress(t *testing.T) {
+	assert.Equal(t, "Sheet1!A1", CellAddress("Sheet1", Location{}))
+	assert.Equal(t, "Sheet1!D14", CellAddress("Sheet1", Location{RowIndex: 13, ColIndex: 3}))
+	assert.Equal(t, "Works!AA2", CellAddress("Works", Location{RowIndex: 1, ColIndex: 26}))
+	assert.Equal(t, "Works!XFD3", CellAddress("Works", Location{RowIndex: 2, ColIndex: 16383}))
+	assert.Equal(t, "'Other Sheet'!B2", CellAddress("Other Sheet", Location{RowIndex: 1, ColIndex: 1}))
+	assert.Equal(t, "'Cataloger''s'!A1", CellAddress("Cataloger's", Location{}))
+}
+
+// TestReport_SetSource tests that warnings in workbooks are located by their cell's address.
+func TestReport_SetSource(t *testing.T) {
+	report := &Report{Warnings: []Warning{{RowIndex: 13, ColIndex: 3}}}
+
+	report.SetSource(&Source{Format: FormatCSV, Encoding: UTF8})
+	assert.Empty(t, report.Warnings[0].Cell)
+
+	report.SetSource(&Source{Format: FormatXLSX, Sheet: "Sheet1"})
+	assert.Equal(t, "Sheet1!D14", report.Warnings[0].Cell)
+	assert.Equal(t, "Sheet1", report.Source.Sheet)
+}
@@ -0,0 +1,423 @@
+package csv
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The largest row and column numbers an Excel worksheet can have
+const (
+	xlsxMaxRows    = 1048576
+	xlsxMaxColumns = 16384
+)
+
+// The kinds of values a number can be formatted as
+const (
+	numberValue = iota
+	dateValue
+	timeValue
+	dateTimeValue
+)
+
+// xlsxEscapeRegex finds the escaped characters (e.g., "_x000D_" for a carriage return) in XLSX text.
+var xlsxEscapeRegex = regexp.MustCompile(`_x([0-9A-Fa-f]{4})_`)
+
+// xlsxFormatRegex finds the parts of a number format that aren't date or time codes: quoted text, bracketed colors
+// and conditions, and escaped, padding, and repeated characters.
+var xlsxFormatRegex = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.|_.|\*.`)
+
+// xlsxWorkbook is the part of an XLSX workbook that lists its sheets.
+type xlsxWorkbook struct {
+	Properties struct {
+		Date1904 string `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		State string `xml:"state,attr"`
+		ID    string `xml:"id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships are the relationships between the workbook and its other parts.
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxText is a piece of text, which can be plain or made up of formatted runs.
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+// xlsxSharedStrings are the strings that the workbook's cells share.
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxStyles are the number formats of the workbook's cell styles.
+type xlsxStyles struct {
+	NumberFormats []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellFormats []struct {
+		NumberFormat int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+// xlsxWorksheet is the data in a worksheet.
+type xlsxWorksheet struct {
+	Rows []struct {
+		Index int        `xml:"r,attr"`
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// xlsxCell is a worksheet cell, whose value's meaning depends on its type and style.
+type xlsxCell struct {
+	Ref    string   `xml:"r,attr"`
+	Type   string   `xml:"t,attr"`
+	Style  int      `xml:"s,attr"`
+	Value  string   `xml:"v"`
+	Inline xlsxText `xml:"is"`
+}
+
+// xlsxReader reads the cells of an XLSX worksheet, using what it's read from the rest of the workbook.
+type xlsxReader struct {
+	strings  []string
+	kinds    []int // The kind of value each of the workbook's cell styles formats numbers as
+	date1904 bool
+}
+
+// readXLSX reads the data in the requested sheet of an Excel workbook.
+func readXLSX(archive *zip.Reader, sheetName string) ([][]string, *Source, error) {
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+
+	if err := unmarshalPart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return nil, nil, err
+	}
+
+	if err := unmarshalPart(archive, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, nil, err
+	}
+
+	sheets := make([]sheet, 0, len(workbook.Sheets))
+	for _, entry := range workbook.Sheets {
+		sheets = append(sheets, sheet{name: entry.Name, hidden: entry.State == "hidden" || entry.State == "veryHidden"})
+	}
+
+	index, err := selectSheet(sheets, sheetName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader := &xlsxReader{date1904: workbook.Properties.Date1904 == "1" || workbook.Properties.Date1904 == "true"}
+	sheetPath := ""
+
+	for _, relationship := range relationships.Relationships {
+		target := path.Join("xl", relationship.Target)
+		if strings.HasPrefix(relationship.Target, "/") {
+			target = strings.TrimPrefix(relationship.Target, "/")
+		}
+
+		switch {
+		case relationship.ID == workbook.Sheets[index].ID:
+			sheetPath = target
+		case strings.HasSuffix(relationship.Type, "/sharedStrings"):
+			if err = reader.readSharedStrings(archive, target); err != nil {
+				return nil, nil, err
+			}
+		case strings.HasSuffix(relationship.Type, "/styles"):
+			if err = reader.readStyles(archive, target); err != nil {
+				return nil, nil, err
+			}
+		default:
+		}
+	}
+
+	if sheetPath == "" {
+		return nil, nil, fmt.Errorf("%w: the workbook doesn't say where sheet '%s' is", ErrWorkbook, sheets[index].name)
+	}
+
+	var worksheet xlsxWorksheet
+	if err = unmarshalPart(archive, sheetPath, &worksheet); err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := reader.readRows(worksheet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sheetMatrix(rows), &Source{Format: FormatXLSX, Sheet: sheets[index].name}, nil
+}
+
+// unmarshalPart reads and unmarshals an XML part of a workbook's archive.
+func unmarshalPart(archive *zip.Reader, name string, value any) error {
+	data, err := readPart(archive, name)
+	if err != nil {
+		return fmt.Errorf("%w: %s can't be read: %w", ErrWorkbook, name, err)
+	}
+
+	if err = xml.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%w: %s can't be parsed: %w", ErrWorkbook, name, err)
+	}
+
+	return nil
+}
+
+// readSharedStrings reads the strings that the workbook's cells share.
+func (reader *xlsxReader) readSharedStrings(archive *zip.Reader, name string) error {
+	var sharedStrings xlsxSharedStrings
+
+	if err := unmarshalPart(archive, name, &sharedStrings); err != nil {
+		return err
+	}
+
+	reader.strings = make([]string, 0, len(sharedStrings.Items))
+	for _, item := range sharedStrings.Items {
+		reader.strings = append(reader.strings, item.String())
+	}
+
+	return nil
+}
+
+// readStyles reads the kind of value that each of the workbook's cell styles formats numbers as.
+func (reader *xlsxReader) readStyles(archive *zip.Reader, name string) error {
+	var styles xlsxStyles
+
+	if err := unmarshalPart(archive, name, &styles); err != nil {
+		return err
+	}
+
+	codes := make(map[int]string, len(styles.NumberFormats))
+	for _, format := range styles.NumberFormats {
+		codes[format.ID] = format.Code
+	}
+
+	reader.kinds = make([]int, 0, len(styles.CellFormats))
+	for _, format := range styles.CellFormats {
+		reader.kinds = append(reader.kinds, numberKind(format.NumberFormat, codes))
+	}
+
+	return nil
+}
+
+// readRows reads a worksheet's cells into rows of values.
+func (reader *xlsxReader) readRows(worksheet xlsxWorksheet) ([][]string, error) {
+	var rows [][]string
+
+	width := 0 // The number of columns the sheet will be read into
+	rowIndex := -1
+	for _, row := range worksheet.Rows {
+		rowIndex++
+		if row.Index > 0 {
+			rowIndex = row.Index - 1
+		}
+
+		colIndex := -1
+		for _, cell := range row.Cells {
+			colIndex++
+			if cell.Ref != "" {
+				ref, found := parseCellRef(cell.Ref)
+				if !found {
+					return nil, fmt.Errorf("%w: cell '%s' has an invalid address", ErrWorkbook, cell.Ref)
+				}
+
+				rowIndex, colIndex = ref.RowIndex, ref.ColIndex
+			}
+
+			value := reader.cellValue(cell)
+			if value == "" {
+				continue
+			}
+
+			if rowIndex >= xlsxMaxRows || colIndex >= xlsxMaxColumns {
+				return nil, fmt.Errorf("%w: cell '%s' is outside of the worksheet", ErrWorkbook, cell.Ref)
+			}
+
+			width = max(width, colIndex+1)
+			if err := checkSheetSize(max(len(rows), rowIndex+1), width); err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrWorkbook, err)
+			}
+
+			for len(rows) <= rowIndex {
+				rows = append(rows, nil)
+			}
+
+			for len(rows[rowIndex]) <= colIndex {
+				rows[rowIndex] = append(rows[rowIndex], "")
+			}
+
+			rows[rowIndex][colIndex] = value
+		}
+	}
+
+	return rows, nil
+}
+
+// cellValue returns a cell's value as text, as it would be written in a CSV file.
+//
+// Numbers that are formatted as dates or times are written in ISO 8601 form (e.g., "1986-08-03").
+func (reader *xlsxReader) cellValue(cell xlsxCell) string {
+	switch cell.Type {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || index < 0 || index >= len(reader.strings) {
+			return ""
+		}
+
+		return reader.strings[index]
+	case "inlineStr":
+		return cell.Inline.String()
+	case "b":
+		if strings.TrimSpace(cell.Value) == "1" {
+			return "TRUE"
+		}
+
+		return "FALSE"
+	case "str", "e", "d":
+		return unescapeXLSX(cell.Value)
+	default:
+	}
+
+	value := strings.TrimSpace(cell.Value)
+	if value == "" || cell.Style < 0 || cell.Style >= len(reader.kinds) || reader.kinds[cell.Style] == numberValue {
+		return formatNumber(value)
+	}
+
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil || serial < 0 || serial >= 2958466 { // Excel's dates end in 9999
+		return formatNumber(value)
+	}
+
+	date := excelTime(serial, reader.date1904)
+
+	switch reader.kinds[cell.Style] {
+	case dateValue:
+		return date.Format(time.DateOnly)
+	case timeValue:
+		return date.Format(time.TimeOnly)
+	default:
+		return date.Format("2006-01-02T15:04:05")
+	}
+}
+
+// String returns the text's plain text.
+func (text xlsxText) String() string {
+	if len(text.Runs) == 0 {
+		return unescapeXLSX(text.Text)
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(text.Text)
+	for _, run := range text.Runs {
+		builder.WriteString(run.Text)
+	}
+
+	return unescapeXLSX(builder.String())
+}
+
+// unescapeXLSX replaces the escaped characters in XLSX text (e.g., "_x000D_") with the characters themselves.
+func unescapeXLSX(text string) string {
+	if !strings.Contains(text, "_x") {
+		return text
+	}
+
+	return xlsxEscapeRegex.ReplaceAllStringFunc(text, func(escape string) string {
+		char, _ := strconv.ParseUint(escape[2:6], 16, 32)
+		return string(rune(char))
+	})
+}
+
+// numberKind returns the kind of value that a number format formats numbers as.
+func numberKind(id int, codes map[int]string) int {
+	// Excel's built-in formats don't need to be defined in a workbook's styles
+	switch {
+	case id >= 14 && id <= 17:
+		return dateValue
+	case id >= 18 && id <= 21, id >= 45 && id <= 47:
+		return timeValue
+	case id == 22:
+		return dateTimeValue
+	default:
+	}
+
+	code, found := codes[id]
+	if !found {
+		return numberValue
+	}
+
+	// Only a format's first section, for positive numbers, is looked at
+	code, _, _ = strings.Cut(code, ";")
+	code = strings.ToLower(xlsxFormatRegex.ReplaceAllString(code, ""))
+
+	hasDate, hasTime := strings.ContainsAny(code, "dy"), strings.ContainsAny(code, "hs")
+
+	switch {
+	case hasDate && hasTime:
+		return dateTimeValue
+	case hasDate:
+		return dateValue
+	case hasTime:
+		return timeValue
+	default:
+		return numberValue
+	}
+}
+
+// excelTime converts an Excel date serial number to the time it represents.
+//
+// The 1900 date system counts from the last day of 1899 and, for compatibility with Lotus 1-2-3, treats 1900 as a
+// leap year; the 1904 date system counts from the first day of 1904.
+func excelTime(serial float64, date1904 bool) time.Time {
+	base := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+	if date1904 {
+		base = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	} else if serial < 61 {
+		base = time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 24 * 60 * 60)
+
+	return base.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+}
+
+// parseCellRef parses a cell reference (e.g., "D14") into its location.
+func parseCellRef(ref string) (Location, bool) {
+	column := 0
+	index := 0
+
+	for index < len(ref) && ref[index] >= 'A' && ref[index] <= 'Z' {
+		column = column*26 + int(ref[index]-'A'+1)
+		if column > xlsxMaxColumns {
+			return Location{}, false
+		}
+
+		index++
+	}
+
+	row, err := strconv.Atoi(ref[index:])
+	if column == 0 || err != nil || row < 1 || row > xlsxMaxRows {
+		return Location{}, false
+	}
+
+	return Location{RowIndex: row - 1, ColIndex: column - 1}, true
+}
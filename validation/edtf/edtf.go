@@ -0,0 +1,375 @@
+// Package edtf parses dates written in the Extended Date/Time Format (EDTF), up to and including its level 1 features.
+package edtf
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors returned when a value can't be parsed as an EDTF date
+var (
+	ErrSyntax   = errors.New("doesn't follow the EDTF syntax")
+	ErrInvalid  = errors.New("isn't a real calendar date")
+	ErrInverted = errors.New("its interval ends before it starts")
+)
+
+// Markers used by EDTF intervals
+const (
+	intervalSeparator = "/"
+	openEnd           = ".."
+)
+
+// Day is a day on the proleptic Gregorian calendar.
+type Day struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// Compare returns -1 if the day is before the other day, 1 if it's after it, and 0 if they're the same day.
+func (day Day) Compare(other Day) int {
+	if result := cmp.Compare(day.Year, other.Year); result != 0 {
+		return result
+	}
+
+	if result := cmp.Compare(day.Month, other.Month); result != 0 {
+		return result
+	}
+
+	return cmp.Compare(day.Day, other.Day)
+}
+
+// String returns the day in the YYYY-MM-DD form.
+func (day Day) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", day.Year, day.Month, day.Day)
+}
+
+// Date is a single EDTF date, which covers all the days from its earliest to its latest.
+//
+// A date that's less precise than a day (e.g., "1985", "1985-04", or "198X") covers more than one day. Seasons are
+// treated as covering their whole year.
+type Date struct {
+	Earliest    Day
+	Latest      Day
+	Uncertain   bool // The date was qualified with '?' or '%'
+	Approximate bool // The date was qualified with '~' or '%'
+}
+
+// Value is a parsed EDTF value, which is either a single date or an interval between two dates.
+type Value struct {
+	Start    *Date // The date, or the start of the interval; nil if the interval's start is open or unknown
+	End      *Date // The date, or the end of the interval; nil if the interval's end is open or unknown
+	Interval bool  // Whether the value is an interval
+	Level    int   // The EDTF level needed for the features the value uses (0 or 1)
+}
+
+// Earliest returns the earliest day the value covers, or false if it has an open or unknown start.
+func (value Value) Earliest() (Day, bool) {
+	if value.Start == nil {
+		return Day{}, false
+	}
+
+	return value.Start.Earliest, true
+}
+
+// Latest returns the latest day the value covers, or false if it has an open or unknown end.
+func (value Value) Latest() (Day, bool) {
+	if value.End == nil {
+		return Day{}, false
+	}
+
+	return value.End.Latest, true
+}
+
+// Parse parses the supplied text as an EDTF level 0 or level 1 value.
+//
+// It returns an error wrapping ErrSyntax if the text isn't EDTF, ErrInvalid if it names a day that doesn't exist, or
+// ErrInverted if it's an interval that ends before it starts.
+func Parse(text string) (Value, error) {
+	if !strings.Contains(text, intervalSeparator) {
+		date, level, err := parseDate(text, true)
+		if err != nil {
+			return Value{}, err
+		}
+
+		return Value{Start: date, End: date, Level: level}, nil
+	}
+
+	start, end, found := strings.Cut(text, intervalSeparator)
+	if !found || strings.Contains(end, intervalSeparator) {
+		return Value{}, ErrSyntax
+	}
+
+	value := Value{Interval: true}
+
+	for _, part := range []struct {
+		text string
+		date **Date
+	}{{text: start, date: &value.Start}, {text: end, date: &value.End}} {
+		// Open (i.e., "..") and unknown (i.e., empty) ends were added in level 1
+		if part.text == openEnd || part.text == "" {
+			value.Level = 1
+			continue
+		}
+
+		date, level, err := parseDate(part.text, false)
+		if err != nil {
+			return Value{}, err
+		}
+
+		*part.date = date
+		value.Level = max(value.Level, level)
+	}
+
+	if value.Start == nil && value.End == nil {
+		return Value{}, ErrSyntax
+	}
+
+	if value.Start != nil && value.End != nil && value.Start.Earliest.Compare(value.End.Latest) > 0 {
+		return Value{}, ErrInverted
+	}
+
+	return value, nil
+}
+
+// parseDate parses a single EDTF date, which can include a time if it's not a part of an interval.
+//
+// It returns the date and the EDTF level needed for the features it uses.
+func parseDate(text string, allowTime bool) (*Date, int, error) {
+	var date Date
+	var level int
+
+	// A qualifier applies to the whole date
+	switch {
+	case strings.HasSuffix(text, "?"):
+		date.Uncertain = true
+	case strings.HasSuffix(text, "~"):
+		date.Approximate = true
+	case strings.HasSuffix(text, "%"):
+		date.Uncertain, date.Approximate = true, true
+	}
+
+	if date.Uncertain || date.Approximate {
+		text = text[:len(text)-1]
+		level = 1
+	}
+
+	text, clock, hasTime := strings.Cut(text, "T")
+	if hasTime && (!allowTime || level > 0 || !validTime(clock)) {
+		return nil, 0, ErrSyntax
+	}
+
+	// Letter-prefixed years can't have a month or day, and their sign follows the 'Y'
+	components := []string{text}
+	negative := false
+
+	if !strings.HasPrefix(text, "Y") {
+		components = strings.Split(text, "-")
+
+		// A negative year leaves an empty first component
+		if negative = components[0] == ""; negative {
+			components = components[1:]
+			level = 1
+		}
+	}
+
+	if len(components) == 0 || len(components) > 3 {
+		return nil, 0, ErrSyntax
+	}
+
+	earliestYear, latestYear, yearLevel, err := parseYear(components[0], negative)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	level = max(level, yearLevel)
+	unspecified := earliestYear != latestYear
+	date.Earliest = Day{Year: earliestYear, Month: 1, Day: 1}
+	date.Latest = Day{Year: latestYear, Month: 12, Day: 31}
+
+	// A time can only follow a complete date
+	if hasTime && (len(components) != 3 || unspecified) {
+		return nil, 0, ErrSyntax
+	}
+
+	if len(components) > 1 {
+		monthLevel, monthErr := date.setMonth(components[1], len(components) > 2, unspecified)
+		if monthErr != nil {
+			return nil, 0, monthErr
+		}
+
+		level = max(level, monthLevel)
+		unspecified = unspecified || components[1] == "XX"
+	}
+
+	if len(components) > 2 {
+		dayLevel, dayErr := date.setDay(components[2], unspecified)
+		if dayErr != nil {
+			return nil, 0, dayErr
+		}
+
+		level = max(level, dayLevel)
+	}
+
+	return &date, level, nil
+}
+
+// parseYear parses a year, returning the earliest and latest years it can be and the EDTF level it needs.
+//
+// Level 1 added years prefixed with 'Y', which have more than four digits, and years with unspecified digits (e.g.,
+// "198X"), which cover all the years that their unspecified digits could be.
+func parseYear(text string, negative bool) (int, int, int, error) {
+	sign := 1
+	if negative {
+		sign = -1
+	}
+
+	if digits, found := strings.CutPrefix(text, "Y"); found {
+		if negative {
+			return 0, 0, 0, ErrSyntax // A letter-prefixed year's sign follows its 'Y'
+		}
+
+		if digits, negative = strings.CutPrefix(digits, "-"); negative {
+			sign = -1
+		}
+
+		year, err := strconv.Atoi(digits)
+		if err != nil || len(digits) <= 4 || !isDigits(digits) {
+			return 0, 0, 0, ErrSyntax
+		}
+
+		return sign * year, sign * year, 1, nil
+	}
+
+	// Unspecified digits can only be the year's last digits
+	digits := strings.TrimRight(text, "X")
+	if len(text) != 4 || !isDigits(digits) || negative && digits != text {
+		return 0, 0, 0, ErrSyntax
+	}
+
+	earliest, _ := strconv.Atoi(digits + strings.Repeat("0", len(text)-len(digits)))
+	latest, _ := strconv.Atoi(digits + strings.Repeat("9", len(text)-len(digits)))
+
+	if digits != text {
+		return earliest, latest, 1, nil
+	}
+
+	return sign * earliest, sign * latest, 0, nil
+}
+
+// setMonth sets the month of the date, returning the EDTF level it needs.
+//
+// A month can be unspecified ("XX") or, in level 1, a season (21 to 24), which can't be followed by a day. Once a part
+// of a date is unspecified, the parts that follow it must be unspecified too.
+func (date *Date) setMonth(text string, hasDay bool, unspecified bool) (int, error) {
+	if text == "XX" {
+		return 1, nil
+	}
+
+	if len(text) != 2 || !isDigits(text) || unspecified {
+		return 0, ErrSyntax
+	}
+
+	month, _ := strconv.Atoi(text)
+
+	switch {
+	case month >= 21 && month <= 24:
+		if hasDay {
+			return 0, ErrSyntax
+		}
+
+		return 1, nil
+	case month < 1 || month > 12:
+		return 0, ErrInvalid
+	}
+
+	date.Earliest.Month, date.Latest.Month = month, month
+	date.Latest.Day = daysIn(date.Latest.Year, month)
+
+	return 0, nil
+}
+
+// setDay sets the day of the date, returning the EDTF level it needs.
+func (date *Date) setDay(text string, unspecified bool) (int, error) {
+	if text == "XX" {
+		return 1, nil
+	}
+
+	if len(text) != 2 || !isDigits(text) || unspecified {
+		return 0, ErrSyntax
+	}
+
+	day, _ := strconv.Atoi(text)
+	if day < 1 || day > date.Latest.Day {
+		return 0, ErrInvalid
+	}
+
+	date.Earliest.Day, date.Latest.Day = day, day
+
+	return 0, nil
+}
+
+// validTime returns whether the supplied text is a time of day, with an optional time zone.
+func validTime(text string) bool {
+	clock, zone := text, ""
+
+	if index := strings.IndexAny(text, "Z+-"); index >= 0 {
+		clock, zone = text[:index], text[index:]
+	}
+
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 || !inRange(parts[0], 23) || !inRange(parts[1], 59) || !inRange(parts[2], 59) {
+		return false
+	}
+
+	if zone == "" || zone == "Z" {
+		return true
+	}
+
+	hours, minutes, hasMinutes := strings.Cut(zone[1:], ":")
+	return inRange(hours, 23) && (!hasMinutes || inRange(minutes, 59))
+}
+
+// inRange returns whether the supplied text is two digits that are no more than the supplied maximum.
+func inRange(text string, maximum int) bool {
+	if len(text) != 2 || !isDigits(text) {
+		return false
+	}
+
+	value, _ := strconv.Atoi(text)
+	return value <= maximum
+}
+
+// isDigits returns whether the supplied text is made up of one or more ASCII digits.
+func isDigits(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for index := range len(text) {
+		if text[index] < '0' || text[index] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// daysIn returns the number of days in the supplied month of the supplied year.
+func daysIn(year int, month int) int {
+	switch month {
+	case 2:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
@@ -0,0 +1,121 @@
+//go:build unit
+
+package edtf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParse tests parsing EDTF dates and intervals.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		earliest string
+		latest   string
+		level    int
+	}{
+		{name: "Year", text: "1985", earliest: "1985-01-01", latest: "1985-12-31"},
+		{name: "Month", text: "1985-02", earliest: "1985-02-01", latest: "1985-02-28"},
+		{name: "Month in a leap year", text: "2000-02", earliest: "2000-02-01", latest: "2000-02-29"},
+		{name: "Day", text: "1976-11-06", earliest: "1976-11-06", latest: "1976-11-06"},
+		{name: "Date and time", text: "1985-04-12T23:20:30Z", earliest: "1985-04-12", latest: "1985-04-12"},
+		{name: "Date and time with a time zone", text: "1985-04-12T23:20:30-04:00", earliest: "1985-04-12",
+			latest: "1985-04-12"},
+		{name: "Interval", text: "1970/1979", earliest: "1970-01-01", latest: "1979-12-31"},
+		{name: "Interval of days", text: "2004-02-01/2005-02-08", earliest: "2004-02-01", latest: "2005-02-08"},
+		{name: "Interval within a year", text: "1990-05/1990", earliest: "1990-05-01", latest: "1990-12-31"},
+		{name: "Uncertain year", text: "1984?", earliest: "1984-01-01", latest: "1984-12-31", level: 1},
+		{name: "Approximate month", text: "2004-06~", earliest: "2004-06-01", latest: "2004-06-30", level: 1},
+		{name: "Uncertain and approximate day", text: "2004-06-11%", earliest: "2004-06-11", latest: "2004-06-11",
+			level: 1},
+		{name: "Unspecified decade", text: "198X", earliest: "1980-01-01", latest: "1989-12-31", level: 1},
+		{name: "Unspecified century", text: "19XX", earliest: "1900-01-01", latest: "1999-12-31", level: 1},
+		{name: "Unspecified day", text: "1985-04-XX", earliest: "1985-04-01", latest: "1985-04-30", level: 1},
+		{name: "Unspecified month and day", text: "1985-XX-XX", earliest: "1985-01-01", latest: "1985-12-31",
+			level: 1},
+		{name: "Season", text: "2001-21", earliest: "2001-01-01", latest: "2001-12-31", level: 1},
+		{name: "Negative year", text: "-1985", earliest: "-1985-01-01", latest: "-1985-12-31", level: 1},
+		{name: "Letter-prefixed year", text: "Y170000002", earliest: "170000002-01-01", latest: "170000002-12-31",
+			level: 1},
+		{name: "Negative letter-prefixed year", text: "Y-170000002", earliest: "-170000002-01-01",
+			latest: "-170000002-12-31", level: 1},
+		{name: "Open start", text: "../1985-04-12", latest: "1985-04-12", level: 1},
+		{name: "Unknown end", text: "1985-04/", earliest: "1985-04-01", level: 1},
+		{name: "Qualified interval", text: "1984?/2004~", earliest: "1984-01-01", latest: "2004-12-31", level: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Parse(tt.text)
+			require.NoError(t, err)
+
+			earliest, found := value.Earliest()
+			assert.Equal(t, tt.earliest != "", found)
+			if found {
+				assert.Equal(t, tt.earliest, earliest.String())
+			}
+
+			latest, found := value.Latest()
+			assert.Equal(t, tt.latest != "", found)
+			if found {
+				assert.Equal(t, tt.latest, latest.String())
+			}
+
+			assert.Equal(t, tt.level, value.Level)
+		})
+	}
+}
+
+// TestParse_Qualifiers tests that qualifiers are recorded on the dates they apply to.
+func TestParse_Qualifiers(t *testing.T) {
+	value, err := Parse("1984?/2004%")
+	require.NoError(t, err)
+
+	assert.True(t, value.Interval)
+	assert.True(t, value.Start.Uncertain)
+	assert.False(t, value.Start.Approximate)
+	assert.True(t, value.End.Uncertain)
+	assert.True(t, value.End.Approximate)
+}
+
+// TestParse_Errors tests that values that aren't valid EDTF are rejected with the right error.
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		expectedErr error
+	}{
+		{name: "Empty", text: "", expectedErr: ErrSyntax},
+		{name: "Free text", text: "circa 1950", expectedErr: ErrSyntax},
+		{name: "Spaced range", text: "1970 - 1979", expectedErr: ErrSyntax},
+		{name: "Month first", text: "08-03-1986", expectedErr: ErrSyntax},
+		{name: "Two-digit year", text: "86", expectedErr: ErrSyntax},
+		{name: "Short letter-prefixed year", text: "Y1985", expectedErr: ErrSyntax},
+		{name: "Letter-prefixed year with a month", text: "Y170000002-05", expectedErr: ErrSyntax},
+		{name: "Unspecified digit before a specified one", text: "19X5", expectedErr: ErrSyntax},
+		{name: "Specified month in an unspecified year", text: "198X-05", expectedErr: ErrSyntax},
+		{name: "Specified day in an unspecified month", text: "1985-XX-12", expectedErr: ErrSyntax},
+		{name: "Season with a day", text: "2001-21-05", expectedErr: ErrSyntax},
+		{name: "Qualified date and time", text: "1985-04-12T23:20:30?", expectedErr: ErrSyntax},
+		{name: "Time in an interval", text: "1985-04-12T23:20:30/1986", expectedErr: ErrSyntax},
+		{name: "Time without a day", text: "1985-04T23:20:30", expectedErr: ErrSyntax},
+		{name: "Invalid time", text: "1985-04-12T24:20:30", expectedErr: ErrSyntax},
+		{name: "Open interval", text: "../..", expectedErr: ErrSyntax},
+		{name: "Too many interval parts", text: "1985/1986/1987", expectedErr: ErrSyntax},
+		{name: "Invalid month", text: "1985-13", expectedErr: ErrInvalid},
+		{name: "Invalid day", text: "1985-02-29", expectedErr: ErrInvalid},
+		{name: "Inverted interval", text: "1996/1990", expectedErr: ErrInverted},
+		{name: "Inverted interval in a year", text: "2004-06/2004-05", expectedErr: ErrInverted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.text)
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
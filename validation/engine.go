@@ -4,7 +4,9 @@ package validation
 import (
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/UCLALibrary/validation-service/validation/checks"
 	"github.com/UCLALibrary/validation-service/validation/config"
 
 	"go.uber.org/multierr"
@@ -52,6 +54,11 @@ func NewEngine(suppliedLogger ...*zap.Logger) (*Engine, error) {
 		return nil, fmt.Errorf("failed to refresh profiles: %w", err)
 	}
 
+	// Profiles loaded at startup are held to the same standard as those that are changed while the service is running
+	if err = checkValidations(profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles: %w", err)
+	}
+
 	// Create a new validations registry so we can retrieve CSV validations
 	registry, regErr := NewRegistry(profiles, logger)
 	if regErr != nil {
@@ -71,6 +78,22 @@ func (engine *Engine) GetLogger() *zap.Logger {
 	return engine.logger
 }
 
+// GetProfiles gets the validation profiles used by the validation engine.
+func (engine *Engine) GetProfiles() *config.Profiles {
+	return engine.profiles
+}
+
+// HasValidation returns whether the named profile has the named validation.
+func (engine *Engine) HasValidation(profileName string, validationName string) bool {
+	profile := engine.profiles.GetProfile(profileName)
+	if profile == nil {
+		return false
+	}
+
+	_, found := profile.GetValidation(validationName)
+	return found
+}
+
 // GetValidators returns just the validators that are associated with the supplied profile names, or all validators
 // if no profile names are passed as arguments.
 func (engine *Engine) GetValidators(profileNames ...string) ([]Validator, error) {
@@ -97,8 +120,8 @@ func (engine *Engine) GetValidators(profileNames ...string) ([]Validator, error)
 		if profile != nil {
 			validations := removeExisting(profile.GetValidations(), existing)
 
-			// Right now, we're just passing Profiles as arguments to validator constructors
-			validators, err := engine.registry.GetValidators(validations, engine.profiles, engine.logger)
+			// We pass the profile to the validator constructors so each can be configured with its options
+			validators, err := engine.registry.GetValidators(validations, engine.profiles, engine.logger, profile)
 			if err != nil {
 				return nil, err
 			}
@@ -146,6 +169,36 @@ func (engine *Engine) Validate(profile string, csvData [][]string) error {
 	return errs
 }
 
+// Report validates the supplied CSV data with the supplied profile name in mind, returning a report of its warnings.
+//
+// A CSV without any validation violations gets a report without any warnings. An error is returned if the CSV can't
+// be validated at all, or if a report can't be generated from its validation errors.
+func (engine *Engine) Report(profile string, csvData [][]string) (*csv.Report, error) {
+	err := engine.Validate(profile, csvData)
+	if err == nil {
+		return &csv.Report{Profile: profile, Time: time.Now(), Warnings: []csv.Warning{}}, nil
+	}
+
+	report, reportErr := csv.NewReport(err, csvData, engine.logger)
+	if reportErr != nil {
+		return nil, fmt.Errorf("failed to generate report: %w", reportErr)
+	}
+
+	// A report without any warnings means the engine failed for a reason that wasn't a validation error
+	if len(report.Warnings) == 0 {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Close releases the resources that the engine's validators have shared, like the HOST_DIR's root.
+//
+// It should be called when the engine is no longer needed.
+func (engine *Engine) Close() error {
+	return checks.CloseHostDirs()
+}
+
 // removeExisting removes validations from a supplied slice if they already exist in the supplied map.
 func removeExisting(validations []string, existing map[string]struct{}) []string {
 	newValidations := make([]string, 0, len(validations)) // Constrain by max size
@@ -5,6 +5,7 @@ package validation
 import (
 	"github.com/UCLALibrary/validation-service/validation/config"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/UCLALibrary/validation-service/pkg/utils"
@@ -34,6 +35,36 @@ func TestEngine_NewEngine(t *testing.T) {
 	assert.NotNil(t, engine)
 }
 
+// TestEngine_NewEngine_BadProfiles tests that a validation engine isn't created from profiles it can't use.
+func TestEngine_NewEngine_BadProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "Unknown validator",
+			content: `{"profiles": {"test": {"name": "test", "validations": [{"name": "BogusCheck"}]}}}`,
+		},
+		{
+			name: "Invalid options",
+			content: `{"profiles": {"test": {"name": "test", "validations": [{"name": "ReqFieldCheck",
+				"options": {"fields": {"Title": {"objTypes": ["Work"], "notObjTypes": ["Collection"]}}}}]}}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profilesFile := filepath.Join(t.TempDir(), "profiles.json")
+			require.NoError(t, os.WriteFile(profilesFile, []byte(test.content), 0644))
+			t.Setenv(config.ConfigFile, profilesFile)
+
+			engine, err := NewEngine(zaptest.NewLogger(t))
+			assert.ErrorContains(t, err, "invalid profiles")
+			assert.Nil(t, engine)
+		})
+	}
+}
+
 // TestEngine_GetLogger tests that a new engine has created a logger and can return it.
 func TestEngine_GetLogger(t *testing.T) {
 	// Set system env to tell the engine where to find its persisted Profiles file
@@ -75,7 +106,7 @@ func TestEngine_Validate(t *testing.T) {
 	}
 
 	// Read in our CSV test data
-	csvData, csvErr := csv.ReadFile("../testdata/cct-works-simple.csv", engine.GetLogger())
+	csvData, _, csvErr := csv.ReadFile("../testdata/cct-works-simple.csv", csv.ReadOptions{}, engine.GetLogger())
 	if csvErr != nil {
 		require.NoError(t, csvErr)
 	}
@@ -86,3 +117,30 @@ func TestEngine_Validate(t *testing.T) {
 		t.Fatalf("error getting validators: %s", err)
 	}
 }
+
+// TestEngine_Report tests that an engine turns the results of a validation into a report.
+func TestEngine_Report(t *testing.T) {
+	logger := zaptest.NewLogger(t, zaptest.Level(utils.GetLogLevel()))
+
+	// Configure the location of the test profiles file
+	t.Setenv(config.ConfigFile, "../testdata/test_profiles.json")
+
+	engine, err := NewEngine(logger)
+	require.NoError(t, err)
+
+	// A CSV without any validation violations gets an empty report
+	report, err := engine.Report("test", [][]string{{"Title"}, {"One"}})
+	require.NoError(t, err)
+	assert.Equal(t, "test", report.Profile)
+	assert.Empty(t, report.Warnings)
+
+	// A CSV with a validation violation gets a warning for it
+	report, err = engine.Report("test", [][]string{{"Title"}, {"One\n"}})
+	require.NoError(t, err)
+	assert.Len(t, report.Warnings, 1)
+
+	// A CSV that can't be validated at all gets an error rather than a report
+	report, err = engine.Report("unknown", [][]string{{"Title"}, {"One"}})
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
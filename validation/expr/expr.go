@@ -0,0 +1,385 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Env gives an expression access to the row it's being evaluated against and to the rest of the CSV data.
+type Env interface {
+	// Column returns the current row's value for the named column, or an empty string if there isn't one.
+	Column(name string) string
+
+	// Lookup returns the value of the target column in the first row whose key column has the supplied value.
+	Lookup(keyColumn string, keyValue string, targetColumn string) string
+}
+
+// Expression is a compiled expression that can be evaluated against many rows.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parses the supplied source into an expression that can be evaluated.
+func Compile(source string) (*Expression, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := parse(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (expression *Expression) String() string {
+	return expression.source
+}
+
+// Columns returns the names of the columns the expression references, in the order in which they're first found.
+func (expression *Expression) Columns() []string {
+	var columns []string
+
+	seen := map[string]bool{}
+	walk(expression.root, func(current node) {
+		if column, ok := current.(*columnNode); ok && !seen[column.name] {
+			seen[column.name] = true
+			columns = append(columns, column.name)
+		}
+	})
+
+	return columns
+}
+
+// Eval evaluates the expression against the supplied environment.
+func (expression *Expression) Eval(env Env) (interface{}, error) {
+	return expression.root.eval(env)
+}
+
+// EvalBool evaluates the expression against the supplied environment, requiring that its result be a boolean.
+func (expression *Expression) EvalBool(env Env) (bool, error) {
+	value, err := expression.root.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression '%s' evaluated to %v, not a boolean", expression.source, value)
+	}
+
+	return result, nil
+}
+
+// node is a part of a compiled expression's tree.
+type node interface {
+	eval(env Env) (interface{}, error)
+}
+
+// literalNode is a string, number, or boolean value.
+type literalNode struct {
+	value interface{}
+}
+
+// columnNode is a reference to one of the row's columns.
+type columnNode struct {
+	name string
+}
+
+// listNode is a list of values, used with the 'in' operator.
+type listNode struct {
+	items []node
+}
+
+// notNode negates its operand.
+type notNode struct {
+	operand node
+}
+
+// logicalNode joins two boolean expressions with && or ||.
+type logicalNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+// comparisonNode compares two values.
+type comparisonNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+// callNode is a call to one of the supported functions.
+type callNode struct {
+	name    string
+	args    []node
+	pattern *regexp.Regexp
+}
+
+// walk visits the supplied node and all of its descendants.
+func walk(current node, visit func(node)) {
+	visit(current)
+
+	switch typed := current.(type) {
+	case *listNode:
+		for _, item := range typed.items {
+			walk(item, visit)
+		}
+	case *notNode:
+		walk(typed.operand, visit)
+	case *logicalNode:
+		walk(typed.left, visit)
+		walk(typed.right, visit)
+	case *comparisonNode:
+		walk(typed.left, visit)
+		walk(typed.right, visit)
+	case *callNode:
+		for _, arg := range typed.args {
+			walk(arg, visit)
+		}
+	default:
+	}
+}
+
+func (literal *literalNode) eval(_ Env) (interface{}, error) {
+	return literal.value, nil
+}
+
+func (column *columnNode) eval(env Env) (interface{}, error) {
+	return env.Column(column.name), nil
+}
+
+func (list *listNode) eval(env Env) (interface{}, error) {
+	values := make([]interface{}, 0, len(list.items))
+
+	for _, item := range list.items {
+		value, err := item.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func (not *notNode) eval(env Env) (interface{}, error) {
+	value, err := evalBool(not.operand, env)
+	if err != nil {
+		return nil, err
+	}
+
+	return !value, nil
+}
+
+func (logical *logicalNode) eval(env Env) (interface{}, error) {
+	left, err := evalBool(logical.left, env)
+	if err != nil {
+		return nil, err
+	}
+
+	// The right side is only evaluated when it can change the result
+	if logical.operator == "&&" && !left || logical.operator == "||" && left {
+		return left, nil
+	}
+
+	return evalBool(logical.right, env)
+}
+
+func (comparison *comparisonNode) eval(env Env) (interface{}, error) {
+	left, err := comparison.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := comparison.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if comparison.operator == "in" {
+		list, ok := right.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'in' requires a list, but found: %v", right)
+		}
+
+		for _, item := range list {
+			if equal(left, item) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	switch comparison.operator {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	default:
+	}
+
+	order, err := compare(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch comparison.operator {
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+func (call *callNode) eval(env Env) (interface{}, error) {
+	args := make([]string, 0, len(call.args))
+
+	for _, arg := range call.args {
+		value, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, toString(value))
+	}
+
+	switch call.name {
+	case "empty":
+		return strings.TrimSpace(args[0]) == "", nil
+	case "trim":
+		return strings.TrimSpace(args[0]), nil
+	case "lower":
+		return strings.ToLower(args[0]), nil
+	case "upper":
+		return strings.ToUpper(args[0]), nil
+	case "len":
+		return float64(utf8.RuneCountInString(args[0])), nil
+	case "contains":
+		return strings.Contains(args[0], args[1]), nil
+	case "matches":
+		pattern := call.pattern
+		if pattern == nil {
+			var err error
+
+			if pattern, err = regexp.Compile(args[1]); err != nil {
+				return nil, fmt.Errorf("invalid pattern for 'matches': %w", err)
+			}
+		}
+
+		return pattern.MatchString(args[0]), nil
+	case "lookup":
+		return env.Lookup(args[0], args[1], args[2]), nil
+	default:
+		return nil, fmt.Errorf("unknown function '%s'", call.name)
+	}
+}
+
+// evalBool evaluates a node that's required to produce a boolean value.
+func evalBool(current node, env Env) (bool, error) {
+	value, err := current.eval(env)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean, but found: %v", value)
+	}
+
+	return result, nil
+}
+
+// equal compares two values, comparing strings as numbers when they're being compared with a number.
+func equal(left interface{}, right interface{}) bool {
+	leftNumber, leftIsNumber := left.(float64)
+	rightNumber, rightIsNumber := right.(float64)
+
+	if leftIsNumber || rightIsNumber {
+		if !leftIsNumber {
+			if leftNumber, leftIsNumber = toNumber(left); !leftIsNumber {
+				return false
+			}
+		}
+
+		if !rightIsNumber {
+			if rightNumber, rightIsNumber = toNumber(right); !rightIsNumber {
+				return false
+			}
+		}
+
+		return leftNumber == rightNumber
+	}
+
+	return toString(left) == toString(right)
+}
+
+// compare orders two values, numerically if either of them is a number and as strings otherwise.
+func compare(left interface{}, right interface{}) (int, error) {
+	_, leftIsNumber := left.(float64)
+	_, rightIsNumber := right.(float64)
+
+	if leftIsNumber || rightIsNumber {
+		leftNumber, ok := toNumber(left)
+		if !ok {
+			return 0, fmt.Errorf("'%v' is not a number", left)
+		}
+
+		rightNumber, ok := toNumber(right)
+		if !ok {
+			return 0, fmt.Errorf("'%v' is not a number", right)
+		}
+
+		switch {
+		case leftNumber < rightNumber:
+			return -1, nil
+		case leftNumber > rightNumber:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	return strings.Compare(toString(left), toString(right)), nil
+}
+
+// toNumber converts a value to a number, if it can be.
+func toNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toString converts a value to its string form.
+func toString(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
@@ -0,0 +1,143 @@
+//go:build unit
+
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEnv is a simple environment with a single row and a table of rows that can be looked up.
+type testEnv struct {
+	row  map[string]string
+	rows []map[string]string
+}
+
+// Column returns the test row's value for the named column.
+func (env *testEnv) Column(name string) string {
+	return env.row[name]
+}
+
+// Lookup returns the target column's value in the first test row whose key column has the supplied value.
+func (env *testEnv) Lookup(keyColumn string, keyValue string, targetColumn string) string {
+	for _, row := range env.rows {
+		if row[keyColumn] == keyValue {
+			return row[targetColumn]
+		}
+	}
+
+	return ""
+}
+
+// TestExpression_EvalBool tests evaluating expressions against a row.
+func TestExpression_EvalBool(t *testing.T) {
+	env := &testEnv{
+		row: map[string]string{
+			"Object Type":   "Page",
+			"Parent ARK":    "ark:/21198/work1",
+			"Visibility":    "private",
+			"Item Sequence": "12",
+			"Title":         "  Page One ",
+		},
+		rows: []map[string]string{
+			{"Item ARK": "ark:/21198/work1", "Object Type": "Work"},
+			{"Item ARK": "ark:/21198/page1", "Object Type": "Page"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected bool
+	}{
+		{name: "String equality", source: "`Object Type` == 'Page'", expected: true},
+		{name: "String inequality", source: "`Object Type` != \"Work\"", expected: true},
+		{name: "Missing column is empty", source: "empty(`IIIF Access URL`)", expected: true},
+		{name: "Numeric comparison", source: "`Item Sequence` > 9", expected: true},
+		{name: "Numeric equality", source: "`Item Sequence` == 12.0", expected: true},
+		{name: "String comparison", source: "`Item Sequence` > '9'", expected: false},
+		{name: "In list", source: "`Visibility` in ['open', 'private']", expected: true},
+		{name: "Not in list", source: "!(`Visibility` in ['open', 'ucla'])", expected: true},
+		{name: "Keyword operators", source: "`Visibility` == 'private' and not empty(`Title`)", expected: true},
+		{name: "Or", source: "`Visibility` == 'open' || `Object Type` == 'Page'", expected: true},
+		{name: "And precedence", source: "false && true || true", expected: true},
+		{name: "Trim", source: "trim(`Title`) == 'Page One'", expected: true},
+		{name: "Lower and upper", source: "lower(`Visibility`) == lower(upper('Private'))", expected: true},
+		{name: "Length", source: "len(`Visibility`) == 7", expected: true},
+		{name: "Contains", source: "contains(`Parent ARK`, '21198')", expected: true},
+		{name: "Matches", source: "matches(`Parent ARK`, '^ark:/[0-9]{5}/')", expected: true},
+		{name: "Lookup", source: "lookup('Item ARK', `Parent ARK`, 'Object Type') == 'Work'", expected: true},
+		{name: "Lookup that's not found", source: "empty(lookup('Item ARK', 'ark:/none', 'Object Type'))",
+			expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expression, err := Compile(test.source)
+			require.NoError(t, err)
+
+			result, err := expression.EvalBool(env)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+// TestCompile_Errors tests that invalid expressions are rejected when they're compiled.
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "Empty expression", source: ""},
+		{name: "Unterminated string", source: "`Title` == 'abc"},
+		{name: "Unterminated column", source: "`Title == 'abc'"},
+		{name: "Unknown function", source: "size(`Title`) > 1"},
+		{name: "Wrong number of arguments", source: "contains(`Title`)"},
+		{name: "Invalid pattern", source: "matches(`Title`, '[')"},
+		{name: "Unexpected character", source: "`Title` = 'abc'"},
+		{name: "Trailing tokens", source: "`Title` == 'abc' 'def'"},
+		{name: "Unclosed parenthesis", source: "(`Title` == 'abc'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Compile(test.source)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestExpression_EvalErrors tests that expressions that can't be evaluated return an error.
+func TestExpression_EvalErrors(t *testing.T) {
+	env := &testEnv{row: map[string]string{"Title": "abc"}}
+
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "Not a boolean", source: "`Title`"},
+		{name: "Not a number", source: "`Title` > 5"},
+		{name: "Negated string", source: "!`Title`"},
+		{name: "In without a list", source: "`Title` in 'abc'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expression, err := Compile(test.source)
+			require.NoError(t, err)
+
+			_, err = expression.EvalBool(env)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestExpression_Columns tests getting the columns an expression references.
+func TestExpression_Columns(t *testing.T) {
+	expression, err := Compile("`Visibility` == 'private' && (empty(`IIIF Access URL`) || `Visibility` != 'open')")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Visibility", "IIIF Access URL"}, expression.Columns())
+}
@@ -0,0 +1,128 @@
+// Package expr provides a small expression language for writing validation rules that span a CSV row's columns.
+//
+// Expressions compare column values, literals, and the results of functions. Columns are referenced by their header
+// in backticks (e.g., `Object Type`) and evaluate to the row's value for that column, or to an empty string if the
+// column isn't present. Strings can be single or double-quoted and lists are written in square brackets.
+//
+// Supported operators are: ||, &&, ! (also: or, and, not), ==, !=, <, <=, >, >=, and in. Supported functions are:
+// empty(value), trim(value), lower(value), upper(value), len(value), contains(value, substring), matches(value,
+// pattern), and lookup(keyColumn, keyValue, column), which finds the first row whose keyColumn has the keyValue and
+// returns its value for the supplied column.
+//
+// For example:
+//
+//	`Object Type` == 'Page' && lookup('Item ARK', `Parent ARK`, 'Object Type') in ['Work', 'Page']
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind is the kind of lexical token that's been found in an expression.
+type tokenKind int
+
+// The kinds of tokens in an expression
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenColumn
+	tokenNumber
+	tokenOperator
+)
+
+// token is a single lexical token in an expression.
+type token struct {
+	kind     tokenKind
+	value    string
+	position int
+}
+
+// operators are the symbolic operators, longest first so that they're matched greedily.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ","}
+
+// lex splits an expression into its tokens.
+func lex(source string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(source)
+	for index := 0; index < len(runes); {
+		char := runes[index]
+
+		switch {
+		case unicode.IsSpace(char):
+			index++
+		case char == '\'' || char == '"' || char == '`':
+			value, next, err := lexQuoted(runes, index)
+			if err != nil {
+				return nil, err
+			}
+
+			kind := tokenString
+			if char == '`' {
+				kind = tokenColumn
+			}
+
+			tokens = append(tokens, token{kind: kind, value: value, position: index})
+			index = next
+		case unicode.IsDigit(char) || char == '-' && index+1 < len(runes) && unicode.IsDigit(runes[index+1]):
+			start := index
+			for index++; index < len(runes) && (unicode.IsDigit(runes[index]) || runes[index] == '.'); index++ {
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[start:index]), position: start})
+		case unicode.IsLetter(char) || char == '_':
+			start := index
+			for index++; index < len(runes) && (unicode.IsLetter(runes[index]) || unicode.IsDigit(runes[index]) ||
+				runes[index] == '_'); index++ {
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[start:index]), position: start})
+		default:
+			operator := matchOperator(string(runes[index:]))
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", char, index)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, value: operator, position: index})
+			index += len([]rune(operator))
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, position: len(runes)}), nil
+}
+
+// lexQuoted reads a quoted string or column name, which may contain backslash-escaped characters.
+func lexQuoted(runes []rune, start int) (string, int, error) {
+	var builder strings.Builder
+
+	quote := runes[start]
+	for index := start + 1; index < len(runes); index++ {
+		switch runes[index] {
+		case '\\':
+			if index+1 < len(runes) {
+				index++
+				builder.WriteRune(runes[index])
+			}
+		case quote:
+			return builder.String(), index + 1, nil
+		default:
+			builder.WriteRune(runes[index])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated %c at position %d", quote, start)
+}
+
+// matchOperator finds the operator at the start of the supplied string.
+func matchOperator(source string) string {
+	for _, operator := range operators {
+		if strings.HasPrefix(source, operator) {
+			return operator
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,290 @@
+package expr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// functionArity is the number of arguments each of the supported functions takes.
+var functionArity = map[string]int{
+	"empty":    1,
+	"trim":     1,
+	"lower":    1,
+	"upper":    1,
+	"len":      1,
+	"contains": 2,
+	"matches":  2,
+	"lookup":   3,
+}
+
+// keywordOperators are the words that can be used in place of symbolic operators.
+var keywordOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+}
+
+// parser builds an expression tree from a list of tokens.
+type parser struct {
+	tokens []token
+	index  int
+}
+
+// parse parses the supplied tokens into an expression tree.
+func parse(tokens []token) (node, error) {
+	parser := &parser{tokens: tokens}
+
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := parser.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected '%s' at position %d", next.value, next.position)
+	}
+
+	return root, nil
+}
+
+// peek returns the current token without consuming it.
+func (parser *parser) peek() token {
+	return parser.tokens[parser.index]
+}
+
+// next consumes and returns the current token.
+func (parser *parser) next() token {
+	current := parser.tokens[parser.index]
+	if current.kind != tokenEOF {
+		parser.index++
+	}
+
+	return current
+}
+
+// operator returns the current token's operator, translating keyword operators, or an empty string.
+func (parser *parser) operator() string {
+	current := parser.peek()
+
+	switch current.kind {
+	case tokenOperator:
+		return current.value
+	case tokenIdent:
+		if operator, exists := keywordOperators[current.value]; exists {
+			return operator
+		}
+
+		if current.value == "in" {
+			return "in"
+		}
+	default:
+	}
+
+	return ""
+}
+
+// expect consumes the supplied operator or returns an error.
+func (parser *parser) expect(operator string) error {
+	if current := parser.next(); current.kind != tokenOperator || current.value != operator {
+		return fmt.Errorf("expected '%s' at position %d", operator, current.position)
+	}
+
+	return nil
+}
+
+// parseOr parses a sequence of expressions joined by ||.
+func (parser *parser) parseOr() (node, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.operator() == "||" {
+		parser.next()
+
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{operator: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses a sequence of expressions joined by &&.
+func (parser *parser) parseAnd() (node, error) {
+	left, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.operator() == "&&" {
+		parser.next()
+
+		right, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicalNode{operator: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseNot parses a negated expression or a comparison.
+func (parser *parser) parseNot() (node, error) {
+	if parser.operator() == "!" {
+		parser.next()
+
+		operand, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return parser.parseComparison()
+}
+
+// parseComparison parses a comparison between two values, or a single value.
+func (parser *parser) parseComparison() (node, error) {
+	left, err := parser.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator := parser.operator(); operator {
+	case "==", "!=", "<", "<=", ">", ">=", "in":
+		parser.next()
+
+		right, err := parser.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &comparisonNode{operator: operator, left: left, right: right}, nil
+	default:
+		return left, nil
+	}
+}
+
+// parsePrimary parses a literal, column, list, function call, or parenthesized expression.
+func (parser *parser) parsePrimary() (node, error) {
+	current := parser.next()
+
+	switch current.kind {
+	case tokenString:
+		return &literalNode{value: current.value}, nil
+	case tokenColumn:
+		return &columnNode{name: current.value}, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(current.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' at position %d", current.value, current.position)
+		}
+
+		return &literalNode{value: number}, nil
+	case tokenIdent:
+		switch current.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		default:
+			return parser.parseCall(current)
+		}
+	case tokenOperator:
+		switch current.value {
+		case "(":
+			inner, err := parser.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			return inner, parser.expect(")")
+		case "[":
+			items, err := parser.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+
+			return &listNode{items: items}, nil
+		default:
+		}
+	default:
+	}
+
+	if current.kind == tokenEOF {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected '%s' at position %d", current.value, current.position)
+}
+
+// parseCall parses a call to one of the supported functions.
+func (parser *parser) parseCall(name token) (node, error) {
+	arity, exists := functionArity[name.value]
+	if !exists {
+		return nil, fmt.Errorf("unknown function '%s' at position %d", name.value, name.position)
+	}
+
+	if err := parser.expect("("); err != nil {
+		return nil, err
+	}
+
+	args, err := parser.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) != arity {
+		return nil, fmt.Errorf("function '%s' takes %d argument(s), but was given %d", name.value, arity, len(args))
+	}
+
+	call := &callNode{name: name.value, args: args}
+
+	// Patterns that are literals are compiled up front, so that errors in them are found when a rule is configured
+	if literal, ok := args[len(args)-1].(*literalNode); ok && name.value == "matches" {
+		pattern, ok := literal.value.(string)
+		if !ok {
+			return nil, fmt.Errorf("function 'matches' requires a string pattern")
+		}
+
+		if call.pattern, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern for 'matches': %w", err)
+		}
+	}
+
+	return call, nil
+}
+
+// parseList parses a comma-separated list of expressions that ends with the supplied closing operator.
+func (parser *parser) parseList(closing string) ([]node, error) {
+	var items []node
+
+	if parser.operator() == closing {
+		parser.next()
+		return items, nil
+	}
+
+	for {
+		item, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+
+		if parser.operator() != "," {
+			break
+		}
+
+		parser.next()
+	}
+
+	return items, parser.expect(closing)
+}
@@ -0,0 +1,524 @@
+// Package jobs provides an in-process queue that runs CSV validations asynchronously.
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/UCLALibrary/validation-service/validation"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+)
+
+// WorkerCount is the ENV property for the number of workers that process validation jobs.
+const WorkerCount string = "JOB_WORKERS"
+
+// Retention is the ENV property for how long a finished job is kept before it's removed (e.g., "1h").
+const Retention string = "JOB_RETENTION"
+
+// QueueSize is the ENV property for the number of jobs that can be waiting to be processed.
+const QueueSize string = "JOB_QUEUE_SIZE"
+
+// The defaults used when the ENV properties aren't set
+const (
+	defaultWorkers   = 2
+	defaultRetention = time.Hour
+	defaultQueueSize = 100
+)
+
+// Status is the processing state of a validation job.
+type Status string
+
+// The states a validation job can be in
+const (
+	Queued    Status = "queued"
+	Running   Status = "running"
+	Completed Status = "completed"
+	Failed    Status = "failed"
+)
+
+// File is a CSV file's name and data, submitted as a part of a validation job.
+type File struct {
+	Name     string
+	Data     [][]string
+	Source   *csv.Source   // How the file was read, if it's known
+	Warnings []csv.Warning // The problems found while the file was parsed, which are reported before its warnings
+	Path     string        // The file's server path, if it's read when its job is run rather than before it's submitted
+}
+
+// NewFile returns the job file for a file that was read. A file with records that couldn't be parsed is still
+// validated, so that its parse problems are reported along with the warnings for the records that could be parsed.
+func NewFile(name string, csvData [][]string, source *csv.Source, readErr error) (File, error) {
+	var parseErr *csv.ParseError
+	if errors.As(readErr, &parseErr) {
+		return File{Name: name, Data: parseErr.Data, Source: parseErr.Source, Warnings: parseErr.Warnings}, nil
+	}
+
+	return File{Name: name, Data: csvData, Source: source}, readErr
+}
+
+// Job is a single thread-safe validation job.
+type Job struct {
+	mutex    sync.RWMutex
+	id       string
+	profile  string
+	fileName string
+	files    []File
+	options  csv.ReadOptions // How the job's files are read, if they're read when it's run
+	combined bool
+	status   Status
+	created  time.Time
+	started  time.Time
+	finished time.Time
+	report   *csv.Report
+	err      error
+}
+
+// jobSnapshot is a temporary struct used for marshaling a Job to JSON.
+type jobSnapshot struct {
+	ID       string     `json:"id"`
+	Status   Status     `json:"status"`
+	Profile  string     `json:"profile"`
+	File     string     `json:"file,omitempty"`
+	Created  time.Time  `json:"created"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// Queue runs submitted validation jobs on a fixed pool of workers and keeps their results for a retention period.
+type Queue struct {
+	mutex     sync.RWMutex
+	engine    *validation.Engine
+	logger    *zap.Logger
+	jobs      map[string]*Job
+	pending   chan *Job
+	retention time.Duration
+	workers   int
+	done      chan struct{}
+	waiter    sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewQueue creates a new job queue, configured from the ENV, and starts its workers.
+func NewQueue(engine *validation.Engine) (*Queue, error) {
+	if engine == nil {
+		return nil, fmt.Errorf("supplied Engine cannot be nil")
+	}
+
+	workers, err := getIntEnv(WorkerCount, defaultWorkers)
+	if err != nil {
+		return nil, err
+	}
+
+	queueSize, err := getIntEnv(QueueSize, defaultQueueSize)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := defaultRetention
+	if value := os.Getenv(Retention); value != "" {
+		if retention, err = time.ParseDuration(value); err != nil || retention <= 0 {
+			return nil, fmt.Errorf("environment variable %s must be a positive duration: '%s'", Retention, value)
+		}
+	}
+
+	return StartQueue(engine, workers, queueSize, retention)
+}
+
+// StartQueue creates a new job queue with the supplied settings and starts its workers.
+func StartQueue(engine *validation.Engine, workers int, queueSize int, retention time.Duration) (*Queue, error) {
+	if engine == nil {
+		return nil, fmt.Errorf("supplied Engine cannot be nil")
+	}
+
+	if workers < 1 || queueSize < 1 || retention <= 0 {
+		return nil, fmt.Errorf("workers, queue size, and retention must all be positive")
+	}
+
+	queue := &Queue{
+		engine:    engine,
+		logger:    engine.GetLogger(),
+		jobs:      make(map[string]*Job),
+		pending:   make(chan *Job, queueSize),
+		retention: retention,
+		workers:   workers,
+		done:      make(chan struct{}),
+	}
+
+	// Start the workers that process the submitted jobs
+	for range workers {
+		queue.waiter.Add(1)
+		go queue.work()
+	}
+
+	// Start the janitor that removes finished jobs once they're past their retention period
+	queue.waiter.Add(1)
+	go queue.sweep()
+
+	queue.logger.Debug("Started validation job queue", zap.Int("workers", workers),
+		zap.Int("queueSize", queueSize), zap.Duration("retention", retention))
+
+	return queue, nil
+}
+
+// Submit adds a new validation job to the queue and returns it without waiting for it to be processed.
+func (queue *Queue) Submit(profile string, fileName string, csvData [][]string) (*Job, error) {
+	return queue.SubmitFile(profile, File{Name: fileName, Data: csvData})
+}
+
+// SubmitFile adds a new validation job for a single file, along with how it was read, to the queue and returns it
+// without waiting for it to be processed.
+func (queue *Queue) SubmitFile(profile string, file File) (*Job, error) {
+	return queue.submit(&Job{
+		id:       uuid.NewString(),
+		profile:  profile,
+		fileName: file.Name,
+		files:    []File{file},
+		status:   Queued,
+		created:  time.Now(),
+	})
+}
+
+// SubmitFiles adds a new job, which validates multiple CSV files, to the queue and returns it without waiting for it
+// to be processed.
+//
+// The job's report combines the warnings of all its files, each of which records the file it was found in.
+func (queue *Queue) SubmitFiles(profile string, name string, files []File) (*Job, error) {
+	return queue.submit(&Job{
+		id:       uuid.NewString(),
+		profile:  profile,
+		fileName: name,
+		files:    files,
+		combined: true,
+		status:   Queued,
+		created:  time.Now(),
+	})
+}
+
+// SubmitPaths adds a new job, which reads and validates multiple CSV files on the server, to the queue and returns it
+// without waiting for it to be processed.
+//
+// Each file's Path is read, with the supplied options, when the job is run, so the files don't have to be read before
+// the job is submitted. A file that can't be read fails the job. Otherwise, the job is just like one submitted with
+// SubmitFiles.
+func (queue *Queue) SubmitPaths(profile string, name string, files []File, options csv.ReadOptions) (*Job, error) {
+	return queue.submit(&Job{
+		id:       uuid.NewString(),
+		profile:  profile,
+		fileName: name,
+		files:    files,
+		options:  options,
+		combined: true,
+		status:   Queued,
+		created:  time.Now(),
+	})
+}
+
+// submit adds the supplied job to the queue, if there is room for it.
+func (queue *Queue) submit(job *Job) (*Job, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	select {
+	case <-queue.done:
+		return nil, fmt.Errorf("job queue has been closed")
+	default:
+	}
+
+	// The job's files are released by a worker once it's finished, so they're counted before it can be picked up
+	fileCount := len(job.files)
+
+	select {
+	case queue.pending <- job:
+		queue.jobs[job.id] = job
+	default:
+		return nil, fmt.Errorf("job queue is full; try again later")
+	}
+
+	queue.logger.Debug("Queued validation job", zap.String("jobID", job.id), zap.String("profile", job.profile),
+		zap.String("csvFile", job.fileName), zap.Int("files", fileCount))
+
+	return job, nil
+}
+
+// GetJob gets the job with the supplied ID, or nil if it's unknown or has expired.
+func (queue *Queue) GetJob(id string) *Job {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	job, exists := queue.jobs[id]
+	if !exists {
+		return nil
+	}
+
+	return job
+}
+
+// Count the number of jobs that the queue is currently tracking.
+func (queue *Queue) Count() int {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+	return len(queue.jobs)
+}
+
+// Close stops the queue from accepting new jobs and waits for its workers to finish the ones already queued.
+func (queue *Queue) Close() {
+	queue.closeOnce.Do(func() {
+		queue.mutex.Lock()
+		close(queue.done)
+		close(queue.pending)
+		queue.mutex.Unlock()
+
+		queue.waiter.Wait()
+	})
+}
+
+// work processes jobs from the queue until it's closed.
+func (queue *Queue) work() {
+	defer queue.waiter.Done()
+
+	for job := range queue.pending {
+		queue.run(job)
+	}
+}
+
+// run validates a single job's CSV data and records the resulting report.
+func (queue *Queue) run(job *Job) {
+	job.start()
+
+	report, err := queue.validate(job)
+	if err != nil {
+		queue.logger.Error("Validation job failed", zap.String("jobID", job.id), zap.Error(err))
+	} else {
+		queue.logger.Debug("Validation job completed", zap.String("jobID", job.id),
+			zap.Int("warnings", len(report.Warnings)))
+	}
+
+	job.finish(report, err)
+}
+
+// validate validates a job's files. A panic while they're validated fails the job, rather than stopping the worker
+// that's running it and, with it, the service.
+func (queue *Queue) validate(job *Job) (report *csv.Report, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			queue.logger.Error("Validation job panicked", zap.String("jobID", job.id), zap.Any("panic", recovered),
+				zap.Stack("stack"))
+			report, err = nil, fmt.Errorf("validation failed unexpectedly: %v", recovered)
+		}
+	}()
+
+	return queue.validateFiles(job.profile, job.files, job.options, job.combined)
+}
+
+// validateFiles validates each of the supplied files and combines their results into a single report.
+//
+// Files that were submitted by their paths are read, with the supplied options, one at a time. If the report is a
+// combined one, each of its warnings records the file in which it was found.
+func (queue *Queue) validateFiles(profile string, files []File, options csv.ReadOptions, combined bool) (*csv.Report,
+	error) {
+	combinedReport := &csv.Report{Profile: profile, Time: time.Now(), Warnings: []csv.Warning{}}
+
+	for _, file := range files {
+		if file.Path != "" {
+			var err error
+			if file, err = queue.readFile(file, options); err != nil {
+				return nil, err
+			}
+		}
+
+		report, err := queue.engine.Report(profile, file.Data)
+		if err != nil {
+			if combined {
+				return nil, fmt.Errorf("failed to validate '%s': %w", file.Name, err)
+			}
+
+			return nil, err
+		}
+
+		if len(file.Warnings) > 0 {
+			report.Warnings = append(slices.Clone(file.Warnings), report.Warnings...)
+		}
+
+		report.SetSource(file.Source)
+
+		// A single file's report is returned as it is
+		if !combined {
+			return report, nil
+		}
+
+		if file.Source != nil {
+			if combinedReport.Sources == nil {
+				combinedReport.Sources = make(map[string]*csv.Source, len(files))
+			}
+
+			combinedReport.Sources[file.Name] = file.Source
+		}
+
+		for _, warning := range report.Warnings {
+			warning.File = file.Name
+			combinedReport.Warnings = append(combinedReport.Warnings, warning)
+		}
+	}
+
+	return combinedReport, nil
+}
+
+// readFile reads the data of a file that was submitted by its path.
+//
+// The error it returns is a job's error, which is seen by the user, so it names the file as it was submitted rather
+// than by its server path.
+func (queue *Queue) readFile(file File, options csv.ReadOptions) (File, error) {
+	csvData, source, err := csv.ReadFile(file.Path, options, queue.logger)
+
+	read, err := NewFile(file.Name, csvData, source, err)
+	if reason, found := csv.ReadMessage(err); found {
+		return File{}, fmt.Errorf("the CSV file '%s' could not be read: %s", file.Name, reason)
+	} else if err != nil {
+		queue.logger.Error("Failed to read CSV file", zap.String("file", file.Name), zap.Error(err))
+		return File{}, fmt.Errorf("the CSV file '%s' could not be read", file.Name)
+	}
+
+	return read, nil
+}
+
+// sweep periodically removes finished jobs that are older than the queue's retention period.
+func (queue *Queue) sweep() {
+	defer queue.waiter.Done()
+
+	ticker := time.NewTicker(min(queue.retention, time.Minute))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-queue.done:
+			return
+		case now := <-ticker.C:
+			queue.expire(now)
+		}
+	}
+}
+
+// expire removes the finished jobs that are past their retention period at the supplied time.
+func (queue *Queue) expire(now time.Time) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	for id, job := range queue.jobs {
+		if finished := job.GetFinished(); !finished.IsZero() && now.Sub(finished) > queue.retention {
+			delete(queue.jobs, id)
+			queue.logger.Debug("Removed expired validation job", zap.String("jobID", id))
+		}
+	}
+}
+
+// GetID gets the ID of the current Job.
+func (job *Job) GetID() string {
+	return job.id // This is never changed after the job is created
+}
+
+// GetStatus gets the status of the current Job.
+func (job *Job) GetStatus() Status {
+	job.mutex.RLock()
+	defer job.mutex.RUnlock()
+	return job.status
+}
+
+// GetFinished gets the time the current Job finished, which is zero if it hasn't finished yet.
+func (job *Job) GetFinished() time.Time {
+	job.mutex.RLock()
+	defer job.mutex.RUnlock()
+	return job.finished
+}
+
+// GetReport gets the report of the current Job, which is nil if the job hasn't completed.
+func (job *Job) GetReport() *csv.Report {
+	job.mutex.RLock()
+	defer job.mutex.RUnlock()
+	return job.report
+}
+
+// GetError gets the error that caused the current Job to fail, which is nil if the job hasn't failed.
+func (job *Job) GetError() error {
+	job.mutex.RLock()
+	defer job.mutex.RUnlock()
+	return job.err
+}
+
+// MarshalJSON returns a JSON representation of the current Job's state.
+func (job *Job) MarshalJSON() ([]byte, error) {
+	job.mutex.RLock()
+	defer job.mutex.RUnlock()
+
+	snapshot := jobSnapshot{
+		ID:      job.id,
+		Status:  job.status,
+		Profile: job.profile,
+		File:    job.fileName,
+		Created: job.created,
+	}
+
+	if !job.started.IsZero() {
+		snapshot.Started = &job.started
+	}
+
+	if !job.finished.IsZero() {
+		snapshot.Finished = &job.finished
+	}
+
+	if job.err != nil {
+		snapshot.Error = job.err.Error()
+	}
+
+	return json.Marshal(snapshot)
+}
+
+// start marks the current Job as running.
+func (job *Job) start() {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	job.status = Running
+	job.started = time.Now()
+}
+
+// finish records the outcome of the current Job and releases the CSV data it no longer needs.
+func (job *Job) finish(report *csv.Report, err error) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+
+	if err != nil {
+		job.status = Failed
+		job.err = err
+	} else {
+		job.status = Completed
+		job.report = report
+	}
+
+	job.finished = time.Now()
+	job.files = nil
+}
+
+// getIntEnv gets a positive integer value from the supplied ENV property, or the default if it's not set.
+func getIntEnv(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		return 0, fmt.Errorf("environment variable %s must be a positive integer: '%s'", name, value)
+	}
+
+	return number, nil
+}
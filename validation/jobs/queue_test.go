@@ -0,0 +1,311 @@
+//go:build unit
+
+package jobs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/UCLALibrary/validation-service/pkg/utils"
+	"github.com/UCLALibrary/validation-service/validation"
+	"github.com/UCLALibrary/validation-service/validation/config"
+	"github.com/UCLALibrary/validation-service/validation/csv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// newTestEngine creates a validation engine that uses the test profiles.
+func newTestEngine(t *testing.T) *validation.Engine {
+	logger := zaptest.NewLogger(t, zaptest.Level(utils.GetLogLevel()))
+
+	// Configure the location of the test profiles file
+	if err := os.Setenv(config.ConfigFile, "../../testdata/test_profiles.json"); err != nil {
+		t.Fatalf("error setting env PROFILES_FILE: %v", err)
+	}
+	defer func() {
+		err := os.Unsetenv(config.ConfigFile)
+		require.NoError(t, err)
+	}()
+
+	engine, err := validation.NewEngine(logger)
+	require.NoError(t, err)
+
+	return engine
+}
+
+// waitFor waits for the supplied job to finish.
+func waitFor(t *testing.T, job *Job) {
+	assert.Eventually(t, func() bool {
+		return !job.GetFinished().IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestStartQueue tests that a queue can't be started with invalid settings.
+func TestStartQueue(t *testing.T) {
+	engine := newTestEngine(t)
+
+	tests := []struct {
+		name      string
+		engine    *validation.Engine
+		workers   int
+		queueSize int
+		retention time.Duration
+		expectErr bool
+	}{
+		{name: "valid settings", engine: engine, workers: 1, queueSize: 1, retention: time.Minute},
+		{name: "nil engine", workers: 1, queueSize: 1, retention: time.Minute, expectErr: true},
+		{name: "no workers", engine: engine, workers: 0, queueSize: 1, retention: time.Minute, expectErr: true},
+		{name: "no queue", engine: engine, workers: 1, queueSize: 0, retention: time.Minute, expectErr: true},
+		{name: "no retention", engine: engine, workers: 1, queueSize: 1, retention: 0, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue, err := StartQueue(tt.engine, tt.workers, tt.queueSize, tt.retention)
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, queue)
+			} else {
+				require.NoError(t, err)
+				queue.Close()
+			}
+		})
+	}
+}
+
+// TestNewQueue tests that the queue's settings are read from the ENV.
+func TestNewQueue(t *testing.T) {
+	engine := newTestEngine(t)
+
+	t.Setenv(WorkerCount, "3")
+	t.Setenv(QueueSize, "5")
+	t.Setenv(Retention, "10m")
+
+	queue, err := NewQueue(engine)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	assert.Equal(t, 3, queue.workers)
+	assert.Equal(t, 5, cap(queue.pending))
+	assert.Equal(t, 10*time.Minute, queue.retention)
+
+	t.Setenv(WorkerCount, "none")
+	_, err = NewQueue(engine)
+	assert.Error(t, err)
+
+	t.Setenv(WorkerCount, "1")
+	t.Setenv(Retention, "-1s")
+	_, err = NewQueue(engine)
+	assert.Error(t, err)
+}
+
+// TestQueue_Submit tests that submitted jobs are validated and their reports are recorded.
+func TestQueue_Submit(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 2, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	tests := []struct {
+		name     string
+		profile  string
+		csvData  [][]string
+		status   Status
+		warnings int
+	}{
+		{
+			name:    "valid CSV",
+			profile: "test",
+			csvData: [][]string{{"Title"}, {"A title"}},
+			status:  Completed,
+		},
+		{
+			name:     "invalid CSV",
+			profile:  "test",
+			csvData:  [][]string{{"Title"}, {"A\ntitle"}},
+			status:   Completed,
+			warnings: 1,
+		},
+		{
+			name:    "unknown profile",
+			profile: "unknown",
+			csvData: [][]string{{"Title"}, {"A title"}},
+			status:  Failed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := queue.Submit(tt.profile, "test.csv", tt.csvData)
+			require.NoError(t, err)
+			assert.Same(t, job, queue.GetJob(job.GetID()))
+
+			waitFor(t, job)
+			assert.Equal(t, tt.status, job.GetStatus())
+
+			if tt.status == Failed {
+				assert.Error(t, job.GetError())
+				assert.Nil(t, job.GetReport())
+			} else {
+				require.NoError(t, job.GetError())
+				require.NotNil(t, job.GetReport())
+				assert.Equal(t, tt.profile, job.GetReport().Profile)
+				assert.Len(t, job.GetReport().Warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+// TestQueue_SubmitFiles tests that a job's files are validated together and their warnings are attributed to them.
+func TestQueue_SubmitFiles(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	job, err := queue.SubmitFiles("test", "project", []File{
+		{Name: "project/a.csv", Data: [][]string{{"Title"}, {"A\ntitle"}}},
+		{Name: "project/b.csv", Data: [][]string{{"Title"}, {"A title"}}},
+		{Name: "project/c.csv", Data: [][]string{{"Title"}, {"Another\ntitle"}}},
+	})
+	require.NoError(t, err)
+
+	waitFor(t, job)
+	require.Equal(t, Completed, job.GetStatus())
+	require.NotNil(t, job.GetReport())
+
+	warnings := job.GetReport().Warnings
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "project/a.csv", warnings[0].File)
+	assert.Equal(t, "project/c.csv", warnings[1].File)
+}
+
+// TestQueue_SubmitFile_ParseWarnings tests that the problems found while a file was parsed are reported before the
+// warnings for the records that could be parsed.
+func TestQueue_SubmitFile_ParseWarnings(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	parseWarning := csv.Warning{Message: "Error: the row has 2 field(s), but the header row has 1 (line 3)",
+		ColIndex: 1, RowIndex: 2, Value: "extra", Line: 3}
+
+	job, err := queue.SubmitFile("test", File{Name: "ragged.csv", Data: [][]string{{"Title"}, {"A\ntitle"}, {"B"}},
+		Warnings: []csv.Warning{parseWarning}})
+	require.NoError(t, err)
+
+	waitFor(t, job)
+	require.Equal(t, Completed, job.GetStatus())
+	require.NotNil(t, job.GetReport())
+
+	warnings := job.GetReport().Warnings
+	require.Len(t, warnings, 2)
+	assert.Equal(t, parseWarning, warnings[0])
+	assert.Equal(t, 1, warnings[1].RowIndex)
+}
+
+// TestQueue_SubmitPaths tests that files submitted by their paths are read when their job is run, and that a file
+// that can't be read fails the job without revealing its path.
+func TestQueue_SubmitPaths(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("Title\n\"A\ntitle\"\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.csv"), []byte("Title;Creator\nB;C;D\n"), 0o600))
+
+	files := []File{{Name: "project/a.csv", Path: filepath.Join(dir, "a.csv")},
+		{Name: "project/b.csv", Path: filepath.Join(dir, "b.csv")}}
+
+	// The second file's long row is left for a StructureCheck to report
+	options := csv.ReadOptions{Tolerant: true, StructureChecked: true}
+	job, err := queue.SubmitPaths("test", "project", files, options)
+	require.NoError(t, err)
+
+	waitFor(t, job)
+	require.Equal(t, Completed, job.GetStatus())
+	require.Len(t, job.GetReport().Warnings, 1)
+	assert.Equal(t, "project/a.csv", job.GetReport().Warnings[0].File)
+	assert.Equal(t, ";", job.GetReport().Sources["project/b.csv"].Dialect.Delimiter)
+
+	// Otherwise, the second file's long row is reported as a parse problem
+	job, err = queue.SubmitPaths("test", "project", files, csv.ReadOptions{})
+	require.NoError(t, err)
+
+	waitFor(t, job)
+	require.Equal(t, Completed, job.GetStatus())
+	require.Len(t, job.GetReport().Warnings, 2)
+	assert.Equal(t, 2, job.GetReport().Warnings[1].Line)
+
+	job, err = queue.SubmitPaths("test", "project", []File{{Name: "project/missing.csv",
+		Path: filepath.Join(dir, "missing.csv")}}, csv.ReadOptions{})
+	require.NoError(t, err)
+
+	waitFor(t, job)
+	require.Equal(t, Failed, job.GetStatus())
+	assert.EqualError(t, job.GetError(), "the CSV file 'project/missing.csv' could not be read")
+}
+
+// TestQueue_SubmitClosed tests that a closed queue doesn't accept new jobs.
+func TestQueue_SubmitClosed(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 1, time.Minute)
+	require.NoError(t, err)
+
+	queue.Close()
+
+	job, err := queue.Submit("test", "test.csv", [][]string{{"Title"}, {"A title"}})
+	assert.Error(t, err)
+	assert.Nil(t, job)
+}
+
+// TestQueue_Expire tests that finished jobs are removed once they're past their retention period.
+func TestQueue_Expire(t *testing.T) {
+	queue, err := StartQueue(newTestEngine(t), 1, 1, time.Minute)
+	require.NoError(t, err)
+	defer queue.Close()
+
+	job, err := queue.Submit("test", "test.csv", [][]string{{"Title"}, {"A title"}})
+	require.NoError(t, err)
+	waitFor(t, job)
+
+	queue.expire(time.Now())
+	assert.Equal(t, 1, queue.Count())
+
+	queue.expire(time.Now().Add(2 * time.Minute))
+	assert.Equal(t, 0, queue.Count())
+	assert.Nil(t, queue.GetJob(job.GetID()))
+}
+
+// TestQueue_RunPanic tests that a job whose validation panics fails, rather than stopping the worker that runs it.
+func TestQueue_RunPanic(t *testing.T) {
+	// A queue without an engine panics when it validates a job's files
+	queue := &Queue{logger: zaptest.NewLogger(t), jobs: map[string]*Job{}}
+	job := &Job{id: "panic", profile: "test", files: []File{{Name: "test.csv", Data: [][]string{{"Title"}}}},
+		status: Queued, created: time.Now()}
+
+	assert.NotPanics(t, func() { queue.run(job) })
+	assert.Equal(t, Failed, job.GetStatus())
+	assert.ErrorContains(t, job.GetError(), "validation failed unexpectedly")
+	assert.Nil(t, job.GetReport())
+}
+
+// TestJob_MarshalJSON tests that a job's state is serialized to JSON.
+func TestJob_MarshalJSON(t *testing.T) {
+	job := &Job{id: "1234", profile: "test", fileName: "test.csv", status: Queued, created: time.Now()}
+
+	data, err := json.Marshal(job)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "1234", fields["id"])
+	assert.Equal(t, "queued", fields["status"])
+	assert.Equal(t, "test", fields["profile"])
+	assert.Equal(t, "test.csv", fields["file"])
+	assert.NotContains(t, fields, "started")
+	assert.NotContains(t, fields, "finished")
+	assert.NotContains(t, fields, "error")
+}
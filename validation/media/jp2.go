@@ -0,0 +1,176 @@
+package media
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// The JPEG 2000 codestream markers that are needed to inspect a codestream
+const (
+	markerSOC = 0xFF4F // Start of codestream
+	markerSIZ = 0xFF51 // Image and tile size
+)
+
+// The JP2 boxes that are needed to inspect a JP2 file
+const (
+	boxHeader     = "jp2h"
+	boxImage      = "ihdr"
+	boxCodestream = "jp2c"
+)
+
+// box is the type and location of the contents of a JP2 box.
+type box struct {
+	kind   string
+	offset int64
+	length int64
+}
+
+// inspectJP2 walks the boxes of a JP2 file, decoding its image header and the codestream that it contains.
+func inspectJP2(reader io.ReaderAt, size int64) (Info, error) {
+	boxes, err := readBoxes(reader, 0, size)
+	if err != nil {
+		return Info{}, err
+	}
+
+	var header, codestream *box
+	for index := range boxes {
+		switch boxes[index].kind {
+		case boxHeader:
+			header = &boxes[index]
+		case boxCodestream:
+			codestream = &boxes[index]
+		default:
+		}
+	}
+
+	if header == nil || codestream == nil {
+		return Info{}, fmt.Errorf("%w: missing JP2 header or codestream", ErrCorrupt)
+	}
+
+	headerBoxes, err := readBoxes(reader, header.offset, header.length)
+	if err != nil {
+		return Info{}, err
+	}
+
+	var info Info
+	for _, headerBox := range headerBoxes {
+		if headerBox.kind != boxImage || headerBox.length < 8 {
+			continue
+		}
+
+		buffer := make([]byte, 8)
+		if _, err = reader.ReadAt(buffer, headerBox.offset); err != nil {
+			return Info{}, err
+		}
+
+		info.Height = int(binary.BigEndian.Uint32(buffer[0:4]))
+		info.Width = int(binary.BigEndian.Uint32(buffer[4:8]))
+	}
+
+	if info.Width == 0 || info.Height == 0 {
+		return Info{}, fmt.Errorf("%w: missing JP2 image header", ErrCorrupt)
+	}
+
+	codestreamInfo, err := inspectCodestream(reader, codestream.offset, codestream.length)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if codestreamInfo.Width != info.Width || codestreamInfo.Height != info.Height {
+		return Info{}, fmt.Errorf("%w: JP2 header and codestream dimensions differ", ErrCorrupt)
+	}
+
+	return info, nil
+}
+
+// readBoxes reads the locations of the boxes in the supplied section of a JP2 file.
+//
+// It returns ErrTruncated if a box extends past the end of the section.
+func readBoxes(reader io.ReaderAt, offset int64, length int64) ([]box, error) {
+	var boxes []box
+
+	end := offset + length
+	buffer := make([]byte, 16)
+
+	for offset < end {
+		if end-offset < 8 {
+			return nil, ErrTruncated
+		}
+
+		if _, err := reader.ReadAt(buffer[:8], offset); err != nil {
+			return nil, err
+		}
+
+		headerSize := int64(8)
+		boxSize := int64(binary.BigEndian.Uint32(buffer[0:4]))
+
+		switch boxSize {
+		case 0: // The box extends to the end of the section
+			boxSize = end - offset
+		case 1: // The box's size is in an extended, 64-bit length field
+			if end-offset < 16 {
+				return nil, ErrTruncated
+			}
+
+			if _, err := reader.ReadAt(buffer[8:16], offset+8); err != nil {
+				return nil, err
+			}
+
+			headerSize = 16
+			boxSize = int64(binary.BigEndian.Uint64(buffer[8:16]))
+		default:
+		}
+
+		if boxSize < headerSize {
+			return nil, fmt.Errorf("%w: invalid JP2 box length", ErrCorrupt)
+		}
+
+		if boxSize > end-offset {
+			return nil, ErrTruncated
+		}
+
+		boxes = append(boxes, box{
+			kind:   string(buffer[4:8]),
+			offset: offset + headerSize,
+			length: boxSize - headerSize,
+		})
+
+		offset += boxSize
+	}
+
+	return boxes, nil
+}
+
+// inspectCodestream decodes the image size of a JPEG 2000 codestream, and checks that the codestream is complete.
+func inspectCodestream(reader io.ReaderAt, offset int64, length int64) (Info, error) {
+	// The start of codestream, SIZ marker, and SIZ fields up to the image offsets
+	buffer := make([]byte, 24)
+	if length < int64(len(buffer)) {
+		return Info{}, ErrTruncated
+	}
+
+	if _, err := reader.ReadAt(buffer, offset); err != nil {
+		return Info{}, err
+	}
+
+	if binary.BigEndian.Uint16(buffer[0:2]) != markerSOC || binary.BigEndian.Uint16(buffer[2:4]) != markerSIZ {
+		return Info{}, fmt.Errorf("%w: invalid JPEG 2000 codestream", ErrCorrupt)
+	}
+
+	width := binary.BigEndian.Uint32(buffer[8:12])
+	height := binary.BigEndian.Uint32(buffer[12:16])
+	xOffset := binary.BigEndian.Uint32(buffer[16:20])
+	yOffset := binary.BigEndian.Uint32(buffer[20:24])
+
+	if width <= xOffset || height <= yOffset {
+		return Info{}, fmt.Errorf("%w: invalid JPEG 2000 image size", ErrCorrupt)
+	}
+
+	// A complete codestream ends with an end of codestream marker
+	if err := checkTrailer(io.NewSectionReader(reader, offset, length), length, []byte{0xFF, 0xD9}); err != nil {
+		return Info{}, err
+	}
+
+	return Info{Width: int(width - xOffset), Height: int(height - yOffset)}, nil
+}
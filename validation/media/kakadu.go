@@ -0,0 +1,60 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// KakaduExpand is the Kakadu command that decodes JPEG 2000 images.
+const KakaduExpand = "kdu_expand"
+
+// ErrNoKakadu is returned when Kakadu isn't installed.
+var ErrNoKakadu = errors.New(KakaduExpand + " could not be found on the PATH")
+
+// Expander decodes JPEG 2000 images with Kakadu, to confirm that they can be decoded.
+type Expander struct {
+	command string
+	timeout time.Duration
+}
+
+// NewExpander creates a new Expander, which gives each image the supplied amount of time to be decoded.
+//
+// It returns ErrNoKakadu if Kakadu's kdu_expand can't be found on the PATH.
+func NewExpander(timeout time.Duration) (*Expander, error) {
+	command, err := exec.LookPath(KakaduExpand)
+	if err != nil {
+		return nil, ErrNoKakadu
+	}
+
+	return &Expander{command: command, timeout: timeout}, nil
+}
+
+// Expand decodes the JPEG 2000 image at the supplied path, discarding its pixels.
+//
+// It returns an error, with the last line of Kakadu's output, if the image can't be decoded.
+func (expander *Expander) Expand(path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), expander.timeout)
+	defer cancel()
+
+	// Without an output file, kdu_expand decodes the image without writing it anywhere
+	command := exec.CommandContext(ctx, expander.command, "-i", path)
+	command.WaitDelay = time.Second
+
+	output, err := command.CombinedOutput()
+	if ctx.Err() != nil {
+		return fmt.Errorf("decoding took longer than %s", expander.timeout)
+	} else if err != nil {
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if message := strings.TrimSpace(lines[len(lines)-1]); message != "" {
+			return errors.New(message)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Package media inspects the image files that CSVs reference, checking that they're what they claim to be and that
+// their headers are intact.
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Format is the format of an image file, as determined by its contents.
+type Format string
+
+// The image formats that can be inspected
+const (
+	TIFF Format = "tiff"
+	JPEG Format = "jpeg"
+	PNG  Format = "png"
+	JP2  Format = "jp2" // A JPEG 2000 file, with its codestream wrapped in JP2 boxes
+	J2K  Format = "j2k" // A raw JPEG 2000 codestream
+)
+
+// Errors returned when an image file can't be inspected
+var (
+	ErrUnknownFormat = errors.New("not a recognized image format")
+	ErrCorrupt       = errors.New("damaged image data")
+	ErrTruncated     = errors.New("image data is truncated")
+)
+
+// Info is what's learned about an image file from its header.
+type Info struct {
+	Format Format
+	Width  int
+	Height int
+}
+
+// signatures are the magic bytes that each of the image formats start with.
+var signatures = []struct {
+	format Format
+	magic  []byte
+}{
+	{format: TIFF, magic: []byte("II*\x00")},
+	{format: TIFF, magic: []byte("MM\x00*")},
+	{format: TIFF, magic: []byte("II+\x00")}, // BigTIFF
+	{format: TIFF, magic: []byte("MM\x00+")}, // BigTIFF
+	{format: JPEG, magic: []byte{0xFF, 0xD8, 0xFF}},
+	{format: PNG, magic: []byte("\x89PNG\r\n\x1a\n")},
+	{format: JP2, magic: []byte("\x00\x00\x00\x0cjP  \r\n\x87\n")},
+	{format: J2K, magic: []byte{0xFF, 0x4F, 0xFF, 0x51}},
+}
+
+// extensions are the formats that files with each of the supported image extensions can contain.
+var extensions = map[string][]Format{
+	".tif":  {TIFF},
+	".tiff": {TIFF},
+	".jpg":  {JPEG},
+	".jpeg": {JPEG},
+	".png":  {PNG},
+	".jp2":  {JP2, J2K},
+	".jpx":  {JP2, J2K},
+	".jpf":  {JP2, J2K},
+	".j2k":  {J2K},
+	".j2c":  {J2K},
+	".jpc":  {J2K},
+}
+
+// mimeTypes are the MIME types that describe each of the formats, with the format's usual MIME type first.
+var mimeTypes = map[Format][]string{
+	TIFF: {"image/tiff"},
+	JPEG: {"image/jpeg", "image/jpg"},
+	PNG:  {"image/png"},
+	JP2:  {"image/jp2", "image/jpx"},
+	J2K:  {"image/j2c", "image/jp2", "image/jpx"},
+}
+
+// Sizes used when reading the headers and trailers of image files
+const (
+	headerSize    = 12   // The number of bytes needed to detect any of the supported formats
+	trailerWindow = 1024 // How far from the end of image data its trailer can be, to allow for padding
+)
+
+// DetectFormat returns the format that the supplied header bytes begin with.
+func DetectFormat(header []byte) (Format, bool) {
+	for _, signature := range signatures {
+		if bytes.HasPrefix(header, signature.magic) {
+			return signature.format, true
+		}
+	}
+
+	return "", false
+}
+
+// MIMEType returns the usual MIME type of the format.
+func (format Format) MIMEType() string {
+	if types := mimeTypes[format]; len(types) > 0 {
+		return types[0]
+	}
+
+	return ""
+}
+
+// MatchesMIMEType returns whether the supplied MIME type describes the format, ignoring case and any parameters.
+func (format Format) MatchesMIMEType(mimeType string) bool {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+
+	return slices.Contains(mimeTypes[format], mimeType)
+}
+
+// FormatsFor returns the formats that a file with the supplied name's extension can contain.
+//
+// It returns nil if the file's extension isn't one of the supported image extensions.
+func FormatsFor(name string) []Format {
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Inspect detects the format of the supplied image data and decodes its header.
+//
+// The structure of the file is checked as far as it can be without decoding its pixels, so files that have been
+// truncated or damaged are found. It returns an error wrapping ErrUnknownFormat, ErrCorrupt, or ErrTruncated if the
+// image can't be inspected.
+func Inspect(reader io.ReaderAt, size int64) (Info, error) {
+	header := make([]byte, headerSize)
+	count, err := reader.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Info{}, err
+	}
+
+	format, found := DetectFormat(header[:count])
+	if !found {
+		return Info{}, ErrUnknownFormat
+	}
+
+	var info Info
+
+	switch format {
+	case TIFF:
+		info, err = inspectTIFF(reader, size)
+	case JP2:
+		info, err = inspectJP2(reader, size)
+	case J2K:
+		info, err = inspectCodestream(reader, 0, size)
+	case JPEG:
+		info, err = inspectStd(reader, size, jpeg.DecodeConfig, []byte{0xFF, 0xD9})
+	case PNG:
+		info, err = inspectStd(reader, size, png.DecodeConfig, []byte("IEND\xaeB`\x82"))
+	}
+
+	info.Format = format
+	return info, err
+}
+
+// inspectStd decodes the header of a format that the standard library supports, and checks that the file ends with
+// the format's trailer.
+func inspectStd(reader io.ReaderAt, size int64, decode func(io.Reader) (image.Config, error), trailer []byte) (
+	Info, error) {
+	config, err := decode(io.NewSectionReader(reader, 0, size))
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Info{}, ErrTruncated
+		}
+
+		return Info{}, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+
+	if err = checkTrailer(reader, size, trailer); err != nil {
+		return Info{}, err
+	}
+
+	return Info{Width: config.Width, Height: config.Height}, nil
+}
+
+// checkTrailer checks that the image data ends with the supplied trailer bytes, ignoring any zero padding after it.
+func checkTrailer(reader io.ReaderAt, size int64, trailer []byte) error {
+	end := make([]byte, min(size, trailerWindow))
+	if _, err := reader.ReadAt(end, size-int64(len(end))); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	if !bytes.HasSuffix(bytes.TrimRight(end, "\x00"), trailer) {
+		return ErrTruncated
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+//go:build unit
+
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codestreamFile is a JPEG 2000 codestream that's used to test the JPEG 2000 formats.
+const codestreamFile = "../../testdata/images/test.jpx"
+
+// buildTIFF builds a little-endian TIFF with a single strip of image data, which is truncated by the supplied amount.
+func buildTIFF(width uint32, height uint32, truncate int) []byte {
+	data := bytes.Repeat([]byte{0x80}, int(width*height))
+	buffer := &bytes.Buffer{}
+
+	// The header, directory with four entries, and next directory offset come before the image data
+	stripOffset := uint32(8 + 2 + 4*12 + 4)
+	entries := [][3]uint32{
+		{tagImageWidth, 4, width},
+		{tagImageLength, 4, height},
+		{tagStripOffsets, 4, stripOffset},
+		{tagStripByteCounts, 4, uint32(len(data))},
+	}
+
+	buffer.WriteString("II*\x00")
+	_ = binary.Write(buffer, binary.LittleEndian, uint32(8))
+	_ = binary.Write(buffer, binary.LittleEndian, uint16(len(entries)))
+
+	for _, entry := range entries {
+		_ = binary.Write(buffer, binary.LittleEndian, uint16(entry[0]))
+		_ = binary.Write(buffer, binary.LittleEndian, uint16(entry[1]))
+		_ = binary.Write(buffer, binary.LittleEndian, uint32(1))
+		_ = binary.Write(buffer, binary.LittleEndian, entry[2])
+	}
+
+	_ = binary.Write(buffer, binary.LittleEndian, uint32(0))
+	buffer.Write(data)
+
+	return buffer.Bytes()[:buffer.Len()-truncate]
+}
+
+// buildJP2 wraps the supplied codestream in the boxes of a JP2 file with the supplied dimensions.
+func buildJP2(codestream []byte, width uint32, height uint32) []byte {
+	buffer := &bytes.Buffer{}
+
+	writeBox := func(kind string, contents []byte) {
+		_ = binary.Write(buffer, binary.BigEndian, uint32(8+len(contents)))
+		buffer.WriteString(kind)
+		buffer.Write(contents)
+	}
+
+	imageHeader := &bytes.Buffer{}
+	_ = binary.Write(imageHeader, binary.BigEndian, height)
+	_ = binary.Write(imageHeader, binary.BigEndian, width)
+	imageHeader.Write([]byte{0, 3, 7, 7, 0, 0})
+
+	header := &bytes.Buffer{}
+	_ = binary.Write(header, binary.BigEndian, uint32(8+imageHeader.Len()))
+	header.WriteString("ihdr")
+	header.Write(imageHeader.Bytes())
+
+	writeBox("jP  ", []byte("\r\n\x87\n"))
+	writeBox("ftyp", []byte("jp2 \x00\x00\x00\x00jp2 "))
+	writeBox("jp2h", header.Bytes())
+	writeBox("jp2c", codestream)
+
+	return buffer.Bytes()
+}
+
+// TestInspect tests that image files are detected and their headers are decoded.
+func TestInspect(t *testing.T) {
+	codestream, err := os.ReadFile(codestreamFile)
+	require.NoError(t, err)
+
+	pngData := &bytes.Buffer{}
+	require.NoError(t, png.Encode(pngData, image.NewGray(image.Rect(0, 0, 30, 20))))
+
+	jpegData := &bytes.Buffer{}
+	require.NoError(t, jpeg.Encode(jpegData, image.NewGray(image.Rect(0, 0, 40, 10)), nil))
+
+	tests := []struct {
+		name        string
+		data        []byte
+		expected    Info
+		expectedErr error
+	}{
+		{name: "TIFF", data: buildTIFF(16, 8, 0), expected: Info{Format: TIFF, Width: 16, Height: 8}},
+		{name: "truncated TIFF", data: buildTIFF(16, 8, 10), expectedErr: ErrTruncated},
+		{name: "truncated TIFF header", data: buildTIFF(16, 8, 0)[:20], expectedErr: ErrTruncated},
+		{name: "PNG", data: pngData.Bytes(), expected: Info{Format: PNG, Width: 30, Height: 20}},
+		{name: "truncated PNG", data: pngData.Bytes()[:pngData.Len()-4], expectedErr: ErrTruncated},
+		{name: "JPEG", data: jpegData.Bytes(), expected: Info{Format: JPEG, Width: 40, Height: 10}},
+		{name: "padded JPEG", data: append(bytes.Clone(jpegData.Bytes()), 0, 0, 0),
+			expected: Info{Format: JPEG, Width: 40, Height: 10}},
+		{name: "truncated JPEG", data: jpegData.Bytes()[:jpegData.Len()-10], expectedErr: ErrTruncated},
+		{name: "codestream", data: codestream, expected: Info{Format: J2K, Width: 2000, Height: 2000}},
+		{name: "truncated codestream", data: codestream[:len(codestream)/2], expectedErr: ErrTruncated},
+		{name: "JP2", data: buildJP2(codestream, 2000, 2000), expected: Info{Format: JP2, Width: 2000, Height: 2000}},
+		{name: "truncated JP2", data: buildJP2(codestream, 2000, 2000)[:1000], expectedErr: ErrTruncated},
+		{name: "mismatched JP2", data: buildJP2(codestream, 100, 2000), expectedErr: ErrCorrupt},
+		{name: "unknown format", data: []byte("Title,Description\n"), expectedErr: ErrUnknownFormat},
+		{name: "empty file", data: []byte{}, expectedErr: ErrUnknownFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Inspect(bytes.NewReader(tt.data), int64(len(tt.data)))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, info)
+			}
+		})
+	}
+}
+
+// TestFormatsFor tests that image extensions are mapped to the formats they can contain.
+func TestFormatsFor(t *testing.T) {
+	assert.Equal(t, []Format{TIFF}, FormatsFor("Masters/image.TIF"))
+	assert.Equal(t, []Format{JP2, J2K}, FormatsFor("image.jpx"))
+	assert.Equal(t, []Format{J2K}, FormatsFor("image.j2c"))
+	assert.Nil(t, FormatsFor("video.mp4"))
+	assert.Nil(t, FormatsFor("image"))
+}
+
+// TestFormat_MatchesMIMEType tests that formats are matched with the MIME types that describe them.
+func TestFormat_MatchesMIMEType(t *testing.T) {
+	assert.Equal(t, "image/tiff", TIFF.MIMEType())
+	assert.Equal(t, "image/j2c", J2K.MIMEType())
+	assert.True(t, JP2.MatchesMIMEType("image/jpx"))
+	assert.True(t, JPEG.MatchesMIMEType(" Image/JPEG; charset=binary"))
+	assert.False(t, PNG.MatchesMIMEType("image/tiff"))
+	assert.False(t, Format("bmp").MatchesMIMEType("image/bmp"))
+}
+
+// TestExpander tests that images are decoded with Kakadu, when it's installed.
+func TestExpander(t *testing.T) {
+	path := os.Getenv("PATH")
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := NewExpander(time.Minute)
+	assert.ErrorIs(t, err, ErrNoKakadu)
+
+	// A fake kdu_expand, which fails for images whose names contain "bad", and takes too long for "slow" ones
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncase \"$2\" in\n*bad*) echo 'Kakadu Error:' >&2; echo 'Unexpected end of codestream' >&2; " +
+		"exit 1;;\n*slow*) sleep 5;;\nesac\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, KakaduExpand), []byte(script), 0o755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+path)
+
+	expander, err := NewExpander(500 * time.Millisecond)
+	require.NoError(t, err)
+
+	assert.NoError(t, expander.Expand("good.jpx"))
+	assert.EqualError(t, expander.Expand("bad.jpx"), "Unexpected end of codestream")
+	assert.ErrorContains(t, expander.Expand("slow.jpx"), "longer than")
+}
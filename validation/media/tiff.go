@@ -0,0 +1,205 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The TIFF tags that are needed to find an image's dimensions and data
+const (
+	tagImageWidth      = 256
+	tagImageLength     = 257
+	tagStripOffsets    = 273
+	tagStripByteCounts = 279
+	tagTileOffsets     = 324
+	tagTileByteCounts  = 325
+)
+
+// tiffTypeSizes are the sizes, in bytes, of the TIFF field types, indexed by type.
+var tiffTypeSizes = []int64{0, 1, 1, 2, 4, 8, 1, 1, 2, 4, 8, 4, 8, 4, 0, 0, 8, 8, 8}
+
+// tiffReader reads the structures of a TIFF or BigTIFF file.
+type tiffReader struct {
+	reader    io.ReaderAt
+	size      int64
+	order     binary.ByteOrder
+	bigTIFF   bool
+	entrySize int64
+}
+
+// inspectTIFF decodes the first image directory of a TIFF file, checking that its image data is inside the file.
+func inspectTIFF(reader io.ReaderAt, size int64) (Info, error) {
+	tiff := &tiffReader{reader: reader, size: size, order: binary.LittleEndian, entrySize: 12}
+
+	header := make([]byte, 16)
+	if err := tiff.readAt(header[:8], 0); err != nil {
+		return Info{}, err
+	}
+
+	if header[0] == 'M' {
+		tiff.order = binary.BigEndian
+	}
+
+	// BigTIFF files have a larger header, with a 64-bit offset to their first directory
+	if tiff.order.Uint16(header[2:4]) == 43 {
+		if err := tiff.readAt(header, 0); err != nil {
+			return Info{}, err
+		}
+
+		tiff.bigTIFF = true
+		tiff.entrySize = 20
+		header = header[8:]
+	} else {
+		header = header[4:]
+	}
+
+	fields, err := tiff.readDirectory(tiff.readOffset(header))
+	if err != nil {
+		return Info{}, err
+	}
+
+	width, height := fields[tagImageWidth], fields[tagImageLength]
+	if len(width) != 1 || len(height) != 1 || width[0] == 0 || height[0] == 0 {
+		return Info{}, fmt.Errorf("%w: missing image dimensions", ErrCorrupt)
+	}
+
+	offsets, counts := fields[tagStripOffsets], fields[tagStripByteCounts]
+	if len(offsets) == 0 {
+		offsets, counts = fields[tagTileOffsets], fields[tagTileByteCounts]
+	}
+
+	if len(offsets) == 0 || len(offsets) != len(counts) {
+		return Info{}, fmt.Errorf("%w: missing image data offsets", ErrCorrupt)
+	}
+
+	// The image data is usually at the end of the file, so this is where truncation is found
+	for index, offset := range offsets {
+		if offset > uint64(size) || counts[index] > uint64(size)-offset {
+			return Info{}, ErrTruncated
+		}
+	}
+
+	return Info{Width: int(width[0]), Height: int(height[0])}, nil
+}
+
+// readDirectory reads the fields of the image file directory at the supplied offset that are needed to inspect it.
+func (tiff *tiffReader) readDirectory(offset int64) (map[uint16][]uint64, error) {
+	countSize := int64(2)
+	if tiff.bigTIFF {
+		countSize = 8
+	}
+
+	buffer := make([]byte, countSize)
+	if err := tiff.readAt(buffer, offset); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if tiff.bigTIFF {
+		count = int64(tiff.order.Uint64(buffer))
+	} else {
+		count = int64(tiff.order.Uint16(buffer))
+	}
+
+	if count <= 0 || count > (tiff.size-offset)/tiff.entrySize {
+		return nil, ErrTruncated
+	}
+
+	entries := make([]byte, count*tiff.entrySize)
+	if err := tiff.readAt(entries, offset+countSize); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[uint16][]uint64)
+	for index := int64(0); index < count; index++ {
+		entry := entries[index*tiff.entrySize : (index+1)*tiff.entrySize]
+
+		switch tag := tiff.order.Uint16(entry[0:2]); tag {
+		case tagImageWidth, tagImageLength, tagStripOffsets, tagStripByteCounts, tagTileOffsets, tagTileByteCounts:
+			values, err := tiff.readValues(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			fields[tag] = values
+		default:
+		}
+	}
+
+	return fields, nil
+}
+
+// readValues reads the unsigned integer values of a directory entry.
+func (tiff *tiffReader) readValues(entry []byte) ([]uint64, error) {
+	fieldType := tiff.order.Uint16(entry[2:4])
+	if int(fieldType) >= len(tiffTypeSizes) || tiffTypeSizes[fieldType] == 0 {
+		return nil, fmt.Errorf("%w: unknown field type %d", ErrCorrupt, fieldType)
+	}
+
+	var count uint64
+	var value []byte
+
+	if tiff.bigTIFF {
+		count, value = tiff.order.Uint64(entry[4:12]), entry[12:20]
+	} else {
+		count, value = uint64(tiff.order.Uint32(entry[4:8])), entry[8:12]
+	}
+
+	typeSize := tiffTypeSizes[fieldType]
+	if count > uint64(tiff.size)/uint64(typeSize) {
+		return nil, ErrTruncated
+	}
+
+	// Values that don't fit in the entry are stored elsewhere, and the entry holds their offset instead
+	data := value
+	if length := int64(count) * typeSize; length > int64(len(value)) {
+		data = make([]byte, length)
+		if err := tiff.readAt(data, tiff.readOffset(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]uint64, count)
+	for index := range values {
+		switch field := data[int64(index)*typeSize:]; typeSize {
+		case 1:
+			values[index] = uint64(field[0])
+		case 2:
+			values[index] = uint64(tiff.order.Uint16(field))
+		case 4:
+			values[index] = uint64(tiff.order.Uint32(field))
+		default:
+			values[index] = tiff.order.Uint64(field)
+		}
+	}
+
+	return values, nil
+}
+
+// readOffset reads a file offset, which is larger in BigTIFF files.
+func (tiff *tiffReader) readOffset(buffer []byte) int64 {
+	if tiff.bigTIFF {
+		return int64(tiff.order.Uint64(buffer))
+	}
+
+	return int64(tiff.order.Uint32(buffer))
+}
+
+// readAt fills the supplied buffer from the offset, returning ErrTruncated if the file ends first.
+func (tiff *tiffReader) readAt(buffer []byte, offset int64) error {
+	if offset < 0 || offset > tiff.size-int64(len(buffer)) {
+		return ErrTruncated
+	}
+
+	if _, err := tiff.reader.ReadAt(buffer, offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrTruncated
+		}
+
+		return err
+	}
+
+	return nil
+}
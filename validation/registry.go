@@ -49,7 +49,7 @@ var constructors = map[string]constructor{
 		if len(args) > 0 {
 			// Check if the first argument is of the type *Profiles
 			if profiles, ok := args[0].(*config.Profiles); ok {
-				return checks.NewARKCheck(profiles)
+				return checks.NewARKCheck(profiles, getValidation("ARKCheck", args))
 			}
 
 			// ARKCheck expects *Profiles to be passed to it
@@ -58,13 +58,13 @@ var constructors = map[string]constructor{
 
 		// Default instance if no arguments are passed
 		defaultProfiles := config.NewProfiles() // Assume a default constructor exists
-		return checks.NewEOLCheck(defaultProfiles)
+		return checks.NewARKCheck(defaultProfiles, config.Validation{Name: "ARKCheck"})
 	},
 	"LicenseCheck": func(args ...interface{}) (Validator, error) {
 		if len(args) > 0 {
 			// Check if the first argument is of the type *Profiles
 			if profiles, ok := args[0].(*config.Profiles); ok {
-				return checks.NewLicenseCheck(profiles)
+				return checks.NewLicenseCheck(profiles, getValidation("LicenseCheck", args))
 			}
 
 			// LicenseCheck expects *Profiles to be passed to it
@@ -73,7 +73,7 @@ var constructors = map[string]constructor{
 
 		// Default instance if no arguments are passed
 		defaultProfiles := config.NewProfiles() // Assume a default constructor exists
-		return checks.NewLicenseCheck(defaultProfiles)
+		return checks.NewLicenseCheck(defaultProfiles, config.Validation{Name: "LicenseCheck"})
 	},
 	"ReqFieldCheck": func(args ...interface{}) (Validator, error) {
 		if len(args) > 0 {
@@ -89,7 +89,7 @@ var constructors = map[string]constructor{
 				return nil, fmt.Errorf("invalid argument: expected *zap.Logger, found: %T", args[1])
 			}
 
-			return checks.NewReqFieldCheck(profile, logger)
+			return checks.NewReqFieldCheck(profile, logger, getValidation("ReqFieldCheck", args))
 		}
 
 		return nil, fmt.Errorf("invalid argument: expected *profiles.Profiles and *zap.Logger; neither were found")
@@ -128,7 +128,7 @@ var constructors = map[string]constructor{
 		if len(args) > 0 {
 			// Check if the first argument is of the type *Profiles
 			if profiles, ok := args[0].(*config.Profiles); ok {
-				return checks.NewItemSeqCheck(profiles)
+				return checks.NewItemSeqCheck(profiles, getValidation("ItemSeqCheck", args))
 			}
 
 			// ItemSeqCheck expects *Profiles to be passed to it
@@ -137,7 +137,7 @@ var constructors = map[string]constructor{
 
 		// Default instance if no arguments are passed
 		defaultProfiles := config.NewProfiles()
-		return checks.NewItemSeqCheck(defaultProfiles)
+		return checks.NewItemSeqCheck(defaultProfiles, config.Validation{Name: "ItemSeqCheck"})
 	},
 	"VisibilityCheck": func(args ...interface{}) (Validator, error) {
 		if len(args) > 0 {
@@ -199,6 +199,192 @@ var constructors = map[string]constructor{
 		defaultProfiles := config.NewProfiles()
 		return checks.NewMediaMetaCheck(defaultProfiles)
 	},
+	"ColumnRuleCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewColumnRuleCheck(profiles, getValidation("ColumnRuleCheck", args))
+			}
+
+			// ColumnRuleCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewColumnRuleCheck(defaultProfiles, config.Validation{Name: "ColumnRuleCheck"})
+	},
+	"ExpressionCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewExpressionCheck(profiles, getValidation("ExpressionCheck", args))
+			}
+
+			// ExpressionCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewExpressionCheck(defaultProfiles, config.Validation{Name: "ExpressionCheck"})
+	},
+	"HierarchyCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewHierarchyCheck(profiles, getValidation("HierarchyCheck", args))
+			}
+
+			// HierarchyCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewHierarchyCheck(defaultProfiles, config.Validation{Name: "HierarchyCheck"})
+	},
+	"DuplicateCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewDuplicateCheck(profiles, getValidation("DuplicateCheck", args))
+			}
+
+			// DuplicateCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewDuplicateCheck(defaultProfiles, config.Validation{Name: "DuplicateCheck"})
+	},
+	"ImageIntegrityCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewImageIntegrityCheck(profiles, getValidation("ImageIntegrityCheck", args))
+			}
+
+			// ImageIntegrityCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewImageIntegrityCheck(defaultProfiles, config.Validation{Name: "ImageIntegrityCheck"})
+	},
+	"ChecksumCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewChecksumCheck(profiles, getValidation("ChecksumCheck", args))
+			}
+
+			// ChecksumCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewChecksumCheck(defaultProfiles, config.Validation{Name: "ChecksumCheck"})
+	},
+	"IIIFURLCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewIIIFURLCheck(profiles, getValidation("IIIFURLCheck", args))
+			}
+
+			// IIIFURLCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewIIIFURLCheck(defaultProfiles, config.Validation{Name: "IIIFURLCheck"})
+	},
+	"DateCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewDateCheck(profiles, getValidation("DateCheck", args))
+			}
+
+			// DateCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewDateCheck(defaultProfiles, config.Validation{Name: "DateCheck"})
+	},
+	"VocabularyCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewVocabularyCheck(profiles, getValidation("VocabularyCheck", args))
+			}
+
+			// VocabularyCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewVocabularyCheck(defaultProfiles, config.Validation{Name: "VocabularyCheck"})
+	},
+	"StructureCheck": func(args ...interface{}) (Validator, error) {
+		if len(args) > 0 {
+			// Check if the first argument is of the type *Profiles
+			if profiles, ok := args[0].(*config.Profiles); ok {
+				return checks.NewStructureCheck(profiles)
+			}
+
+			// StructureCheck expects *Profiles to be passed to it
+			return nil, fmt.Errorf("invalid argument: expected *Profiles, found: %T", args[0])
+		}
+
+		// Default instance if no arguments are passed
+		defaultProfiles := config.NewProfiles()
+		return checks.NewStructureCheck(defaultProfiles)
+	},
+}
+
+// IsRegistered checks whether a validator with the supplied name has been registered.
+func IsRegistered(name string) bool {
+	_, exists := constructors[name]
+	return exists
+}
+
+// ValidateOptions checks that the supplied validation's options can be used to create its validator.
+func ValidateOptions(validation config.Validation) error {
+	constructor, exists := constructors[validation.Name]
+	if !exists {
+		return fmt.Errorf("unknown validator: %s", validation.Name)
+	}
+
+	// We create a throwaway profile so the constructor receives the validation's options
+	profile, err := config.NewProfile("options", []config.Validation{validation})
+	if err != nil {
+		return err
+	}
+
+	_, err = constructor(config.NewProfiles(), zap.NewNop(), profile)
+	return err
+}
+
+// getValidation finds the named validation in the *Profile passed to a constructor, so its options can be used.
+func getValidation(name string, args []interface{}) config.Validation {
+	for _, arg := range args {
+		if profile, ok := arg.(*config.Profile); ok {
+			if validation, found := profile.GetValidation(name); found {
+				return validation
+			}
+		}
+	}
+
+	return config.Validation{Name: name}
 }
 
 // NewRegistry creates a new registry of validators
@@ -3,11 +3,14 @@
 package validation
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/UCLALibrary/validation-service/validation/config"
+	"maps"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -32,6 +35,35 @@ func TestNewRegistry(t *testing.T) {
 	assert.Nil(t, reg)
 }
 
+// TestIsRegistered tests checking whether a validator name is in the registry.
+func TestIsRegistered(t *testing.T) {
+	assert.True(t, IsRegistered("EOLCheck"))
+	assert.False(t, IsRegistered("BogusCheck"))
+}
+
+// TestValidateOptions tests checking a validation's options against its validator.
+func TestValidateOptions(t *testing.T) {
+	assert.NoError(t, ValidateOptions(config.Validation{Name: "EOLCheck"}))
+	assert.NoError(t, ValidateOptions(config.Validation{Name: "ARKCheck",
+		Options: json.RawMessage(`{"naans": ["21198"]}`)}))
+	assert.Error(t, ValidateOptions(config.Validation{Name: "ARKCheck", Options: json.RawMessage(`{"naans": 21198}`)}))
+	assert.Error(t, ValidateOptions(config.Validation{Name: "BogusCheck"}))
+}
+
+// TestGetValidation tests finding a validation's configuration in a validator constructor's arguments.
+func TestGetValidation(t *testing.T) {
+	profile, err := config.NewProfile("example", []config.Validation{
+		{Name: "ARKCheck", Options: json.RawMessage(`{"naans": ["21198"]}`)},
+	})
+	require.NoError(t, err)
+
+	validation := getValidation("ARKCheck", []interface{}{config.NewProfiles(), profile})
+	assert.JSONEq(t, `{"naans": ["21198"]}`, string(validation.Options))
+
+	// A validation that isn't in the profile just gets its name
+	assert.Equal(t, config.Validation{Name: "EOLCheck"}, getValidation("EOLCheck", []interface{}{profile}))
+}
+
 // TestGetValidators tests getting the validators from the registry.
 func TestGetValidators(t *testing.T) {
 	logger := zaptest.NewLogger(t)
@@ -41,7 +73,13 @@ func TestGetValidators(t *testing.T) {
 		t.Errorf("NewRegistry() error = %v", err)
 	}
 
-	// Delete map's entries so we have a fresh start to test with
+	// Delete map's entries so we have a fresh start to test with, restoring them for the tests that follow
+	registered := maps.Clone(constructors)
+	t.Cleanup(func() {
+		clear(constructors)
+		maps.Copy(constructors, registered)
+	})
+
 	for key := range constructors {
 		delete(constructors, key)
 	}
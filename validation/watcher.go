@@ -0,0 +1,218 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/UCLALibrary/validation-service/validation/config"
+)
+
+// defaultPollInterval is how often the Profiles file is checked when the ENV property isn't set.
+const defaultPollInterval = 5 * time.Second
+
+// ProfilesWatcher polls the persisted Profiles file and refreshes the engine's Profiles when the file changes.
+type ProfilesWatcher struct {
+	engine    *Engine
+	filePath  string
+	interval  time.Duration
+	modTime   time.Time
+	size      int64
+	done      chan struct{}
+	waiter    sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewProfilesWatcher creates a new Profiles watcher, configured from the ENV, and starts it.
+//
+// A poll interval of zero disables the watcher, in which case nil is returned.
+func NewProfilesWatcher(engine *Engine) (*ProfilesWatcher, error) {
+	interval := defaultPollInterval
+
+	if value := os.Getenv(config.PollInterval); value != "" {
+		var err error
+
+		if interval, err = time.ParseDuration(value); err != nil || interval < 0 {
+			return nil, fmt.Errorf("environment variable %s must be a non-negative duration: '%s'",
+				config.PollInterval, value)
+		}
+	}
+
+	if interval == 0 {
+		return nil, nil
+	}
+
+	return StartProfilesWatcher(engine, interval)
+}
+
+// StartProfilesWatcher creates a new Profiles watcher that polls at the supplied interval and starts it.
+func StartProfilesWatcher(engine *Engine, interval time.Duration) (*ProfilesWatcher, error) {
+	if engine == nil {
+		return nil, fmt.Errorf("supplied Engine cannot be nil")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("poll interval must be positive")
+	}
+
+	filePath := os.Getenv(config.ConfigFile)
+	if filePath == "" {
+		return nil, fmt.Errorf("environment variable %s is not set or empty", config.ConfigFile)
+	}
+
+	watcher := &ProfilesWatcher{
+		engine:   engine,
+		filePath: filePath,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+
+	// Record the state of the file that the engine's Profiles were loaded from
+	if info, err := os.Stat(filePath); err == nil {
+		watcher.modTime = info.ModTime()
+		watcher.size = info.Size()
+	}
+
+	watcher.waiter.Add(1)
+	go watcher.watch()
+
+	engine.logger.Debug("Watching profiles file for changes", zap.String("file", filePath),
+		zap.Duration("interval", interval))
+
+	return watcher, nil
+}
+
+// Close stops the watcher from polling the Profiles file.
+func (watcher *ProfilesWatcher) Close() {
+	watcher.closeOnce.Do(func() {
+		close(watcher.done)
+		watcher.waiter.Wait()
+	})
+}
+
+// watch polls the Profiles file until the watcher is closed.
+func (watcher *ProfilesWatcher) watch() {
+	defer watcher.waiter.Done()
+
+	ticker := time.NewTicker(watcher.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.done:
+			return
+		case <-ticker.C:
+			watcher.check()
+		}
+	}
+}
+
+// check refreshes the engine's Profiles if the Profiles file has changed since it was last checked.
+//
+// It returns true if the Profiles were refreshed.
+func (watcher *ProfilesWatcher) check() bool {
+	logger := watcher.engine.logger
+
+	info, err := os.Stat(watcher.filePath)
+	if err != nil {
+		logger.Error("Failed to check profiles file", zap.String("file", watcher.filePath), zap.Error(err))
+		return false
+	}
+
+	if info.ModTime().Equal(watcher.modTime) && info.Size() == watcher.size {
+		return false
+	}
+
+	// Record the file's new state, even if it's invalid, so that we don't keep trying to load the same bad file
+	watcher.modTime = info.ModTime()
+	watcher.size = info.Size()
+
+	profiles := watcher.engine.profiles
+	previous := validationsByProfile(profiles)
+
+	// The file's profiles are only swapped in if they load and their validators can be created from them, so a bad
+	// file leaves the last good profiles in place
+	refreshed, err := config.LoadProfiles()
+	if err == nil {
+		err = checkValidations(refreshed)
+	}
+
+	if err != nil {
+		logger.Error("Rejected invalid profiles file; keeping the last good profiles",
+			zap.String("file", watcher.filePath), zap.Error(err))
+		return false
+	}
+
+	profiles.Replace(refreshed)
+
+	current := validationsByProfile(profiles)
+	logChanges(logger, previous, current)
+
+	return true
+}
+
+// checkValidations checks that each of the supplied profiles' validations is registered and has usable options.
+func checkValidations(profiles *config.Profiles) error {
+	for name, validations := range validationsByProfile(profiles) {
+		for _, validation := range validations {
+			if !IsRegistered(validation.Name) {
+				return fmt.Errorf("profile '%s' references an unknown validator: %s", name, validation.Name)
+			}
+
+			if err := ValidateOptions(validation); err != nil {
+				return fmt.Errorf("profile '%s' has invalid options for %s: %w", name, validation.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validationsByProfile gets a copy of each profile's validations, keyed by the profile's name.
+func validationsByProfile(profiles *config.Profiles) map[string][]config.Validation {
+	validations := make(map[string][]config.Validation)
+
+	for _, name := range profiles.GetProfileNames() {
+		if profile := profiles.GetProfile(name); profile != nil {
+			validations[name] = profile.GetValidationDetails()
+		}
+	}
+
+	return validations
+}
+
+// logChanges logs the differences between two sets of profile validations.
+func logChanges(logger *zap.Logger, previous map[string][]config.Validation, current map[string][]config.Validation) {
+	var added, removed, changed []string
+
+	for name, validations := range current {
+		if oldValidations, exists := previous[name]; !exists {
+			added = append(added, name)
+		} else if !reflect.DeepEqual(oldValidations, validations) {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range previous {
+		if _, exists := current[name]; !exists {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+
+	if len(added) == 0 && len(removed) == 0 && len(changed) == 0 {
+		logger.Debug("Refreshed profiles without any changes")
+		return
+	}
+
+	logger.Info("Refreshed profiles", zap.Strings("added", added), zap.Strings("removed", removed),
+		zap.Strings("changed", changed))
+}
@@ -0,0 +1,132 @@
+//go:build unit
+
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/UCLALibrary/validation-service/pkg/utils"
+	"github.com/UCLALibrary/validation-service/validation/config"
+)
+
+// TestProfilesWatcher_Check tests that the engine's profiles are refreshed when the profiles file changes.
+func TestProfilesWatcher_Check(t *testing.T) {
+	logger := zaptest.NewLogger(t, zaptest.Level(utils.GetLogLevel()))
+
+	// Copy the test profiles file so that we can change it
+	data, err := os.ReadFile("../testdata/test_profiles.json")
+	require.NoError(t, err)
+	profilesFile := filepath.Join(t.TempDir(), "profiles.json")
+	require.NoError(t, os.WriteFile(profilesFile, data, 0644))
+
+	// Configure the location of the test profiles file
+	t.Setenv(config.ConfigFile, profilesFile)
+
+	engine, err := NewEngine(logger)
+	require.NoError(t, err)
+
+	// We use a long interval so that the test controls when the file is checked
+	watcher, err := StartProfilesWatcher(engine, time.Hour)
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	// An unchanged file isn't reloaded
+	assert.False(t, watcher.check())
+
+	tests := []struct {
+		name      string
+		content   string
+		refreshed bool
+		profiles  []string
+	}{
+		{
+			name: "Added profile",
+			content: `{"profiles": {
+				"test": {"name": "test", "validations": [{"name": "EOLCheck"}]},
+				"new": {"name": "new", "validations": [{"name": "UnicodeCheck"}]}
+			}}`,
+			refreshed: true,
+			profiles:  []string{"new", "test"},
+		},
+		{
+			name:      "Invalid JSON",
+			content:   `{"profiles": {`,
+			refreshed: false,
+			profiles:  []string{"new", "test"},
+		},
+		{
+			name:      "No profiles",
+			content:   `{"profiles": {}}`,
+			refreshed: false,
+			profiles:  []string{"new", "test"},
+		},
+		{
+			name: "Unknown validator",
+			content: `{"profiles": {
+				"test": {"name": "test", "validations": [{"name": "EOLCheck"}]},
+				"unknown": {"name": "unknown", "validations": [{"name": "NoSuchCheck"}]}
+			}}`,
+			refreshed: false,
+			profiles:  []string{"new", "test"},
+		},
+		{
+			name: "Options that can't be decoded",
+			content: `{"profiles": {
+				"test": {"name": "test", "validations": [{"name": "EOLCheck"}]},
+				"license": {"name": "license", "validations": [{"name": "LicenseCheck", "options": {"timeout": "soon"}}]}
+			}}`,
+			refreshed: false,
+			profiles:  []string{"new", "test"},
+		},
+		{
+			name:      "Removed profile",
+			content:   `{"profiles": {"test": {"name": "test", "validations": [{"name": "EOLCheck"}]}}}`,
+			refreshed: true,
+			profiles:  []string{"test"},
+		},
+	}
+
+	for index, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(profilesFile, []byte(tt.content), 0644))
+
+			// Make sure the modification time changes, even on file systems with a coarse resolution
+			modTime := time.Now().Add(time.Duration(index+1) * time.Second)
+			require.NoError(t, os.Chtimes(profilesFile, modTime, modTime))
+
+			assert.Equal(t, tt.refreshed, watcher.check())
+			assert.Equal(t, tt.profiles, engine.GetProfiles().GetProfileNames())
+		})
+	}
+}
+
+// TestNewProfilesWatcher tests that the watcher's poll interval is read from the ENV.
+func TestNewProfilesWatcher(t *testing.T) {
+	logger := zaptest.NewLogger(t, zaptest.Level(utils.GetLogLevel()))
+	t.Setenv(config.ConfigFile, "../testdata/test_profiles.json")
+
+	engine, err := NewEngine(logger)
+	require.NoError(t, err)
+
+	t.Setenv(config.PollInterval, "0")
+	watcher, err := NewProfilesWatcher(engine)
+	assert.NoError(t, err)
+	assert.Nil(t, watcher)
+
+	t.Setenv(config.PollInterval, "bogus")
+	_, err = NewProfilesWatcher(engine)
+	assert.Error(t, err)
+
+	t.Setenv(config.PollInterval, "1m")
+	watcher, err = NewProfilesWatcher(engine)
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, watcher.interval)
+	watcher.Close()
+}